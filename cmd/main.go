@@ -4,20 +4,34 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
+	"os/signal"
 	"time"
 
 	"github.com/Henelik/tricaster/pkg/renderer"
 	"gopkg.in/yaml.v3"
 )
 
-var filename string
+var (
+	filename string
+	output   string
+	format   string
+	toneMap  string
+	exposure float64
+)
 
 func init() {
 	flag.StringVar(&filename, "f", "scene.yml", "file path for the scene to render")
+	flag.StringVar(&output, "o", "", "file path for the rendered image, whose extension decides the format, overriding the scene file")
+	flag.StringVar(&format, "format", "", "output format (png, jpg, ppm, tiff, pfm, hdr or exr) when no file path is given, overriding the scene file")
+	flag.StringVar(&toneMap, "tonemap", "", "tone mapping for 8 and 16-bit output (linear, reinhard or aces), overriding the scene file")
+	flag.Float64Var(&exposure, "exposure", 0, "exposure of 8 and 16-bit output in stops, overriding the scene file")
 }
 
 func main() {
@@ -37,11 +51,46 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// flags which were given override the scene file
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "o":
+			config.Output.File = output
+		case "format":
+			config.Output.Format = format
+		case "tonemap":
+			config.Output.ToneMap = toneMap
+		case "exposure":
+			config.Output.Exposure = exposure
+		}
+	})
+
 	scene := renderer.NewScene(config)
 
+	// the first Ctrl-C stops the render and saves what's done, and a second one quits straight away
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
 	fmt.Printf("rendering scene %s\n", filename)
 
-	scene.Render()
+	err = scene.Render(ctx, func(p renderer.Progress) {
+		drawProgress(os.Stderr, p)
+	})
+	fmt.Fprintln(os.Stderr)
+
+	if errors.Is(err, context.Canceled) {
+		fmt.Printf("render stopped, saved what was rendered to %s\n", scene.File)
+		os.Exit(1)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	fmt.Printf("render took %s\n", time.Since(start))
 }
@@ -0,0 +1,45 @@
+//go:build !test
+// +build !test
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Henelik/tricaster/pkg/renderer"
+)
+
+// progressBarWidth is the number of characters in the bar itself
+const progressBarWidth = 30
+
+// drawProgress redraws the progress bar over the current line of the terminal
+func drawProgress(w io.Writer, p renderer.Progress) {
+	filled := int(p.Fraction() * progressBarWidth)
+
+	// the trailing spaces clear what's left of a longer line
+	fmt.Fprintf(w, "\r[%s%s] %3.0f%%  %d/%d tiles  %s rays  ETA %s    ",
+		strings.Repeat("#", filled),
+		strings.Repeat("-", progressBarWidth-filled),
+		p.Fraction()*100,
+		p.TilesDone,
+		p.Tiles,
+		formatCount(p.Rays),
+		p.ETA.Round(time.Second))
+}
+
+// formatCount shortens a large number with a k, M or G suffix
+func formatCount(n int64) string {
+	switch {
+	case n >= 1e9:
+		return fmt.Sprintf("%.1fG", float64(n)/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 1e3:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	default:
+		return fmt.Sprint(n)
+	}
+}
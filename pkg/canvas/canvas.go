@@ -2,11 +2,10 @@ package canvas
 
 import (
 	"image"
+	stdcolor "image/color"
 	"image/png"
 	"os"
 
-	"github.com/Henelik/tricaster/pkg/util"
-
 	"github.com/Henelik/tricaster/pkg/color"
 )
 
@@ -48,6 +47,7 @@ func (c *Canvas) SetSafe(x, y int, col *color.Color) {
 	c.Pix[x+y*c.W].B = col.B
 }
 
+// ToImage converts the canvas to an 8-bit image, rounding each channel to the nearest level
 func (c *Canvas) ToImage() *image.RGBA {
 	img := image.NewRGBA(image.Rect(0, 0, c.W, c.H))
 	var r, g, b uint8
@@ -56,9 +56,9 @@ func (c *Canvas) ToImage() *image.RGBA {
 	for x := 0; x < c.W; x++ {
 		for y := 0; y < c.H; y++ {
 			i = x + y*c.W
-			r = uint8(util.Clamp(c.Pix[i].R, 0, 1) * 255)
-			g = uint8(util.Clamp(c.Pix[i].G, 0, 1) * 255)
-			b = uint8(util.Clamp(c.Pix[i].B, 0, 1) * 255)
+			r = uint8(quantize(c.Pix[i].R, 0xff))
+			g = uint8(quantize(c.Pix[i].G, 0xff))
+			b = uint8(quantize(c.Pix[i].B, 0xff))
 			img.Pix[i*4] = r
 			img.Pix[i*4+1] = g
 			img.Pix[i*4+2] = b
@@ -69,6 +69,25 @@ func (c *Canvas) ToImage() *image.RGBA {
 	return img
 }
 
+// ToImage16 converts the canvas to a 16-bit image, rounding each channel to the nearest level
+func (c *Canvas) ToImage16() *image.RGBA64 {
+	img := image.NewRGBA64(image.Rect(0, 0, c.W, c.H))
+
+	for y := 0; y < c.H; y++ {
+		for x := 0; x < c.W; x++ {
+			p := c.Get(x, y)
+			img.SetRGBA64(x, y, stdcolor.RGBA64{
+				R: uint16(quantize(p.R, 0xffff)),
+				G: uint16(quantize(p.G, 0xffff)),
+				B: uint16(quantize(p.B, 0xffff)),
+				A: 0xffff,
+			})
+		}
+	}
+
+	return img
+}
+
 func (c *Canvas) SaveImage(name string) error {
 	img := c.ToImage()
 
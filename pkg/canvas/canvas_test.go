@@ -2,6 +2,7 @@ package canvas
 
 import (
 	"image"
+	stdcolor "image/color"
 	"testing"
 
 	"github.com/Henelik/tricaster/pkg/color"
@@ -77,15 +78,31 @@ func TestToImage(t *testing.T) {
 
 	img := canv.ToImage()
 
-	wantPix := []byte{0x0, 0x0, 0x0, 0xff, 0x7, 0x0, 0x0, 0xff, 0xf, 0x0, 0x0, 0xff, 0x17, 0x0, 0x0, 0xff, 0x1f, 0x0, 0x0, 0xff, 0x27, 0x0, 0x0, 0xff, 0x2f, 0x0, 0x0, 0xff, 0x37, 0x0, 0x0, 0xff, 0x3f, 0x0, 0x0, 0xff, 0x47, 0x0, 0x0, 0xff, 0x4f, 0x0, 0x0, 0xff, 0x57, 0x0, 0x0, 0xff, 0x5f, 0x0, 0x0, 0xff, 0x67, 0x0, 0x0, 0xff, 0x6f, 0x0, 0x0, 0xff, 0x77, 0x0, 0x0, 0xff, 0x7f, 0x0, 0x0, 0xff, 0x87, 0x0, 0x0, 0xff, 0x8f, 0x0, 0x0, 0xff, 0x97, 0x0, 0x0, 0xff, 0x9f, 0x0, 0x0, 0xff, 0xa7, 0x0, 0x0, 0xff, 0xaf, 0x0, 0x0, 0xff, 0xb7, 0x0, 0x0, 0xff, 0xbf, 0x0, 0x0, 0xff, 0xc7, 0x0, 0x0, 0xff, 0xcf, 0x0, 0x0, 0xff, 0xd7, 0x0, 0x0, 0xff, 0xdf, 0x0, 0x0, 0xff, 0xe7, 0x0, 0x0, 0xff, 0xef, 0x0, 0x0, 0xff, 0xf7, 0x0, 0x0, 0xff, 0x0, 0x7, 0x0, 0xff, 0x7, 0x7, 0x0, 0xff, 0xf, 0x7, 0x0, 0xff, 0x17, 0x7, 0x0, 0xff, 0x1f, 0x7, 0x0, 0xff, 0x27, 0x7, 0x0, 0xff, 0x2f, 0x7, 0x0, 0xff, 0x37, 0x7, 0x0, 0xff, 0x3f, 0x7, 0x0, 0xff, 0x47, 0x7, 0x0, 0xff, 0x4f, 0x7, 0x0, 0xff, 0x57, 0x7, 0x0, 0xff, 0x5f, 0x7, 0x0, 0xff, 0x67, 0x7, 0x0, 0xff, 0x6f, 0x7, 0x0, 0xff, 0x77, 0x7, 0x0, 0xff, 0x7f, 0x7, 0x0, 0xff, 0x87, 0x7, 0x0, 0xff, 0x8f, 0x7, 0x0, 0xff, 0x97, 0x7, 0x0, 0xff, 0x9f, 0x7, 0x0, 0xff, 0xa7, 0x7, 0x0, 0xff, 0xaf, 0x7, 0x0, 0xff, 0xb7, 0x7, 0x0, 0xff, 0xbf, 0x7, 0x0, 0xff, 0xc7, 0x7, 0x0, 0xff, 0xcf, 0x7, 0x0, 0xff, 0xd7, 0x7, 0x0, 0xff, 0xdf, 0x7, 0x0, 0xff, 0xe7, 0x7, 0x0, 0xff, 0xef, 0x7, 0x0, 0xff, 0xf7, 0x7, 0x0, 0xff, 0x0, 0xf, 0x0, 0xff, 0x7, 0xf, 0x0, 0xff, 0xf, 0xf, 0x0, 0xff, 0x17, 0xf, 0x0, 0xff, 0x1f, 0xf, 0x0, 0xff, 0x27, 0xf, 0x0, 0xff, 0x2f, 0xf, 0x0, 0xff, 0x37, 0xf, 0x0, 0xff, 0x3f, 0xf, 0x0, 0xff, 0x47, 0xf, 0x0, 0xff, 0x4f, 0xf, 0x0, 0xff, 0x57, 0xf, 0x0, 0xff, 0x5f, 0xf, 0x0, 0xff, 0x67, 0xf, 0x0, 0xff, 0x6f, 0xf, 0x0, 0xff, 0x77, 0xf, 0x0, 0xff, 0x7f, 0xf, 0x0, 0xff, 0x87, 0xf, 0x0, 0xff, 0x8f, 0xf, 0x0, 0xff, 0x97, 0xf, 0x0, 0xff, 0x9f, 0xf, 0x0, 0xff, 0xa7, 0xf, 0x0, 0xff, 0xaf, 0xf, 0x0, 0xff, 0xb7, 0xf, 0x0, 0xff, 0xbf, 0xf, 0x0, 0xff, 0xc7, 0xf, 0x0, 0xff, 0xcf, 0xf, 0x0, 0xff, 0xd7, 0xf, 0x0, 0xff, 0xdf, 0xf, 0x0, 0xff, 0xe7, 0xf, 0x0, 0xff, 0xef, 0xf, 0x0, 0xff, 0xf7, 0xf, 0x0, 0xff, 0x0, 0x17, 0x0, 0xff, 0x7, 0x17, 0x0, 0xff, 0xf, 0x17, 0x0, 0xff, 0x17, 0x17, 0x0, 0xff, 0x1f, 0x17, 0x0, 0xff, 0x27, 0x17, 0x0, 0xff, 0x2f, 0x17, 0x0, 0xff, 0x37, 0x17, 0x0, 0xff, 0x3f, 0x17, 0x0, 0xff, 0x47, 0x17, 0x0, 0xff, 0x4f, 0x17, 0x0, 0xff, 0x57, 0x17, 0x0, 0xff, 0x5f, 0x17, 0x0, 0xff, 0x67, 0x17, 0x0, 0xff, 0x6f, 0x17, 0x0, 0xff, 0x77, 0x17, 0x0, 0xff, 0x7f, 0x17, 0x0, 0xff, 0x87, 0x17, 0x0, 0xff, 0x8f, 0x17, 0x0, 0xff, 0x97, 0x17, 0x0, 0xff, 0x9f, 0x17, 0x0, 0xff, 0xa7, 0x17, 0x0, 0xff, 0xaf, 0x17, 0x0, 0xff, 0xb7, 0x17, 0x0, 0xff, 0xbf, 0x17, 0x0, 0xff, 0xc7, 0x17, 0x0, 0xff, 0xcf, 0x17, 0x0, 0xff, 0xd7, 0x17, 0x0, 0xff, 0xdf, 0x17, 0x0, 0xff, 0xe7, 0x17, 0x0, 0xff, 0xef, 0x17, 0x0, 0xff, 0xf7, 0x17, 0x0, 0xff, 0x0, 0x1f, 0x0, 0xff, 0x7, 0x1f, 0x0, 0xff, 0xf, 0x1f, 0x0, 0xff, 0x17, 0x1f, 0x0, 0xff, 0x1f, 0x1f, 0x0, 0xff, 0x27, 0x1f, 0x0, 0xff, 0x2f, 0x1f, 0x0, 0xff, 0x37, 0x1f, 0x0, 0xff, 0x3f, 0x1f, 0x0, 0xff, 0x47, 0x1f, 0x0, 0xff, 0x4f, 0x1f, 0x0, 0xff, 0x57, 0x1f, 0x0, 0xff, 0x5f, 0x1f, 0x0, 0xff, 0x67, 0x1f, 0x0, 0xff, 0x6f, 0x1f, 0x0, 0xff, 0x77, 0x1f, 0x0, 0xff, 0x7f, 0x1f, 0x0, 0xff, 0x87, 0x1f, 0x0, 0xff, 0x8f, 0x1f, 0x0, 0xff, 0x97, 0x1f, 0x0, 0xff, 0x9f, 0x1f, 0x0, 0xff, 0xa7, 0x1f, 0x0, 0xff, 0xaf, 0x1f, 0x0, 0xff, 0xb7, 0x1f, 0x0, 0xff, 0xbf, 0x1f, 0x0, 0xff, 0xc7, 0x1f, 0x0, 0xff, 0xcf, 0x1f, 0x0, 0xff, 0xd7, 0x1f, 0x0, 0xff, 0xdf, 0x1f, 0x0, 0xff, 0xe7, 0x1f, 0x0, 0xff, 0xef, 0x1f, 0x0, 0xff, 0xf7, 0x1f, 0x0, 0xff, 0x0, 0x27, 0x0, 0xff, 0x7, 0x27, 0x0, 0xff, 0xf, 0x27, 0x0, 0xff, 0x17, 0x27, 0x0, 0xff, 0x1f, 0x27, 0x0, 0xff, 0x27, 0x27, 0x0, 0xff, 0x2f, 0x27, 0x0, 0xff, 0x37, 0x27, 0x0, 0xff, 0x3f, 0x27, 0x0, 0xff, 0x47, 0x27, 0x0, 0xff, 0x4f, 0x27, 0x0, 0xff, 0x57, 0x27, 0x0, 0xff, 0x5f, 0x27, 0x0, 0xff, 0x67, 0x27, 0x0, 0xff, 0x6f, 0x27, 0x0, 0xff, 0x77, 0x27, 0x0, 0xff, 0x7f, 0x27, 0x0, 0xff, 0x87, 0x27, 0x0, 0xff, 0x8f, 0x27, 0x0, 0xff, 0x97, 0x27, 0x0, 0xff, 0x9f, 0x27, 0x0, 0xff, 0xa7, 0x27, 0x0, 0xff, 0xaf, 0x27, 0x0, 0xff, 0xb7, 0x27, 0x0, 0xff, 0xbf, 0x27, 0x0, 0xff, 0xc7, 0x27, 0x0, 0xff, 0xcf, 0x27, 0x0, 0xff, 0xd7, 0x27, 0x0, 0xff, 0xdf, 0x27, 0x0, 0xff, 0xe7, 0x27, 0x0, 0xff, 0xef, 0x27, 0x0, 0xff, 0xf7, 0x27, 0x0, 0xff, 0x0, 0x2f, 0x0, 0xff, 0x7, 0x2f, 0x0, 0xff, 0xf, 0x2f, 0x0, 0xff, 0x17, 0x2f, 0x0, 0xff, 0x1f, 0x2f, 0x0, 0xff, 0x27, 0x2f, 0x0, 0xff, 0x2f, 0x2f, 0x0, 0xff, 0x37, 0x2f, 0x0, 0xff, 0x3f, 0x2f, 0x0, 0xff, 0x47, 0x2f, 0x0, 0xff, 0x4f, 0x2f, 0x0, 0xff, 0x57, 0x2f, 0x0, 0xff, 0x5f, 0x2f, 0x0, 0xff, 0x67, 0x2f, 0x0, 0xff, 0x6f, 0x2f, 0x0, 0xff, 0x77, 0x2f, 0x0, 0xff, 0x7f, 0x2f, 0x0, 0xff, 0x87, 0x2f, 0x0, 0xff, 0x8f, 0x2f, 0x0, 0xff, 0x97, 0x2f, 0x0, 0xff, 0x9f, 0x2f, 0x0, 0xff, 0xa7, 0x2f, 0x0, 0xff, 0xaf, 0x2f, 0x0, 0xff, 0xb7, 0x2f, 0x0, 0xff, 0xbf, 0x2f, 0x0, 0xff, 0xc7, 0x2f, 0x0, 0xff, 0xcf, 0x2f, 0x0, 0xff, 0xd7, 0x2f, 0x0, 0xff, 0xdf, 0x2f, 0x0, 0xff, 0xe7, 0x2f, 0x0, 0xff, 0xef, 0x2f, 0x0, 0xff, 0xf7, 0x2f, 0x0, 0xff, 0x0, 0x37, 0x0, 0xff, 0x7, 0x37, 0x0, 0xff, 0xf, 0x37, 0x0, 0xff, 0x17, 0x37, 0x0, 0xff, 0x1f, 0x37, 0x0, 0xff, 0x27, 0x37, 0x0, 0xff, 0x2f, 0x37, 0x0, 0xff, 0x37, 0x37, 0x0, 0xff, 0x3f, 0x37, 0x0, 0xff, 0x47, 0x37, 0x0, 0xff, 0x4f, 0x37, 0x0, 0xff, 0x57, 0x37, 0x0, 0xff, 0x5f, 0x37, 0x0, 0xff, 0x67, 0x37, 0x0, 0xff, 0x6f, 0x37, 0x0, 0xff, 0x77, 0x37, 0x0, 0xff, 0x7f, 0x37, 0x0, 0xff, 0x87, 0x37, 0x0, 0xff, 0x8f, 0x37, 0x0, 0xff, 0x97, 0x37, 0x0, 0xff, 0x9f, 0x37, 0x0, 0xff, 0xa7, 0x37, 0x0, 0xff, 0xaf, 0x37, 0x0, 0xff, 0xb7, 0x37, 0x0, 0xff, 0xbf, 0x37, 0x0, 0xff, 0xc7, 0x37, 0x0, 0xff, 0xcf, 0x37, 0x0, 0xff, 0xd7, 0x37, 0x0, 0xff, 0xdf, 0x37, 0x0, 0xff, 0xe7, 0x37, 0x0, 0xff, 0xef, 0x37, 0x0, 0xff, 0xf7, 0x37, 0x0, 0xff, 0x0, 0x3f, 0x0, 0xff, 0x7, 0x3f, 0x0, 0xff, 0xf, 0x3f, 0x0, 0xff, 0x17, 0x3f, 0x0, 0xff, 0x1f, 0x3f, 0x0, 0xff, 0x27, 0x3f, 0x0, 0xff, 0x2f, 0x3f, 0x0, 0xff, 0x37, 0x3f, 0x0, 0xff, 0x3f, 0x3f, 0x0, 0xff, 0x47, 0x3f, 0x0, 0xff, 0x4f, 0x3f, 0x0, 0xff, 0x57, 0x3f, 0x0, 0xff, 0x5f, 0x3f, 0x0, 0xff, 0x67, 0x3f, 0x0, 0xff, 0x6f, 0x3f, 0x0, 0xff, 0x77, 0x3f, 0x0, 0xff, 0x7f, 0x3f, 0x0, 0xff, 0x87, 0x3f, 0x0, 0xff, 0x8f, 0x3f, 0x0, 0xff, 0x97, 0x3f, 0x0, 0xff, 0x9f, 0x3f, 0x0, 0xff, 0xa7, 0x3f, 0x0, 0xff, 0xaf, 0x3f, 0x0, 0xff, 0xb7, 0x3f, 0x0, 0xff, 0xbf, 0x3f, 0x0, 0xff, 0xc7, 0x3f, 0x0, 0xff, 0xcf, 0x3f, 0x0, 0xff, 0xd7, 0x3f, 0x0, 0xff, 0xdf, 0x3f, 0x0, 0xff, 0xe7, 0x3f, 0x0, 0xff, 0xef, 0x3f, 0x0, 0xff, 0xf7, 0x3f, 0x0, 0xff, 0x0, 0x47, 0x0, 0xff, 0x7, 0x47, 0x0, 0xff, 0xf, 0x47, 0x0, 0xff, 0x17, 0x47, 0x0, 0xff, 0x1f, 0x47, 0x0, 0xff, 0x27, 0x47, 0x0, 0xff, 0x2f, 0x47, 0x0, 0xff, 0x37, 0x47, 0x0, 0xff, 0x3f, 0x47, 0x0, 0xff, 0x47, 0x47, 0x0, 0xff, 0x4f, 0x47, 0x0, 0xff, 0x57, 0x47, 0x0, 0xff, 0x5f, 0x47, 0x0, 0xff, 0x67, 0x47, 0x0, 0xff, 0x6f, 0x47, 0x0, 0xff, 0x77, 0x47, 0x0, 0xff, 0x7f, 0x47, 0x0, 0xff, 0x87, 0x47, 0x0, 0xff, 0x8f, 0x47, 0x0, 0xff, 0x97, 0x47, 0x0, 0xff, 0x9f, 0x47, 0x0, 0xff, 0xa7, 0x47, 0x0, 0xff, 0xaf, 0x47, 0x0, 0xff, 0xb7, 0x47, 0x0, 0xff, 0xbf, 0x47, 0x0, 0xff, 0xc7, 0x47, 0x0, 0xff, 0xcf, 0x47, 0x0, 0xff, 0xd7, 0x47, 0x0, 0xff, 0xdf, 0x47, 0x0, 0xff, 0xe7, 0x47, 0x0, 0xff, 0xef, 0x47, 0x0, 0xff, 0xf7, 0x47, 0x0, 0xff, 0x0, 0x4f, 0x0, 0xff, 0x7, 0x4f, 0x0, 0xff, 0xf, 0x4f, 0x0, 0xff, 0x17, 0x4f, 0x0, 0xff, 0x1f, 0x4f, 0x0, 0xff, 0x27, 0x4f, 0x0, 0xff, 0x2f, 0x4f, 0x0, 0xff, 0x37, 0x4f, 0x0, 0xff, 0x3f, 0x4f, 0x0, 0xff, 0x47, 0x4f, 0x0, 0xff, 0x4f, 0x4f, 0x0, 0xff, 0x57, 0x4f, 0x0, 0xff, 0x5f, 0x4f, 0x0, 0xff, 0x67, 0x4f, 0x0, 0xff, 0x6f, 0x4f, 0x0, 0xff, 0x77, 0x4f, 0x0, 0xff, 0x7f, 0x4f, 0x0, 0xff, 0x87, 0x4f, 0x0, 0xff, 0x8f, 0x4f, 0x0, 0xff, 0x97, 0x4f, 0x0, 0xff, 0x9f, 0x4f, 0x0, 0xff, 0xa7, 0x4f, 0x0, 0xff, 0xaf, 0x4f, 0x0, 0xff, 0xb7, 0x4f, 0x0, 0xff, 0xbf, 0x4f, 0x0, 0xff, 0xc7, 0x4f, 0x0, 0xff, 0xcf, 0x4f, 0x0, 0xff, 0xd7, 0x4f, 0x0, 0xff, 0xdf, 0x4f, 0x0, 0xff, 0xe7, 0x4f, 0x0, 0xff, 0xef, 0x4f, 0x0, 0xff, 0xf7, 0x4f, 0x0, 0xff, 0x0, 0x57, 0x0, 0xff, 0x7, 0x57, 0x0, 0xff, 0xf, 0x57, 0x0, 0xff, 0x17, 0x57, 0x0, 0xff, 0x1f, 0x57, 0x0, 0xff, 0x27, 0x57, 0x0, 0xff, 0x2f, 0x57, 0x0, 0xff, 0x37, 0x57, 0x0, 0xff, 0x3f, 0x57, 0x0, 0xff, 0x47, 0x57, 0x0, 0xff, 0x4f, 0x57, 0x0, 0xff, 0x57, 0x57, 0x0, 0xff, 0x5f, 0x57, 0x0, 0xff, 0x67, 0x57, 0x0, 0xff, 0x6f, 0x57, 0x0, 0xff, 0x77, 0x57, 0x0, 0xff, 0x7f, 0x57, 0x0, 0xff, 0x87, 0x57, 0x0, 0xff, 0x8f, 0x57, 0x0, 0xff, 0x97, 0x57, 0x0, 0xff, 0x9f, 0x57, 0x0, 0xff, 0xa7, 0x57, 0x0, 0xff, 0xaf, 0x57, 0x0, 0xff, 0xb7, 0x57, 0x0, 0xff, 0xbf, 0x57, 0x0, 0xff, 0xc7, 0x57, 0x0, 0xff, 0xcf, 0x57, 0x0, 0xff, 0xd7, 0x57, 0x0, 0xff, 0xdf, 0x57, 0x0, 0xff, 0xe7, 0x57, 0x0, 0xff, 0xef, 0x57, 0x0, 0xff, 0xf7, 0x57, 0x0, 0xff, 0x0, 0x5f, 0x0, 0xff, 0x7, 0x5f, 0x0, 0xff, 0xf, 0x5f, 0x0, 0xff, 0x17, 0x5f, 0x0, 0xff, 0x1f, 0x5f, 0x0, 0xff, 0x27, 0x5f, 0x0, 0xff, 0x2f, 0x5f, 0x0, 0xff, 0x37, 0x5f, 0x0, 0xff, 0x3f, 0x5f, 0x0, 0xff, 0x47, 0x5f, 0x0, 0xff, 0x4f, 0x5f, 0x0, 0xff, 0x57, 0x5f, 0x0, 0xff, 0x5f, 0x5f, 0x0, 0xff, 0x67, 0x5f, 0x0, 0xff, 0x6f, 0x5f, 0x0, 0xff, 0x77, 0x5f, 0x0, 0xff, 0x7f, 0x5f, 0x0, 0xff, 0x87, 0x5f, 0x0, 0xff, 0x8f, 0x5f, 0x0, 0xff, 0x97, 0x5f, 0x0, 0xff, 0x9f, 0x5f, 0x0, 0xff, 0xa7, 0x5f, 0x0, 0xff, 0xaf, 0x5f, 0x0, 0xff, 0xb7, 0x5f, 0x0, 0xff, 0xbf, 0x5f, 0x0, 0xff, 0xc7, 0x5f, 0x0, 0xff, 0xcf, 0x5f, 0x0, 0xff, 0xd7, 0x5f, 0x0, 0xff, 0xdf, 0x5f, 0x0, 0xff, 0xe7, 0x5f, 0x0, 0xff, 0xef, 0x5f, 0x0, 0xff, 0xf7, 0x5f, 0x0, 0xff, 0x0, 0x67, 0x0, 0xff, 0x7, 0x67, 0x0, 0xff, 0xf, 0x67, 0x0, 0xff, 0x17, 0x67, 0x0, 0xff, 0x1f, 0x67, 0x0, 0xff, 0x27, 0x67, 0x0, 0xff, 0x2f, 0x67, 0x0, 0xff, 0x37, 0x67, 0x0, 0xff, 0x3f, 0x67, 0x0, 0xff, 0x47, 0x67, 0x0, 0xff, 0x4f, 0x67, 0x0, 0xff, 0x57, 0x67, 0x0, 0xff, 0x5f, 0x67, 0x0, 0xff, 0x67, 0x67, 0x0, 0xff, 0x6f, 0x67, 0x0, 0xff, 0x77, 0x67, 0x0, 0xff, 0x7f, 0x67, 0x0, 0xff, 0x87, 0x67, 0x0, 0xff, 0x8f, 0x67, 0x0, 0xff, 0x97, 0x67, 0x0, 0xff, 0x9f, 0x67, 0x0, 0xff, 0xa7, 0x67, 0x0, 0xff, 0xaf, 0x67, 0x0, 0xff, 0xb7, 0x67, 0x0, 0xff, 0xbf, 0x67, 0x0, 0xff, 0xc7, 0x67, 0x0, 0xff, 0xcf, 0x67, 0x0, 0xff, 0xd7, 0x67, 0x0, 0xff, 0xdf, 0x67, 0x0, 0xff, 0xe7, 0x67, 0x0, 0xff, 0xef, 0x67, 0x0, 0xff, 0xf7, 0x67, 0x0, 0xff, 0x0, 0x6f, 0x0, 0xff, 0x7, 0x6f, 0x0, 0xff, 0xf, 0x6f, 0x0, 0xff, 0x17, 0x6f, 0x0, 0xff, 0x1f, 0x6f, 0x0, 0xff, 0x27, 0x6f, 0x0, 0xff, 0x2f, 0x6f, 0x0, 0xff, 0x37, 0x6f, 0x0, 0xff, 0x3f, 0x6f, 0x0, 0xff, 0x47, 0x6f, 0x0, 0xff, 0x4f, 0x6f, 0x0, 0xff, 0x57, 0x6f, 0x0, 0xff, 0x5f, 0x6f, 0x0, 0xff, 0x67, 0x6f, 0x0, 0xff, 0x6f, 0x6f, 0x0, 0xff, 0x77, 0x6f, 0x0, 0xff, 0x7f, 0x6f, 0x0, 0xff, 0x87, 0x6f, 0x0, 0xff, 0x8f, 0x6f, 0x0, 0xff, 0x97, 0x6f, 0x0, 0xff, 0x9f, 0x6f, 0x0, 0xff, 0xa7, 0x6f, 0x0, 0xff, 0xaf, 0x6f, 0x0, 0xff, 0xb7, 0x6f, 0x0, 0xff, 0xbf, 0x6f, 0x0, 0xff, 0xc7, 0x6f, 0x0, 0xff, 0xcf, 0x6f, 0x0, 0xff, 0xd7, 0x6f, 0x0, 0xff, 0xdf, 0x6f, 0x0, 0xff, 0xe7, 0x6f, 0x0, 0xff, 0xef, 0x6f, 0x0, 0xff, 0xf7, 0x6f, 0x0, 0xff, 0x0, 0x77, 0x0, 0xff, 0x7, 0x77, 0x0, 0xff, 0xf, 0x77, 0x0, 0xff, 0x17, 0x77, 0x0, 0xff, 0x1f, 0x77, 0x0, 0xff, 0x27, 0x77, 0x0, 0xff, 0x2f, 0x77, 0x0, 0xff, 0x37, 0x77, 0x0, 0xff, 0x3f, 0x77, 0x0, 0xff, 0x47, 0x77, 0x0, 0xff, 0x4f, 0x77, 0x0, 0xff, 0x57, 0x77, 0x0, 0xff, 0x5f, 0x77, 0x0, 0xff, 0x67, 0x77, 0x0, 0xff, 0x6f, 0x77, 0x0, 0xff, 0x77, 0x77, 0x0, 0xff, 0x7f, 0x77, 0x0, 0xff, 0x87, 0x77, 0x0, 0xff, 0x8f, 0x77, 0x0, 0xff, 0x97, 0x77, 0x0, 0xff, 0x9f, 0x77, 0x0, 0xff, 0xa7, 0x77, 0x0, 0xff, 0xaf, 0x77, 0x0, 0xff, 0xb7, 0x77, 0x0, 0xff, 0xbf, 0x77, 0x0, 0xff, 0xc7, 0x77, 0x0, 0xff, 0xcf, 0x77, 0x0, 0xff, 0xd7, 0x77, 0x0, 0xff, 0xdf, 0x77, 0x0, 0xff, 0xe7, 0x77, 0x0, 0xff, 0xef, 0x77, 0x0, 0xff, 0xf7, 0x77, 0x0, 0xff, 0x0, 0x7f, 0x0, 0xff, 0x7, 0x7f, 0x0, 0xff, 0xf, 0x7f, 0x0, 0xff, 0x17, 0x7f, 0x0, 0xff, 0x1f, 0x7f, 0x0, 0xff, 0x27, 0x7f, 0x0, 0xff, 0x2f, 0x7f, 0x0, 0xff, 0x37, 0x7f, 0x0, 0xff, 0x3f, 0x7f, 0x0, 0xff, 0x47, 0x7f, 0x0, 0xff, 0x4f, 0x7f, 0x0, 0xff, 0x57, 0x7f, 0x0, 0xff, 0x5f, 0x7f, 0x0, 0xff, 0x67, 0x7f, 0x0, 0xff, 0x6f, 0x7f, 0x0, 0xff, 0x77, 0x7f, 0x0, 0xff, 0x7f, 0x7f, 0x0, 0xff, 0x87, 0x7f, 0x0, 0xff, 0x8f, 0x7f, 0x0, 0xff, 0x97, 0x7f, 0x0, 0xff, 0x9f, 0x7f, 0x0, 0xff, 0xa7, 0x7f, 0x0, 0xff, 0xaf, 0x7f, 0x0, 0xff, 0xb7, 0x7f, 0x0, 0xff, 0xbf, 0x7f, 0x0, 0xff, 0xc7, 0x7f, 0x0, 0xff, 0xcf, 0x7f, 0x0, 0xff, 0xd7, 0x7f, 0x0, 0xff, 0xdf, 0x7f, 0x0, 0xff, 0xe7, 0x7f, 0x0, 0xff, 0xef, 0x7f, 0x0, 0xff, 0xf7, 0x7f, 0x0, 0xff, 0x0, 0x87, 0x0, 0xff, 0x7, 0x87, 0x0, 0xff, 0xf, 0x87, 0x0, 0xff, 0x17, 0x87, 0x0, 0xff, 0x1f, 0x87, 0x0, 0xff, 0x27, 0x87, 0x0, 0xff, 0x2f, 0x87, 0x0, 0xff, 0x37, 0x87, 0x0, 0xff, 0x3f, 0x87, 0x0, 0xff, 0x47, 0x87, 0x0, 0xff, 0x4f, 0x87, 0x0, 0xff, 0x57, 0x87, 0x0, 0xff, 0x5f, 0x87, 0x0, 0xff, 0x67, 0x87, 0x0, 0xff, 0x6f, 0x87, 0x0, 0xff, 0x77, 0x87, 0x0, 0xff, 0x7f, 0x87, 0x0, 0xff, 0x87, 0x87, 0x0, 0xff, 0x8f, 0x87, 0x0, 0xff, 0x97, 0x87, 0x0, 0xff, 0x9f, 0x87, 0x0, 0xff, 0xa7, 0x87, 0x0, 0xff, 0xaf, 0x87, 0x0, 0xff, 0xb7, 0x87, 0x0, 0xff, 0xbf, 0x87, 0x0, 0xff, 0xc7, 0x87, 0x0, 0xff, 0xcf, 0x87, 0x0, 0xff, 0xd7, 0x87, 0x0, 0xff, 0xdf, 0x87, 0x0, 0xff, 0xe7, 0x87, 0x0, 0xff, 0xef, 0x87, 0x0, 0xff, 0xf7, 0x87, 0x0, 0xff, 0x0, 0x8f, 0x0, 0xff, 0x7, 0x8f, 0x0, 0xff, 0xf, 0x8f, 0x0, 0xff, 0x17, 0x8f, 0x0, 0xff, 0x1f, 0x8f, 0x0, 0xff, 0x27, 0x8f, 0x0, 0xff, 0x2f, 0x8f, 0x0, 0xff, 0x37, 0x8f, 0x0, 0xff, 0x3f, 0x8f, 0x0, 0xff, 0x47, 0x8f, 0x0, 0xff, 0x4f, 0x8f, 0x0, 0xff, 0x57, 0x8f, 0x0, 0xff, 0x5f, 0x8f, 0x0, 0xff, 0x67, 0x8f, 0x0, 0xff, 0x6f, 0x8f, 0x0, 0xff, 0x77, 0x8f, 0x0, 0xff, 0x7f, 0x8f, 0x0, 0xff, 0x87, 0x8f, 0x0, 0xff, 0x8f, 0x8f, 0x0, 0xff, 0x97, 0x8f, 0x0, 0xff, 0x9f, 0x8f, 0x0, 0xff, 0xa7, 0x8f, 0x0, 0xff, 0xaf, 0x8f, 0x0, 0xff, 0xb7, 0x8f, 0x0, 0xff, 0xbf, 0x8f, 0x0, 0xff, 0xc7, 0x8f, 0x0, 0xff, 0xcf, 0x8f, 0x0, 0xff, 0xd7, 0x8f, 0x0, 0xff, 0xdf, 0x8f, 0x0, 0xff, 0xe7, 0x8f, 0x0, 0xff, 0xef, 0x8f, 0x0, 0xff, 0xf7, 0x8f, 0x0, 0xff, 0x0, 0x97, 0x0, 0xff, 0x7, 0x97, 0x0, 0xff, 0xf, 0x97, 0x0, 0xff, 0x17, 0x97, 0x0, 0xff, 0x1f, 0x97, 0x0, 0xff, 0x27, 0x97, 0x0, 0xff, 0x2f, 0x97, 0x0, 0xff, 0x37, 0x97, 0x0, 0xff, 0x3f, 0x97, 0x0, 0xff, 0x47, 0x97, 0x0, 0xff, 0x4f, 0x97, 0x0, 0xff, 0x57, 0x97, 0x0, 0xff, 0x5f, 0x97, 0x0, 0xff, 0x67, 0x97, 0x0, 0xff, 0x6f, 0x97, 0x0, 0xff, 0x77, 0x97, 0x0, 0xff, 0x7f, 0x97, 0x0, 0xff, 0x87, 0x97, 0x0, 0xff, 0x8f, 0x97, 0x0, 0xff, 0x97, 0x97, 0x0, 0xff, 0x9f, 0x97, 0x0, 0xff, 0xa7, 0x97, 0x0, 0xff, 0xaf, 0x97, 0x0, 0xff, 0xb7, 0x97, 0x0, 0xff, 0xbf, 0x97, 0x0, 0xff, 0xc7, 0x97, 0x0, 0xff, 0xcf, 0x97, 0x0, 0xff, 0xd7, 0x97, 0x0, 0xff, 0xdf, 0x97, 0x0, 0xff, 0xe7, 0x97, 0x0, 0xff, 0xef, 0x97, 0x0, 0xff, 0xf7, 0x97, 0x0, 0xff, 0x0, 0x9f, 0x0, 0xff, 0x7, 0x9f, 0x0, 0xff, 0xf, 0x9f, 0x0, 0xff, 0x17, 0x9f, 0x0, 0xff, 0x1f, 0x9f, 0x0, 0xff, 0x27, 0x9f, 0x0, 0xff, 0x2f, 0x9f, 0x0, 0xff, 0x37, 0x9f, 0x0, 0xff, 0x3f, 0x9f, 0x0, 0xff, 0x47, 0x9f, 0x0, 0xff, 0x4f, 0x9f, 0x0, 0xff, 0x57, 0x9f, 0x0, 0xff, 0x5f, 0x9f, 0x0, 0xff, 0x67, 0x9f, 0x0, 0xff, 0x6f, 0x9f, 0x0, 0xff, 0x77, 0x9f, 0x0, 0xff, 0x7f, 0x9f, 0x0, 0xff, 0x87, 0x9f, 0x0, 0xff, 0x8f, 0x9f, 0x0, 0xff, 0x97, 0x9f, 0x0, 0xff, 0x9f, 0x9f, 0x0, 0xff, 0xa7, 0x9f, 0x0, 0xff, 0xaf, 0x9f, 0x0, 0xff, 0xb7, 0x9f, 0x0, 0xff, 0xbf, 0x9f, 0x0, 0xff, 0xc7, 0x9f, 0x0, 0xff, 0xcf, 0x9f, 0x0, 0xff, 0xd7, 0x9f, 0x0, 0xff, 0xdf, 0x9f, 0x0, 0xff, 0xe7, 0x9f, 0x0, 0xff, 0xef, 0x9f, 0x0, 0xff, 0xf7, 0x9f, 0x0, 0xff, 0x0, 0xa7, 0x0, 0xff, 0x7, 0xa7, 0x0, 0xff, 0xf, 0xa7, 0x0, 0xff, 0x17, 0xa7, 0x0, 0xff, 0x1f, 0xa7, 0x0, 0xff, 0x27, 0xa7, 0x0, 0xff, 0x2f, 0xa7, 0x0, 0xff, 0x37, 0xa7, 0x0, 0xff, 0x3f, 0xa7, 0x0, 0xff, 0x47, 0xa7, 0x0, 0xff, 0x4f, 0xa7, 0x0, 0xff, 0x57, 0xa7, 0x0, 0xff, 0x5f, 0xa7, 0x0, 0xff, 0x67, 0xa7, 0x0, 0xff, 0x6f, 0xa7, 0x0, 0xff, 0x77, 0xa7, 0x0, 0xff, 0x7f, 0xa7, 0x0, 0xff, 0x87, 0xa7, 0x0, 0xff, 0x8f, 0xa7, 0x0, 0xff, 0x97, 0xa7, 0x0, 0xff, 0x9f, 0xa7, 0x0, 0xff, 0xa7, 0xa7, 0x0, 0xff, 0xaf, 0xa7, 0x0, 0xff, 0xb7, 0xa7, 0x0, 0xff, 0xbf, 0xa7, 0x0, 0xff, 0xc7, 0xa7, 0x0, 0xff, 0xcf, 0xa7, 0x0, 0xff, 0xd7, 0xa7, 0x0, 0xff, 0xdf, 0xa7, 0x0, 0xff, 0xe7, 0xa7, 0x0, 0xff, 0xef, 0xa7, 0x0, 0xff, 0xf7, 0xa7, 0x0, 0xff, 0x0, 0xaf, 0x0, 0xff, 0x7, 0xaf, 0x0, 0xff, 0xf, 0xaf, 0x0, 0xff, 0x17, 0xaf, 0x0, 0xff, 0x1f, 0xaf, 0x0, 0xff, 0x27, 0xaf, 0x0, 0xff, 0x2f, 0xaf, 0x0, 0xff, 0x37, 0xaf, 0x0, 0xff, 0x3f, 0xaf, 0x0, 0xff, 0x47, 0xaf, 0x0, 0xff, 0x4f, 0xaf, 0x0, 0xff, 0x57, 0xaf, 0x0, 0xff, 0x5f, 0xaf, 0x0, 0xff, 0x67, 0xaf, 0x0, 0xff, 0x6f, 0xaf, 0x0, 0xff, 0x77, 0xaf, 0x0, 0xff, 0x7f, 0xaf, 0x0, 0xff, 0x87, 0xaf, 0x0, 0xff, 0x8f, 0xaf, 0x0, 0xff, 0x97, 0xaf, 0x0, 0xff, 0x9f, 0xaf, 0x0, 0xff, 0xa7, 0xaf, 0x0, 0xff, 0xaf, 0xaf, 0x0, 0xff, 0xb7, 0xaf, 0x0, 0xff, 0xbf, 0xaf, 0x0, 0xff, 0xc7, 0xaf, 0x0, 0xff, 0xcf, 0xaf, 0x0, 0xff, 0xd7, 0xaf, 0x0, 0xff, 0xdf, 0xaf, 0x0, 0xff, 0xe7, 0xaf, 0x0, 0xff, 0xef, 0xaf, 0x0, 0xff, 0xf7, 0xaf, 0x0, 0xff, 0x0, 0xb7, 0x0, 0xff, 0x7, 0xb7, 0x0, 0xff, 0xf, 0xb7, 0x0, 0xff, 0x17, 0xb7, 0x0, 0xff, 0x1f, 0xb7, 0x0, 0xff, 0x27, 0xb7, 0x0, 0xff, 0x2f, 0xb7, 0x0, 0xff, 0x37, 0xb7, 0x0, 0xff, 0x3f, 0xb7, 0x0, 0xff, 0x47, 0xb7, 0x0, 0xff, 0x4f, 0xb7, 0x0, 0xff, 0x57, 0xb7, 0x0, 0xff, 0x5f, 0xb7, 0x0, 0xff, 0x67, 0xb7, 0x0, 0xff, 0x6f, 0xb7, 0x0, 0xff, 0x77, 0xb7, 0x0, 0xff, 0x7f, 0xb7, 0x0, 0xff, 0x87, 0xb7, 0x0, 0xff, 0x8f, 0xb7, 0x0, 0xff, 0x97, 0xb7, 0x0, 0xff, 0x9f, 0xb7, 0x0, 0xff, 0xa7, 0xb7, 0x0, 0xff, 0xaf, 0xb7, 0x0, 0xff, 0xb7, 0xb7, 0x0, 0xff, 0xbf, 0xb7, 0x0, 0xff, 0xc7, 0xb7, 0x0, 0xff, 0xcf, 0xb7, 0x0, 0xff, 0xd7, 0xb7, 0x0, 0xff, 0xdf, 0xb7, 0x0, 0xff, 0xe7, 0xb7, 0x0, 0xff, 0xef, 0xb7, 0x0, 0xff, 0xf7, 0xb7, 0x0, 0xff, 0x0, 0xbf, 0x0, 0xff, 0x7, 0xbf, 0x0, 0xff, 0xf, 0xbf, 0x0, 0xff, 0x17, 0xbf, 0x0, 0xff, 0x1f, 0xbf, 0x0, 0xff, 0x27, 0xbf, 0x0, 0xff, 0x2f, 0xbf, 0x0, 0xff, 0x37, 0xbf, 0x0, 0xff, 0x3f, 0xbf, 0x0, 0xff, 0x47, 0xbf, 0x0, 0xff, 0x4f, 0xbf, 0x0, 0xff, 0x57, 0xbf, 0x0, 0xff, 0x5f, 0xbf, 0x0, 0xff, 0x67, 0xbf, 0x0, 0xff, 0x6f, 0xbf, 0x0, 0xff, 0x77, 0xbf, 0x0, 0xff, 0x7f, 0xbf, 0x0, 0xff, 0x87, 0xbf, 0x0, 0xff, 0x8f, 0xbf, 0x0, 0xff, 0x97, 0xbf, 0x0, 0xff, 0x9f, 0xbf, 0x0, 0xff, 0xa7, 0xbf, 0x0, 0xff, 0xaf, 0xbf, 0x0, 0xff, 0xb7, 0xbf, 0x0, 0xff, 0xbf, 0xbf, 0x0, 0xff, 0xc7, 0xbf, 0x0, 0xff, 0xcf, 0xbf, 0x0, 0xff, 0xd7, 0xbf, 0x0, 0xff, 0xdf, 0xbf, 0x0, 0xff, 0xe7, 0xbf, 0x0, 0xff, 0xef, 0xbf, 0x0, 0xff, 0xf7, 0xbf, 0x0, 0xff, 0x0, 0xc7, 0x0, 0xff, 0x7, 0xc7, 0x0, 0xff, 0xf, 0xc7, 0x0, 0xff, 0x17, 0xc7, 0x0, 0xff, 0x1f, 0xc7, 0x0, 0xff, 0x27, 0xc7, 0x0, 0xff, 0x2f, 0xc7, 0x0, 0xff, 0x37, 0xc7, 0x0, 0xff, 0x3f, 0xc7, 0x0, 0xff, 0x47, 0xc7, 0x0, 0xff, 0x4f, 0xc7, 0x0, 0xff, 0x57, 0xc7, 0x0, 0xff, 0x5f, 0xc7, 0x0, 0xff, 0x67, 0xc7, 0x0, 0xff, 0x6f, 0xc7, 0x0, 0xff, 0x77, 0xc7, 0x0, 0xff, 0x7f, 0xc7, 0x0, 0xff, 0x87, 0xc7, 0x0, 0xff, 0x8f, 0xc7, 0x0, 0xff, 0x97, 0xc7, 0x0, 0xff, 0x9f, 0xc7, 0x0, 0xff, 0xa7, 0xc7, 0x0, 0xff, 0xaf, 0xc7, 0x0, 0xff, 0xb7, 0xc7, 0x0, 0xff, 0xbf, 0xc7, 0x0, 0xff, 0xc7, 0xc7, 0x0, 0xff, 0xcf, 0xc7, 0x0, 0xff, 0xd7, 0xc7, 0x0, 0xff, 0xdf, 0xc7, 0x0, 0xff, 0xe7, 0xc7, 0x0, 0xff, 0xef, 0xc7, 0x0, 0xff, 0xf7, 0xc7, 0x0, 0xff, 0x0, 0xcf, 0x0, 0xff, 0x7, 0xcf, 0x0, 0xff, 0xf, 0xcf, 0x0, 0xff, 0x17, 0xcf, 0x0, 0xff, 0x1f, 0xcf, 0x0, 0xff, 0x27, 0xcf, 0x0, 0xff, 0x2f, 0xcf, 0x0, 0xff, 0x37, 0xcf, 0x0, 0xff, 0x3f, 0xcf, 0x0, 0xff, 0x47, 0xcf, 0x0, 0xff, 0x4f, 0xcf, 0x0, 0xff, 0x57, 0xcf, 0x0, 0xff, 0x5f, 0xcf, 0x0, 0xff, 0x67, 0xcf, 0x0, 0xff, 0x6f, 0xcf, 0x0, 0xff, 0x77, 0xcf, 0x0, 0xff, 0x7f, 0xcf, 0x0, 0xff, 0x87, 0xcf, 0x0, 0xff, 0x8f, 0xcf, 0x0, 0xff, 0x97, 0xcf, 0x0, 0xff, 0x9f, 0xcf, 0x0, 0xff, 0xa7, 0xcf, 0x0, 0xff, 0xaf, 0xcf, 0x0, 0xff, 0xb7, 0xcf, 0x0, 0xff, 0xbf, 0xcf, 0x0, 0xff, 0xc7, 0xcf, 0x0, 0xff, 0xcf, 0xcf, 0x0, 0xff, 0xd7, 0xcf, 0x0, 0xff, 0xdf, 0xcf, 0x0, 0xff, 0xe7, 0xcf, 0x0, 0xff, 0xef, 0xcf, 0x0, 0xff, 0xf7, 0xcf, 0x0, 0xff, 0x0, 0xd7, 0x0, 0xff, 0x7, 0xd7, 0x0, 0xff, 0xf, 0xd7, 0x0, 0xff, 0x17, 0xd7, 0x0, 0xff, 0x1f, 0xd7, 0x0, 0xff, 0x27, 0xd7, 0x0, 0xff, 0x2f, 0xd7, 0x0, 0xff, 0x37, 0xd7, 0x0, 0xff, 0x3f, 0xd7, 0x0, 0xff, 0x47, 0xd7, 0x0, 0xff, 0x4f, 0xd7, 0x0, 0xff, 0x57, 0xd7, 0x0, 0xff, 0x5f, 0xd7, 0x0, 0xff, 0x67, 0xd7, 0x0, 0xff, 0x6f, 0xd7, 0x0, 0xff, 0x77, 0xd7, 0x0, 0xff, 0x7f, 0xd7, 0x0, 0xff, 0x87, 0xd7, 0x0, 0xff, 0x8f, 0xd7, 0x0, 0xff, 0x97, 0xd7, 0x0, 0xff, 0x9f, 0xd7, 0x0, 0xff, 0xa7, 0xd7, 0x0, 0xff, 0xaf, 0xd7, 0x0, 0xff, 0xb7, 0xd7, 0x0, 0xff, 0xbf, 0xd7, 0x0, 0xff, 0xc7, 0xd7, 0x0, 0xff, 0xcf, 0xd7, 0x0, 0xff, 0xd7, 0xd7, 0x0, 0xff, 0xdf, 0xd7, 0x0, 0xff, 0xe7, 0xd7, 0x0, 0xff, 0xef, 0xd7, 0x0, 0xff, 0xf7, 0xd7, 0x0, 0xff, 0x0, 0xdf, 0x0, 0xff, 0x7, 0xdf, 0x0, 0xff, 0xf, 0xdf, 0x0, 0xff, 0x17, 0xdf, 0x0, 0xff, 0x1f, 0xdf, 0x0, 0xff, 0x27, 0xdf, 0x0, 0xff, 0x2f, 0xdf, 0x0, 0xff, 0x37, 0xdf, 0x0, 0xff, 0x3f, 0xdf, 0x0, 0xff, 0x47, 0xdf, 0x0, 0xff, 0x4f, 0xdf, 0x0, 0xff, 0x57, 0xdf, 0x0, 0xff, 0x5f, 0xdf, 0x0, 0xff, 0x67, 0xdf, 0x0, 0xff, 0x6f, 0xdf, 0x0, 0xff, 0x77, 0xdf, 0x0, 0xff, 0x7f, 0xdf, 0x0, 0xff, 0x87, 0xdf, 0x0, 0xff, 0x8f, 0xdf, 0x0, 0xff, 0x97, 0xdf, 0x0, 0xff, 0x9f, 0xdf, 0x0, 0xff, 0xa7, 0xdf, 0x0, 0xff, 0xaf, 0xdf, 0x0, 0xff, 0xb7, 0xdf, 0x0, 0xff, 0xbf, 0xdf, 0x0, 0xff, 0xc7, 0xdf, 0x0, 0xff, 0xcf, 0xdf, 0x0, 0xff, 0xd7, 0xdf, 0x0, 0xff, 0xdf, 0xdf, 0x0, 0xff, 0xe7, 0xdf, 0x0, 0xff, 0xef, 0xdf, 0x0, 0xff, 0xf7, 0xdf, 0x0, 0xff, 0x0, 0xe7, 0x0, 0xff, 0x7, 0xe7, 0x0, 0xff, 0xf, 0xe7, 0x0, 0xff, 0x17, 0xe7, 0x0, 0xff, 0x1f, 0xe7, 0x0, 0xff, 0x27, 0xe7, 0x0, 0xff, 0x2f, 0xe7, 0x0, 0xff, 0x37, 0xe7, 0x0, 0xff, 0x3f, 0xe7, 0x0, 0xff, 0x47, 0xe7, 0x0, 0xff, 0x4f, 0xe7, 0x0, 0xff, 0x57, 0xe7, 0x0, 0xff, 0x5f, 0xe7, 0x0, 0xff, 0x67, 0xe7, 0x0, 0xff, 0x6f, 0xe7, 0x0, 0xff, 0x77, 0xe7, 0x0, 0xff, 0x7f, 0xe7, 0x0, 0xff, 0x87, 0xe7, 0x0, 0xff, 0x8f, 0xe7, 0x0, 0xff, 0x97, 0xe7, 0x0, 0xff, 0x9f, 0xe7, 0x0, 0xff, 0xa7, 0xe7, 0x0, 0xff, 0xaf, 0xe7, 0x0, 0xff, 0xb7, 0xe7, 0x0, 0xff, 0xbf, 0xe7, 0x0, 0xff, 0xc7, 0xe7, 0x0, 0xff, 0xcf, 0xe7, 0x0, 0xff, 0xd7, 0xe7, 0x0, 0xff, 0xdf, 0xe7, 0x0, 0xff, 0xe7, 0xe7, 0x0, 0xff, 0xef, 0xe7, 0x0, 0xff, 0xf7, 0xe7, 0x0, 0xff, 0x0, 0xef, 0x0, 0xff, 0x7, 0xef, 0x0, 0xff, 0xf, 0xef, 0x0, 0xff, 0x17, 0xef, 0x0, 0xff, 0x1f, 0xef, 0x0, 0xff, 0x27, 0xef, 0x0, 0xff, 0x2f, 0xef, 0x0, 0xff, 0x37, 0xef, 0x0, 0xff, 0x3f, 0xef, 0x0, 0xff, 0x47, 0xef, 0x0, 0xff, 0x4f, 0xef, 0x0, 0xff, 0x57, 0xef, 0x0, 0xff, 0x5f, 0xef, 0x0, 0xff, 0x67, 0xef, 0x0, 0xff, 0x6f, 0xef, 0x0, 0xff, 0x77, 0xef, 0x0, 0xff, 0x7f, 0xef, 0x0, 0xff, 0x87, 0xef, 0x0, 0xff, 0x8f, 0xef, 0x0, 0xff, 0x97, 0xef, 0x0, 0xff, 0x9f, 0xef, 0x0, 0xff, 0xa7, 0xef, 0x0, 0xff, 0xaf, 0xef, 0x0, 0xff, 0xb7, 0xef, 0x0, 0xff, 0xbf, 0xef, 0x0, 0xff, 0xc7, 0xef, 0x0, 0xff, 0xcf, 0xef, 0x0, 0xff, 0xd7, 0xef, 0x0, 0xff, 0xdf, 0xef, 0x0, 0xff, 0xe7, 0xef, 0x0, 0xff, 0xef, 0xef, 0x0, 0xff, 0xf7, 0xef, 0x0, 0xff, 0x0, 0xf7, 0x0, 0xff, 0x7, 0xf7, 0x0, 0xff, 0xf, 0xf7, 0x0, 0xff, 0x17, 0xf7, 0x0, 0xff, 0x1f, 0xf7, 0x0, 0xff, 0x27, 0xf7, 0x0, 0xff, 0x2f, 0xf7, 0x0, 0xff, 0x37, 0xf7, 0x0, 0xff, 0x3f, 0xf7, 0x0, 0xff, 0x47, 0xf7, 0x0, 0xff, 0x4f, 0xf7, 0x0, 0xff, 0x57, 0xf7, 0x0, 0xff, 0x5f, 0xf7, 0x0, 0xff, 0x67, 0xf7, 0x0, 0xff, 0x6f, 0xf7, 0x0, 0xff, 0x77, 0xf7, 0x0, 0xff, 0x7f, 0xf7, 0x0, 0xff, 0x87, 0xf7, 0x0, 0xff, 0x8f, 0xf7, 0x0, 0xff, 0x97, 0xf7, 0x0, 0xff, 0x9f, 0xf7, 0x0, 0xff, 0xa7, 0xf7, 0x0, 0xff, 0xaf, 0xf7, 0x0, 0xff, 0xb7, 0xf7, 0x0, 0xff, 0xbf, 0xf7, 0x0, 0xff, 0xc7, 0xf7, 0x0, 0xff, 0xcf, 0xf7, 0x0, 0xff, 0xd7, 0xf7, 0x0, 0xff, 0xdf, 0xf7, 0x0, 0xff, 0xe7, 0xf7, 0x0, 0xff, 0xef, 0xf7, 0x0, 0xff, 0xf7, 0xf7, 0x0, 0xff}
+	wantPix := []byte{0x0, 0x0, 0x0, 0xff, 0x8, 0x0, 0x0, 0xff, 0x10, 0x0, 0x0, 0xff, 0x18, 0x0, 0x0, 0xff, 0x20, 0x0, 0x0, 0xff, 0x28, 0x0, 0x0, 0xff, 0x30, 0x0, 0x0, 0xff, 0x38, 0x0, 0x0, 0xff, 0x40, 0x0, 0x0, 0xff, 0x48, 0x0, 0x0, 0xff, 0x50, 0x0, 0x0, 0xff, 0x58, 0x0, 0x0, 0xff, 0x60, 0x0, 0x0, 0xff, 0x68, 0x0, 0x0, 0xff, 0x70, 0x0, 0x0, 0xff, 0x78, 0x0, 0x0, 0xff, 0x80, 0x0, 0x0, 0xff, 0x87, 0x0, 0x0, 0xff, 0x8f, 0x0, 0x0, 0xff, 0x97, 0x0, 0x0, 0xff, 0x9f, 0x0, 0x0, 0xff, 0xa7, 0x0, 0x0, 0xff, 0xaf, 0x0, 0x0, 0xff, 0xb7, 0x0, 0x0, 0xff, 0xbf, 0x0, 0x0, 0xff, 0xc7, 0x0, 0x0, 0xff, 0xcf, 0x0, 0x0, 0xff, 0xd7, 0x0, 0x0, 0xff, 0xdf, 0x0, 0x0, 0xff, 0xe7, 0x0, 0x0, 0xff, 0xef, 0x0, 0x0, 0xff, 0xf7, 0x0, 0x0, 0xff, 0x0, 0x8, 0x0, 0xff, 0x8, 0x8, 0x0, 0xff, 0x10, 0x8, 0x0, 0xff, 0x18, 0x8, 0x0, 0xff, 0x20, 0x8, 0x0, 0xff, 0x28, 0x8, 0x0, 0xff, 0x30, 0x8, 0x0, 0xff, 0x38, 0x8, 0x0, 0xff, 0x40, 0x8, 0x0, 0xff, 0x48, 0x8, 0x0, 0xff, 0x50, 0x8, 0x0, 0xff, 0x58, 0x8, 0x0, 0xff, 0x60, 0x8, 0x0, 0xff, 0x68, 0x8, 0x0, 0xff, 0x70, 0x8, 0x0, 0xff, 0x78, 0x8, 0x0, 0xff, 0x80, 0x8, 0x0, 0xff, 0x87, 0x8, 0x0, 0xff, 0x8f, 0x8, 0x0, 0xff, 0x97, 0x8, 0x0, 0xff, 0x9f, 0x8, 0x0, 0xff, 0xa7, 0x8, 0x0, 0xff, 0xaf, 0x8, 0x0, 0xff, 0xb7, 0x8, 0x0, 0xff, 0xbf, 0x8, 0x0, 0xff, 0xc7, 0x8, 0x0, 0xff, 0xcf, 0x8, 0x0, 0xff, 0xd7, 0x8, 0x0, 0xff, 0xdf, 0x8, 0x0, 0xff, 0xe7, 0x8, 0x0, 0xff, 0xef, 0x8, 0x0, 0xff, 0xf7, 0x8, 0x0, 0xff, 0x0, 0x10, 0x0, 0xff, 0x8, 0x10, 0x0, 0xff, 0x10, 0x10, 0x0, 0xff, 0x18, 0x10, 0x0, 0xff, 0x20, 0x10, 0x0, 0xff, 0x28, 0x10, 0x0, 0xff, 0x30, 0x10, 0x0, 0xff, 0x38, 0x10, 0x0, 0xff, 0x40, 0x10, 0x0, 0xff, 0x48, 0x10, 0x0, 0xff, 0x50, 0x10, 0x0, 0xff, 0x58, 0x10, 0x0, 0xff, 0x60, 0x10, 0x0, 0xff, 0x68, 0x10, 0x0, 0xff, 0x70, 0x10, 0x0, 0xff, 0x78, 0x10, 0x0, 0xff, 0x80, 0x10, 0x0, 0xff, 0x87, 0x10, 0x0, 0xff, 0x8f, 0x10, 0x0, 0xff, 0x97, 0x10, 0x0, 0xff, 0x9f, 0x10, 0x0, 0xff, 0xa7, 0x10, 0x0, 0xff, 0xaf, 0x10, 0x0, 0xff, 0xb7, 0x10, 0x0, 0xff, 0xbf, 0x10, 0x0, 0xff, 0xc7, 0x10, 0x0, 0xff, 0xcf, 0x10, 0x0, 0xff, 0xd7, 0x10, 0x0, 0xff, 0xdf, 0x10, 0x0, 0xff, 0xe7, 0x10, 0x0, 0xff, 0xef, 0x10, 0x0, 0xff, 0xf7, 0x10, 0x0, 0xff, 0x0, 0x18, 0x0, 0xff, 0x8, 0x18, 0x0, 0xff, 0x10, 0x18, 0x0, 0xff, 0x18, 0x18, 0x0, 0xff, 0x20, 0x18, 0x0, 0xff, 0x28, 0x18, 0x0, 0xff, 0x30, 0x18, 0x0, 0xff, 0x38, 0x18, 0x0, 0xff, 0x40, 0x18, 0x0, 0xff, 0x48, 0x18, 0x0, 0xff, 0x50, 0x18, 0x0, 0xff, 0x58, 0x18, 0x0, 0xff, 0x60, 0x18, 0x0, 0xff, 0x68, 0x18, 0x0, 0xff, 0x70, 0x18, 0x0, 0xff, 0x78, 0x18, 0x0, 0xff, 0x80, 0x18, 0x0, 0xff, 0x87, 0x18, 0x0, 0xff, 0x8f, 0x18, 0x0, 0xff, 0x97, 0x18, 0x0, 0xff, 0x9f, 0x18, 0x0, 0xff, 0xa7, 0x18, 0x0, 0xff, 0xaf, 0x18, 0x0, 0xff, 0xb7, 0x18, 0x0, 0xff, 0xbf, 0x18, 0x0, 0xff, 0xc7, 0x18, 0x0, 0xff, 0xcf, 0x18, 0x0, 0xff, 0xd7, 0x18, 0x0, 0xff, 0xdf, 0x18, 0x0, 0xff, 0xe7, 0x18, 0x0, 0xff, 0xef, 0x18, 0x0, 0xff, 0xf7, 0x18, 0x0, 0xff, 0x0, 0x20, 0x0, 0xff, 0x8, 0x20, 0x0, 0xff, 0x10, 0x20, 0x0, 0xff, 0x18, 0x20, 0x0, 0xff, 0x20, 0x20, 0x0, 0xff, 0x28, 0x20, 0x0, 0xff, 0x30, 0x20, 0x0, 0xff, 0x38, 0x20, 0x0, 0xff, 0x40, 0x20, 0x0, 0xff, 0x48, 0x20, 0x0, 0xff, 0x50, 0x20, 0x0, 0xff, 0x58, 0x20, 0x0, 0xff, 0x60, 0x20, 0x0, 0xff, 0x68, 0x20, 0x0, 0xff, 0x70, 0x20, 0x0, 0xff, 0x78, 0x20, 0x0, 0xff, 0x80, 0x20, 0x0, 0xff, 0x87, 0x20, 0x0, 0xff, 0x8f, 0x20, 0x0, 0xff, 0x97, 0x20, 0x0, 0xff, 0x9f, 0x20, 0x0, 0xff, 0xa7, 0x20, 0x0, 0xff, 0xaf, 0x20, 0x0, 0xff, 0xb7, 0x20, 0x0, 0xff, 0xbf, 0x20, 0x0, 0xff, 0xc7, 0x20, 0x0, 0xff, 0xcf, 0x20, 0x0, 0xff, 0xd7, 0x20, 0x0, 0xff, 0xdf, 0x20, 0x0, 0xff, 0xe7, 0x20, 0x0, 0xff, 0xef, 0x20, 0x0, 0xff, 0xf7, 0x20, 0x0, 0xff, 0x0, 0x28, 0x0, 0xff, 0x8, 0x28, 0x0, 0xff, 0x10, 0x28, 0x0, 0xff, 0x18, 0x28, 0x0, 0xff, 0x20, 0x28, 0x0, 0xff, 0x28, 0x28, 0x0, 0xff, 0x30, 0x28, 0x0, 0xff, 0x38, 0x28, 0x0, 0xff, 0x40, 0x28, 0x0, 0xff, 0x48, 0x28, 0x0, 0xff, 0x50, 0x28, 0x0, 0xff, 0x58, 0x28, 0x0, 0xff, 0x60, 0x28, 0x0, 0xff, 0x68, 0x28, 0x0, 0xff, 0x70, 0x28, 0x0, 0xff, 0x78, 0x28, 0x0, 0xff, 0x80, 0x28, 0x0, 0xff, 0x87, 0x28, 0x0, 0xff, 0x8f, 0x28, 0x0, 0xff, 0x97, 0x28, 0x0, 0xff, 0x9f, 0x28, 0x0, 0xff, 0xa7, 0x28, 0x0, 0xff, 0xaf, 0x28, 0x0, 0xff, 0xb7, 0x28, 0x0, 0xff, 0xbf, 0x28, 0x0, 0xff, 0xc7, 0x28, 0x0, 0xff, 0xcf, 0x28, 0x0, 0xff, 0xd7, 0x28, 0x0, 0xff, 0xdf, 0x28, 0x0, 0xff, 0xe7, 0x28, 0x0, 0xff, 0xef, 0x28, 0x0, 0xff, 0xf7, 0x28, 0x0, 0xff, 0x0, 0x30, 0x0, 0xff, 0x8, 0x30, 0x0, 0xff, 0x10, 0x30, 0x0, 0xff, 0x18, 0x30, 0x0, 0xff, 0x20, 0x30, 0x0, 0xff, 0x28, 0x30, 0x0, 0xff, 0x30, 0x30, 0x0, 0xff, 0x38, 0x30, 0x0, 0xff, 0x40, 0x30, 0x0, 0xff, 0x48, 0x30, 0x0, 0xff, 0x50, 0x30, 0x0, 0xff, 0x58, 0x30, 0x0, 0xff, 0x60, 0x30, 0x0, 0xff, 0x68, 0x30, 0x0, 0xff, 0x70, 0x30, 0x0, 0xff, 0x78, 0x30, 0x0, 0xff, 0x80, 0x30, 0x0, 0xff, 0x87, 0x30, 0x0, 0xff, 0x8f, 0x30, 0x0, 0xff, 0x97, 0x30, 0x0, 0xff, 0x9f, 0x30, 0x0, 0xff, 0xa7, 0x30, 0x0, 0xff, 0xaf, 0x30, 0x0, 0xff, 0xb7, 0x30, 0x0, 0xff, 0xbf, 0x30, 0x0, 0xff, 0xc7, 0x30, 0x0, 0xff, 0xcf, 0x30, 0x0, 0xff, 0xd7, 0x30, 0x0, 0xff, 0xdf, 0x30, 0x0, 0xff, 0xe7, 0x30, 0x0, 0xff, 0xef, 0x30, 0x0, 0xff, 0xf7, 0x30, 0x0, 0xff, 0x0, 0x38, 0x0, 0xff, 0x8, 0x38, 0x0, 0xff, 0x10, 0x38, 0x0, 0xff, 0x18, 0x38, 0x0, 0xff, 0x20, 0x38, 0x0, 0xff, 0x28, 0x38, 0x0, 0xff, 0x30, 0x38, 0x0, 0xff, 0x38, 0x38, 0x0, 0xff, 0x40, 0x38, 0x0, 0xff, 0x48, 0x38, 0x0, 0xff, 0x50, 0x38, 0x0, 0xff, 0x58, 0x38, 0x0, 0xff, 0x60, 0x38, 0x0, 0xff, 0x68, 0x38, 0x0, 0xff, 0x70, 0x38, 0x0, 0xff, 0x78, 0x38, 0x0, 0xff, 0x80, 0x38, 0x0, 0xff, 0x87, 0x38, 0x0, 0xff, 0x8f, 0x38, 0x0, 0xff, 0x97, 0x38, 0x0, 0xff, 0x9f, 0x38, 0x0, 0xff, 0xa7, 0x38, 0x0, 0xff, 0xaf, 0x38, 0x0, 0xff, 0xb7, 0x38, 0x0, 0xff, 0xbf, 0x38, 0x0, 0xff, 0xc7, 0x38, 0x0, 0xff, 0xcf, 0x38, 0x0, 0xff, 0xd7, 0x38, 0x0, 0xff, 0xdf, 0x38, 0x0, 0xff, 0xe7, 0x38, 0x0, 0xff, 0xef, 0x38, 0x0, 0xff, 0xf7, 0x38, 0x0, 0xff, 0x0, 0x40, 0x0, 0xff, 0x8, 0x40, 0x0, 0xff, 0x10, 0x40, 0x0, 0xff, 0x18, 0x40, 0x0, 0xff, 0x20, 0x40, 0x0, 0xff, 0x28, 0x40, 0x0, 0xff, 0x30, 0x40, 0x0, 0xff, 0x38, 0x40, 0x0, 0xff, 0x40, 0x40, 0x0, 0xff, 0x48, 0x40, 0x0, 0xff, 0x50, 0x40, 0x0, 0xff, 0x58, 0x40, 0x0, 0xff, 0x60, 0x40, 0x0, 0xff, 0x68, 0x40, 0x0, 0xff, 0x70, 0x40, 0x0, 0xff, 0x78, 0x40, 0x0, 0xff, 0x80, 0x40, 0x0, 0xff, 0x87, 0x40, 0x0, 0xff, 0x8f, 0x40, 0x0, 0xff, 0x97, 0x40, 0x0, 0xff, 0x9f, 0x40, 0x0, 0xff, 0xa7, 0x40, 0x0, 0xff, 0xaf, 0x40, 0x0, 0xff, 0xb7, 0x40, 0x0, 0xff, 0xbf, 0x40, 0x0, 0xff, 0xc7, 0x40, 0x0, 0xff, 0xcf, 0x40, 0x0, 0xff, 0xd7, 0x40, 0x0, 0xff, 0xdf, 0x40, 0x0, 0xff, 0xe7, 0x40, 0x0, 0xff, 0xef, 0x40, 0x0, 0xff, 0xf7, 0x40, 0x0, 0xff, 0x0, 0x48, 0x0, 0xff, 0x8, 0x48, 0x0, 0xff, 0x10, 0x48, 0x0, 0xff, 0x18, 0x48, 0x0, 0xff, 0x20, 0x48, 0x0, 0xff, 0x28, 0x48, 0x0, 0xff, 0x30, 0x48, 0x0, 0xff, 0x38, 0x48, 0x0, 0xff, 0x40, 0x48, 0x0, 0xff, 0x48, 0x48, 0x0, 0xff, 0x50, 0x48, 0x0, 0xff, 0x58, 0x48, 0x0, 0xff, 0x60, 0x48, 0x0, 0xff, 0x68, 0x48, 0x0, 0xff, 0x70, 0x48, 0x0, 0xff, 0x78, 0x48, 0x0, 0xff, 0x80, 0x48, 0x0, 0xff, 0x87, 0x48, 0x0, 0xff, 0x8f, 0x48, 0x0, 0xff, 0x97, 0x48, 0x0, 0xff, 0x9f, 0x48, 0x0, 0xff, 0xa7, 0x48, 0x0, 0xff, 0xaf, 0x48, 0x0, 0xff, 0xb7, 0x48, 0x0, 0xff, 0xbf, 0x48, 0x0, 0xff, 0xc7, 0x48, 0x0, 0xff, 0xcf, 0x48, 0x0, 0xff, 0xd7, 0x48, 0x0, 0xff, 0xdf, 0x48, 0x0, 0xff, 0xe7, 0x48, 0x0, 0xff, 0xef, 0x48, 0x0, 0xff, 0xf7, 0x48, 0x0, 0xff, 0x0, 0x50, 0x0, 0xff, 0x8, 0x50, 0x0, 0xff, 0x10, 0x50, 0x0, 0xff, 0x18, 0x50, 0x0, 0xff, 0x20, 0x50, 0x0, 0xff, 0x28, 0x50, 0x0, 0xff, 0x30, 0x50, 0x0, 0xff, 0x38, 0x50, 0x0, 0xff, 0x40, 0x50, 0x0, 0xff, 0x48, 0x50, 0x0, 0xff, 0x50, 0x50, 0x0, 0xff, 0x58, 0x50, 0x0, 0xff, 0x60, 0x50, 0x0, 0xff, 0x68, 0x50, 0x0, 0xff, 0x70, 0x50, 0x0, 0xff, 0x78, 0x50, 0x0, 0xff, 0x80, 0x50, 0x0, 0xff, 0x87, 0x50, 0x0, 0xff, 0x8f, 0x50, 0x0, 0xff, 0x97, 0x50, 0x0, 0xff, 0x9f, 0x50, 0x0, 0xff, 0xa7, 0x50, 0x0, 0xff, 0xaf, 0x50, 0x0, 0xff, 0xb7, 0x50, 0x0, 0xff, 0xbf, 0x50, 0x0, 0xff, 0xc7, 0x50, 0x0, 0xff, 0xcf, 0x50, 0x0, 0xff, 0xd7, 0x50, 0x0, 0xff, 0xdf, 0x50, 0x0, 0xff, 0xe7, 0x50, 0x0, 0xff, 0xef, 0x50, 0x0, 0xff, 0xf7, 0x50, 0x0, 0xff, 0x0, 0x58, 0x0, 0xff, 0x8, 0x58, 0x0, 0xff, 0x10, 0x58, 0x0, 0xff, 0x18, 0x58, 0x0, 0xff, 0x20, 0x58, 0x0, 0xff, 0x28, 0x58, 0x0, 0xff, 0x30, 0x58, 0x0, 0xff, 0x38, 0x58, 0x0, 0xff, 0x40, 0x58, 0x0, 0xff, 0x48, 0x58, 0x0, 0xff, 0x50, 0x58, 0x0, 0xff, 0x58, 0x58, 0x0, 0xff, 0x60, 0x58, 0x0, 0xff, 0x68, 0x58, 0x0, 0xff, 0x70, 0x58, 0x0, 0xff, 0x78, 0x58, 0x0, 0xff, 0x80, 0x58, 0x0, 0xff, 0x87, 0x58, 0x0, 0xff, 0x8f, 0x58, 0x0, 0xff, 0x97, 0x58, 0x0, 0xff, 0x9f, 0x58, 0x0, 0xff, 0xa7, 0x58, 0x0, 0xff, 0xaf, 0x58, 0x0, 0xff, 0xb7, 0x58, 0x0, 0xff, 0xbf, 0x58, 0x0, 0xff, 0xc7, 0x58, 0x0, 0xff, 0xcf, 0x58, 0x0, 0xff, 0xd7, 0x58, 0x0, 0xff, 0xdf, 0x58, 0x0, 0xff, 0xe7, 0x58, 0x0, 0xff, 0xef, 0x58, 0x0, 0xff, 0xf7, 0x58, 0x0, 0xff, 0x0, 0x60, 0x0, 0xff, 0x8, 0x60, 0x0, 0xff, 0x10, 0x60, 0x0, 0xff, 0x18, 0x60, 0x0, 0xff, 0x20, 0x60, 0x0, 0xff, 0x28, 0x60, 0x0, 0xff, 0x30, 0x60, 0x0, 0xff, 0x38, 0x60, 0x0, 0xff, 0x40, 0x60, 0x0, 0xff, 0x48, 0x60, 0x0, 0xff, 0x50, 0x60, 0x0, 0xff, 0x58, 0x60, 0x0, 0xff, 0x60, 0x60, 0x0, 0xff, 0x68, 0x60, 0x0, 0xff, 0x70, 0x60, 0x0, 0xff, 0x78, 0x60, 0x0, 0xff, 0x80, 0x60, 0x0, 0xff, 0x87, 0x60, 0x0, 0xff, 0x8f, 0x60, 0x0, 0xff, 0x97, 0x60, 0x0, 0xff, 0x9f, 0x60, 0x0, 0xff, 0xa7, 0x60, 0x0, 0xff, 0xaf, 0x60, 0x0, 0xff, 0xb7, 0x60, 0x0, 0xff, 0xbf, 0x60, 0x0, 0xff, 0xc7, 0x60, 0x0, 0xff, 0xcf, 0x60, 0x0, 0xff, 0xd7, 0x60, 0x0, 0xff, 0xdf, 0x60, 0x0, 0xff, 0xe7, 0x60, 0x0, 0xff, 0xef, 0x60, 0x0, 0xff, 0xf7, 0x60, 0x0, 0xff, 0x0, 0x68, 0x0, 0xff, 0x8, 0x68, 0x0, 0xff, 0x10, 0x68, 0x0, 0xff, 0x18, 0x68, 0x0, 0xff, 0x20, 0x68, 0x0, 0xff, 0x28, 0x68, 0x0, 0xff, 0x30, 0x68, 0x0, 0xff, 0x38, 0x68, 0x0, 0xff, 0x40, 0x68, 0x0, 0xff, 0x48, 0x68, 0x0, 0xff, 0x50, 0x68, 0x0, 0xff, 0x58, 0x68, 0x0, 0xff, 0x60, 0x68, 0x0, 0xff, 0x68, 0x68, 0x0, 0xff, 0x70, 0x68, 0x0, 0xff, 0x78, 0x68, 0x0, 0xff, 0x80, 0x68, 0x0, 0xff, 0x87, 0x68, 0x0, 0xff, 0x8f, 0x68, 0x0, 0xff, 0x97, 0x68, 0x0, 0xff, 0x9f, 0x68, 0x0, 0xff, 0xa7, 0x68, 0x0, 0xff, 0xaf, 0x68, 0x0, 0xff, 0xb7, 0x68, 0x0, 0xff, 0xbf, 0x68, 0x0, 0xff, 0xc7, 0x68, 0x0, 0xff, 0xcf, 0x68, 0x0, 0xff, 0xd7, 0x68, 0x0, 0xff, 0xdf, 0x68, 0x0, 0xff, 0xe7, 0x68, 0x0, 0xff, 0xef, 0x68, 0x0, 0xff, 0xf7, 0x68, 0x0, 0xff, 0x0, 0x70, 0x0, 0xff, 0x8, 0x70, 0x0, 0xff, 0x10, 0x70, 0x0, 0xff, 0x18, 0x70, 0x0, 0xff, 0x20, 0x70, 0x0, 0xff, 0x28, 0x70, 0x0, 0xff, 0x30, 0x70, 0x0, 0xff, 0x38, 0x70, 0x0, 0xff, 0x40, 0x70, 0x0, 0xff, 0x48, 0x70, 0x0, 0xff, 0x50, 0x70, 0x0, 0xff, 0x58, 0x70, 0x0, 0xff, 0x60, 0x70, 0x0, 0xff, 0x68, 0x70, 0x0, 0xff, 0x70, 0x70, 0x0, 0xff, 0x78, 0x70, 0x0, 0xff, 0x80, 0x70, 0x0, 0xff, 0x87, 0x70, 0x0, 0xff, 0x8f, 0x70, 0x0, 0xff, 0x97, 0x70, 0x0, 0xff, 0x9f, 0x70, 0x0, 0xff, 0xa7, 0x70, 0x0, 0xff, 0xaf, 0x70, 0x0, 0xff, 0xb7, 0x70, 0x0, 0xff, 0xbf, 0x70, 0x0, 0xff, 0xc7, 0x70, 0x0, 0xff, 0xcf, 0x70, 0x0, 0xff, 0xd7, 0x70, 0x0, 0xff, 0xdf, 0x70, 0x0, 0xff, 0xe7, 0x70, 0x0, 0xff, 0xef, 0x70, 0x0, 0xff, 0xf7, 0x70, 0x0, 0xff, 0x0, 0x78, 0x0, 0xff, 0x8, 0x78, 0x0, 0xff, 0x10, 0x78, 0x0, 0xff, 0x18, 0x78, 0x0, 0xff, 0x20, 0x78, 0x0, 0xff, 0x28, 0x78, 0x0, 0xff, 0x30, 0x78, 0x0, 0xff, 0x38, 0x78, 0x0, 0xff, 0x40, 0x78, 0x0, 0xff, 0x48, 0x78, 0x0, 0xff, 0x50, 0x78, 0x0, 0xff, 0x58, 0x78, 0x0, 0xff, 0x60, 0x78, 0x0, 0xff, 0x68, 0x78, 0x0, 0xff, 0x70, 0x78, 0x0, 0xff, 0x78, 0x78, 0x0, 0xff, 0x80, 0x78, 0x0, 0xff, 0x87, 0x78, 0x0, 0xff, 0x8f, 0x78, 0x0, 0xff, 0x97, 0x78, 0x0, 0xff, 0x9f, 0x78, 0x0, 0xff, 0xa7, 0x78, 0x0, 0xff, 0xaf, 0x78, 0x0, 0xff, 0xb7, 0x78, 0x0, 0xff, 0xbf, 0x78, 0x0, 0xff, 0xc7, 0x78, 0x0, 0xff, 0xcf, 0x78, 0x0, 0xff, 0xd7, 0x78, 0x0, 0xff, 0xdf, 0x78, 0x0, 0xff, 0xe7, 0x78, 0x0, 0xff, 0xef, 0x78, 0x0, 0xff, 0xf7, 0x78, 0x0, 0xff, 0x0, 0x80, 0x0, 0xff, 0x8, 0x80, 0x0, 0xff, 0x10, 0x80, 0x0, 0xff, 0x18, 0x80, 0x0, 0xff, 0x20, 0x80, 0x0, 0xff, 0x28, 0x80, 0x0, 0xff, 0x30, 0x80, 0x0, 0xff, 0x38, 0x80, 0x0, 0xff, 0x40, 0x80, 0x0, 0xff, 0x48, 0x80, 0x0, 0xff, 0x50, 0x80, 0x0, 0xff, 0x58, 0x80, 0x0, 0xff, 0x60, 0x80, 0x0, 0xff, 0x68, 0x80, 0x0, 0xff, 0x70, 0x80, 0x0, 0xff, 0x78, 0x80, 0x0, 0xff, 0x80, 0x80, 0x0, 0xff, 0x87, 0x80, 0x0, 0xff, 0x8f, 0x80, 0x0, 0xff, 0x97, 0x80, 0x0, 0xff, 0x9f, 0x80, 0x0, 0xff, 0xa7, 0x80, 0x0, 0xff, 0xaf, 0x80, 0x0, 0xff, 0xb7, 0x80, 0x0, 0xff, 0xbf, 0x80, 0x0, 0xff, 0xc7, 0x80, 0x0, 0xff, 0xcf, 0x80, 0x0, 0xff, 0xd7, 0x80, 0x0, 0xff, 0xdf, 0x80, 0x0, 0xff, 0xe7, 0x80, 0x0, 0xff, 0xef, 0x80, 0x0, 0xff, 0xf7, 0x80, 0x0, 0xff, 0x0, 0x87, 0x0, 0xff, 0x8, 0x87, 0x0, 0xff, 0x10, 0x87, 0x0, 0xff, 0x18, 0x87, 0x0, 0xff, 0x20, 0x87, 0x0, 0xff, 0x28, 0x87, 0x0, 0xff, 0x30, 0x87, 0x0, 0xff, 0x38, 0x87, 0x0, 0xff, 0x40, 0x87, 0x0, 0xff, 0x48, 0x87, 0x0, 0xff, 0x50, 0x87, 0x0, 0xff, 0x58, 0x87, 0x0, 0xff, 0x60, 0x87, 0x0, 0xff, 0x68, 0x87, 0x0, 0xff, 0x70, 0x87, 0x0, 0xff, 0x78, 0x87, 0x0, 0xff, 0x80, 0x87, 0x0, 0xff, 0x87, 0x87, 0x0, 0xff, 0x8f, 0x87, 0x0, 0xff, 0x97, 0x87, 0x0, 0xff, 0x9f, 0x87, 0x0, 0xff, 0xa7, 0x87, 0x0, 0xff, 0xaf, 0x87, 0x0, 0xff, 0xb7, 0x87, 0x0, 0xff, 0xbf, 0x87, 0x0, 0xff, 0xc7, 0x87, 0x0, 0xff, 0xcf, 0x87, 0x0, 0xff, 0xd7, 0x87, 0x0, 0xff, 0xdf, 0x87, 0x0, 0xff, 0xe7, 0x87, 0x0, 0xff, 0xef, 0x87, 0x0, 0xff, 0xf7, 0x87, 0x0, 0xff, 0x0, 0x8f, 0x0, 0xff, 0x8, 0x8f, 0x0, 0xff, 0x10, 0x8f, 0x0, 0xff, 0x18, 0x8f, 0x0, 0xff, 0x20, 0x8f, 0x0, 0xff, 0x28, 0x8f, 0x0, 0xff, 0x30, 0x8f, 0x0, 0xff, 0x38, 0x8f, 0x0, 0xff, 0x40, 0x8f, 0x0, 0xff, 0x48, 0x8f, 0x0, 0xff, 0x50, 0x8f, 0x0, 0xff, 0x58, 0x8f, 0x0, 0xff, 0x60, 0x8f, 0x0, 0xff, 0x68, 0x8f, 0x0, 0xff, 0x70, 0x8f, 0x0, 0xff, 0x78, 0x8f, 0x0, 0xff, 0x80, 0x8f, 0x0, 0xff, 0x87, 0x8f, 0x0, 0xff, 0x8f, 0x8f, 0x0, 0xff, 0x97, 0x8f, 0x0, 0xff, 0x9f, 0x8f, 0x0, 0xff, 0xa7, 0x8f, 0x0, 0xff, 0xaf, 0x8f, 0x0, 0xff, 0xb7, 0x8f, 0x0, 0xff, 0xbf, 0x8f, 0x0, 0xff, 0xc7, 0x8f, 0x0, 0xff, 0xcf, 0x8f, 0x0, 0xff, 0xd7, 0x8f, 0x0, 0xff, 0xdf, 0x8f, 0x0, 0xff, 0xe7, 0x8f, 0x0, 0xff, 0xef, 0x8f, 0x0, 0xff, 0xf7, 0x8f, 0x0, 0xff, 0x0, 0x97, 0x0, 0xff, 0x8, 0x97, 0x0, 0xff, 0x10, 0x97, 0x0, 0xff, 0x18, 0x97, 0x0, 0xff, 0x20, 0x97, 0x0, 0xff, 0x28, 0x97, 0x0, 0xff, 0x30, 0x97, 0x0, 0xff, 0x38, 0x97, 0x0, 0xff, 0x40, 0x97, 0x0, 0xff, 0x48, 0x97, 0x0, 0xff, 0x50, 0x97, 0x0, 0xff, 0x58, 0x97, 0x0, 0xff, 0x60, 0x97, 0x0, 0xff, 0x68, 0x97, 0x0, 0xff, 0x70, 0x97, 0x0, 0xff, 0x78, 0x97, 0x0, 0xff, 0x80, 0x97, 0x0, 0xff, 0x87, 0x97, 0x0, 0xff, 0x8f, 0x97, 0x0, 0xff, 0x97, 0x97, 0x0, 0xff, 0x9f, 0x97, 0x0, 0xff, 0xa7, 0x97, 0x0, 0xff, 0xaf, 0x97, 0x0, 0xff, 0xb7, 0x97, 0x0, 0xff, 0xbf, 0x97, 0x0, 0xff, 0xc7, 0x97, 0x0, 0xff, 0xcf, 0x97, 0x0, 0xff, 0xd7, 0x97, 0x0, 0xff, 0xdf, 0x97, 0x0, 0xff, 0xe7, 0x97, 0x0, 0xff, 0xef, 0x97, 0x0, 0xff, 0xf7, 0x97, 0x0, 0xff, 0x0, 0x9f, 0x0, 0xff, 0x8, 0x9f, 0x0, 0xff, 0x10, 0x9f, 0x0, 0xff, 0x18, 0x9f, 0x0, 0xff, 0x20, 0x9f, 0x0, 0xff, 0x28, 0x9f, 0x0, 0xff, 0x30, 0x9f, 0x0, 0xff, 0x38, 0x9f, 0x0, 0xff, 0x40, 0x9f, 0x0, 0xff, 0x48, 0x9f, 0x0, 0xff, 0x50, 0x9f, 0x0, 0xff, 0x58, 0x9f, 0x0, 0xff, 0x60, 0x9f, 0x0, 0xff, 0x68, 0x9f, 0x0, 0xff, 0x70, 0x9f, 0x0, 0xff, 0x78, 0x9f, 0x0, 0xff, 0x80, 0x9f, 0x0, 0xff, 0x87, 0x9f, 0x0, 0xff, 0x8f, 0x9f, 0x0, 0xff, 0x97, 0x9f, 0x0, 0xff, 0x9f, 0x9f, 0x0, 0xff, 0xa7, 0x9f, 0x0, 0xff, 0xaf, 0x9f, 0x0, 0xff, 0xb7, 0x9f, 0x0, 0xff, 0xbf, 0x9f, 0x0, 0xff, 0xc7, 0x9f, 0x0, 0xff, 0xcf, 0x9f, 0x0, 0xff, 0xd7, 0x9f, 0x0, 0xff, 0xdf, 0x9f, 0x0, 0xff, 0xe7, 0x9f, 0x0, 0xff, 0xef, 0x9f, 0x0, 0xff, 0xf7, 0x9f, 0x0, 0xff, 0x0, 0xa7, 0x0, 0xff, 0x8, 0xa7, 0x0, 0xff, 0x10, 0xa7, 0x0, 0xff, 0x18, 0xa7, 0x0, 0xff, 0x20, 0xa7, 0x0, 0xff, 0x28, 0xa7, 0x0, 0xff, 0x30, 0xa7, 0x0, 0xff, 0x38, 0xa7, 0x0, 0xff, 0x40, 0xa7, 0x0, 0xff, 0x48, 0xa7, 0x0, 0xff, 0x50, 0xa7, 0x0, 0xff, 0x58, 0xa7, 0x0, 0xff, 0x60, 0xa7, 0x0, 0xff, 0x68, 0xa7, 0x0, 0xff, 0x70, 0xa7, 0x0, 0xff, 0x78, 0xa7, 0x0, 0xff, 0x80, 0xa7, 0x0, 0xff, 0x87, 0xa7, 0x0, 0xff, 0x8f, 0xa7, 0x0, 0xff, 0x97, 0xa7, 0x0, 0xff, 0x9f, 0xa7, 0x0, 0xff, 0xa7, 0xa7, 0x0, 0xff, 0xaf, 0xa7, 0x0, 0xff, 0xb7, 0xa7, 0x0, 0xff, 0xbf, 0xa7, 0x0, 0xff, 0xc7, 0xa7, 0x0, 0xff, 0xcf, 0xa7, 0x0, 0xff, 0xd7, 0xa7, 0x0, 0xff, 0xdf, 0xa7, 0x0, 0xff, 0xe7, 0xa7, 0x0, 0xff, 0xef, 0xa7, 0x0, 0xff, 0xf7, 0xa7, 0x0, 0xff, 0x0, 0xaf, 0x0, 0xff, 0x8, 0xaf, 0x0, 0xff, 0x10, 0xaf, 0x0, 0xff, 0x18, 0xaf, 0x0, 0xff, 0x20, 0xaf, 0x0, 0xff, 0x28, 0xaf, 0x0, 0xff, 0x30, 0xaf, 0x0, 0xff, 0x38, 0xaf, 0x0, 0xff, 0x40, 0xaf, 0x0, 0xff, 0x48, 0xaf, 0x0, 0xff, 0x50, 0xaf, 0x0, 0xff, 0x58, 0xaf, 0x0, 0xff, 0x60, 0xaf, 0x0, 0xff, 0x68, 0xaf, 0x0, 0xff, 0x70, 0xaf, 0x0, 0xff, 0x78, 0xaf, 0x0, 0xff, 0x80, 0xaf, 0x0, 0xff, 0x87, 0xaf, 0x0, 0xff, 0x8f, 0xaf, 0x0, 0xff, 0x97, 0xaf, 0x0, 0xff, 0x9f, 0xaf, 0x0, 0xff, 0xa7, 0xaf, 0x0, 0xff, 0xaf, 0xaf, 0x0, 0xff, 0xb7, 0xaf, 0x0, 0xff, 0xbf, 0xaf, 0x0, 0xff, 0xc7, 0xaf, 0x0, 0xff, 0xcf, 0xaf, 0x0, 0xff, 0xd7, 0xaf, 0x0, 0xff, 0xdf, 0xaf, 0x0, 0xff, 0xe7, 0xaf, 0x0, 0xff, 0xef, 0xaf, 0x0, 0xff, 0xf7, 0xaf, 0x0, 0xff, 0x0, 0xb7, 0x0, 0xff, 0x8, 0xb7, 0x0, 0xff, 0x10, 0xb7, 0x0, 0xff, 0x18, 0xb7, 0x0, 0xff, 0x20, 0xb7, 0x0, 0xff, 0x28, 0xb7, 0x0, 0xff, 0x30, 0xb7, 0x0, 0xff, 0x38, 0xb7, 0x0, 0xff, 0x40, 0xb7, 0x0, 0xff, 0x48, 0xb7, 0x0, 0xff, 0x50, 0xb7, 0x0, 0xff, 0x58, 0xb7, 0x0, 0xff, 0x60, 0xb7, 0x0, 0xff, 0x68, 0xb7, 0x0, 0xff, 0x70, 0xb7, 0x0, 0xff, 0x78, 0xb7, 0x0, 0xff, 0x80, 0xb7, 0x0, 0xff, 0x87, 0xb7, 0x0, 0xff, 0x8f, 0xb7, 0x0, 0xff, 0x97, 0xb7, 0x0, 0xff, 0x9f, 0xb7, 0x0, 0xff, 0xa7, 0xb7, 0x0, 0xff, 0xaf, 0xb7, 0x0, 0xff, 0xb7, 0xb7, 0x0, 0xff, 0xbf, 0xb7, 0x0, 0xff, 0xc7, 0xb7, 0x0, 0xff, 0xcf, 0xb7, 0x0, 0xff, 0xd7, 0xb7, 0x0, 0xff, 0xdf, 0xb7, 0x0, 0xff, 0xe7, 0xb7, 0x0, 0xff, 0xef, 0xb7, 0x0, 0xff, 0xf7, 0xb7, 0x0, 0xff, 0x0, 0xbf, 0x0, 0xff, 0x8, 0xbf, 0x0, 0xff, 0x10, 0xbf, 0x0, 0xff, 0x18, 0xbf, 0x0, 0xff, 0x20, 0xbf, 0x0, 0xff, 0x28, 0xbf, 0x0, 0xff, 0x30, 0xbf, 0x0, 0xff, 0x38, 0xbf, 0x0, 0xff, 0x40, 0xbf, 0x0, 0xff, 0x48, 0xbf, 0x0, 0xff, 0x50, 0xbf, 0x0, 0xff, 0x58, 0xbf, 0x0, 0xff, 0x60, 0xbf, 0x0, 0xff, 0x68, 0xbf, 0x0, 0xff, 0x70, 0xbf, 0x0, 0xff, 0x78, 0xbf, 0x0, 0xff, 0x80, 0xbf, 0x0, 0xff, 0x87, 0xbf, 0x0, 0xff, 0x8f, 0xbf, 0x0, 0xff, 0x97, 0xbf, 0x0, 0xff, 0x9f, 0xbf, 0x0, 0xff, 0xa7, 0xbf, 0x0, 0xff, 0xaf, 0xbf, 0x0, 0xff, 0xb7, 0xbf, 0x0, 0xff, 0xbf, 0xbf, 0x0, 0xff, 0xc7, 0xbf, 0x0, 0xff, 0xcf, 0xbf, 0x0, 0xff, 0xd7, 0xbf, 0x0, 0xff, 0xdf, 0xbf, 0x0, 0xff, 0xe7, 0xbf, 0x0, 0xff, 0xef, 0xbf, 0x0, 0xff, 0xf7, 0xbf, 0x0, 0xff, 0x0, 0xc7, 0x0, 0xff, 0x8, 0xc7, 0x0, 0xff, 0x10, 0xc7, 0x0, 0xff, 0x18, 0xc7, 0x0, 0xff, 0x20, 0xc7, 0x0, 0xff, 0x28, 0xc7, 0x0, 0xff, 0x30, 0xc7, 0x0, 0xff, 0x38, 0xc7, 0x0, 0xff, 0x40, 0xc7, 0x0, 0xff, 0x48, 0xc7, 0x0, 0xff, 0x50, 0xc7, 0x0, 0xff, 0x58, 0xc7, 0x0, 0xff, 0x60, 0xc7, 0x0, 0xff, 0x68, 0xc7, 0x0, 0xff, 0x70, 0xc7, 0x0, 0xff, 0x78, 0xc7, 0x0, 0xff, 0x80, 0xc7, 0x0, 0xff, 0x87, 0xc7, 0x0, 0xff, 0x8f, 0xc7, 0x0, 0xff, 0x97, 0xc7, 0x0, 0xff, 0x9f, 0xc7, 0x0, 0xff, 0xa7, 0xc7, 0x0, 0xff, 0xaf, 0xc7, 0x0, 0xff, 0xb7, 0xc7, 0x0, 0xff, 0xbf, 0xc7, 0x0, 0xff, 0xc7, 0xc7, 0x0, 0xff, 0xcf, 0xc7, 0x0, 0xff, 0xd7, 0xc7, 0x0, 0xff, 0xdf, 0xc7, 0x0, 0xff, 0xe7, 0xc7, 0x0, 0xff, 0xef, 0xc7, 0x0, 0xff, 0xf7, 0xc7, 0x0, 0xff, 0x0, 0xcf, 0x0, 0xff, 0x8, 0xcf, 0x0, 0xff, 0x10, 0xcf, 0x0, 0xff, 0x18, 0xcf, 0x0, 0xff, 0x20, 0xcf, 0x0, 0xff, 0x28, 0xcf, 0x0, 0xff, 0x30, 0xcf, 0x0, 0xff, 0x38, 0xcf, 0x0, 0xff, 0x40, 0xcf, 0x0, 0xff, 0x48, 0xcf, 0x0, 0xff, 0x50, 0xcf, 0x0, 0xff, 0x58, 0xcf, 0x0, 0xff, 0x60, 0xcf, 0x0, 0xff, 0x68, 0xcf, 0x0, 0xff, 0x70, 0xcf, 0x0, 0xff, 0x78, 0xcf, 0x0, 0xff, 0x80, 0xcf, 0x0, 0xff, 0x87, 0xcf, 0x0, 0xff, 0x8f, 0xcf, 0x0, 0xff, 0x97, 0xcf, 0x0, 0xff, 0x9f, 0xcf, 0x0, 0xff, 0xa7, 0xcf, 0x0, 0xff, 0xaf, 0xcf, 0x0, 0xff, 0xb7, 0xcf, 0x0, 0xff, 0xbf, 0xcf, 0x0, 0xff, 0xc7, 0xcf, 0x0, 0xff, 0xcf, 0xcf, 0x0, 0xff, 0xd7, 0xcf, 0x0, 0xff, 0xdf, 0xcf, 0x0, 0xff, 0xe7, 0xcf, 0x0, 0xff, 0xef, 0xcf, 0x0, 0xff, 0xf7, 0xcf, 0x0, 0xff, 0x0, 0xd7, 0x0, 0xff, 0x8, 0xd7, 0x0, 0xff, 0x10, 0xd7, 0x0, 0xff, 0x18, 0xd7, 0x0, 0xff, 0x20, 0xd7, 0x0, 0xff, 0x28, 0xd7, 0x0, 0xff, 0x30, 0xd7, 0x0, 0xff, 0x38, 0xd7, 0x0, 0xff, 0x40, 0xd7, 0x0, 0xff, 0x48, 0xd7, 0x0, 0xff, 0x50, 0xd7, 0x0, 0xff, 0x58, 0xd7, 0x0, 0xff, 0x60, 0xd7, 0x0, 0xff, 0x68, 0xd7, 0x0, 0xff, 0x70, 0xd7, 0x0, 0xff, 0x78, 0xd7, 0x0, 0xff, 0x80, 0xd7, 0x0, 0xff, 0x87, 0xd7, 0x0, 0xff, 0x8f, 0xd7, 0x0, 0xff, 0x97, 0xd7, 0x0, 0xff, 0x9f, 0xd7, 0x0, 0xff, 0xa7, 0xd7, 0x0, 0xff, 0xaf, 0xd7, 0x0, 0xff, 0xb7, 0xd7, 0x0, 0xff, 0xbf, 0xd7, 0x0, 0xff, 0xc7, 0xd7, 0x0, 0xff, 0xcf, 0xd7, 0x0, 0xff, 0xd7, 0xd7, 0x0, 0xff, 0xdf, 0xd7, 0x0, 0xff, 0xe7, 0xd7, 0x0, 0xff, 0xef, 0xd7, 0x0, 0xff, 0xf7, 0xd7, 0x0, 0xff, 0x0, 0xdf, 0x0, 0xff, 0x8, 0xdf, 0x0, 0xff, 0x10, 0xdf, 0x0, 0xff, 0x18, 0xdf, 0x0, 0xff, 0x20, 0xdf, 0x0, 0xff, 0x28, 0xdf, 0x0, 0xff, 0x30, 0xdf, 0x0, 0xff, 0x38, 0xdf, 0x0, 0xff, 0x40, 0xdf, 0x0, 0xff, 0x48, 0xdf, 0x0, 0xff, 0x50, 0xdf, 0x0, 0xff, 0x58, 0xdf, 0x0, 0xff, 0x60, 0xdf, 0x0, 0xff, 0x68, 0xdf, 0x0, 0xff, 0x70, 0xdf, 0x0, 0xff, 0x78, 0xdf, 0x0, 0xff, 0x80, 0xdf, 0x0, 0xff, 0x87, 0xdf, 0x0, 0xff, 0x8f, 0xdf, 0x0, 0xff, 0x97, 0xdf, 0x0, 0xff, 0x9f, 0xdf, 0x0, 0xff, 0xa7, 0xdf, 0x0, 0xff, 0xaf, 0xdf, 0x0, 0xff, 0xb7, 0xdf, 0x0, 0xff, 0xbf, 0xdf, 0x0, 0xff, 0xc7, 0xdf, 0x0, 0xff, 0xcf, 0xdf, 0x0, 0xff, 0xd7, 0xdf, 0x0, 0xff, 0xdf, 0xdf, 0x0, 0xff, 0xe7, 0xdf, 0x0, 0xff, 0xef, 0xdf, 0x0, 0xff, 0xf7, 0xdf, 0x0, 0xff, 0x0, 0xe7, 0x0, 0xff, 0x8, 0xe7, 0x0, 0xff, 0x10, 0xe7, 0x0, 0xff, 0x18, 0xe7, 0x0, 0xff, 0x20, 0xe7, 0x0, 0xff, 0x28, 0xe7, 0x0, 0xff, 0x30, 0xe7, 0x0, 0xff, 0x38, 0xe7, 0x0, 0xff, 0x40, 0xe7, 0x0, 0xff, 0x48, 0xe7, 0x0, 0xff, 0x50, 0xe7, 0x0, 0xff, 0x58, 0xe7, 0x0, 0xff, 0x60, 0xe7, 0x0, 0xff, 0x68, 0xe7, 0x0, 0xff, 0x70, 0xe7, 0x0, 0xff, 0x78, 0xe7, 0x0, 0xff, 0x80, 0xe7, 0x0, 0xff, 0x87, 0xe7, 0x0, 0xff, 0x8f, 0xe7, 0x0, 0xff, 0x97, 0xe7, 0x0, 0xff, 0x9f, 0xe7, 0x0, 0xff, 0xa7, 0xe7, 0x0, 0xff, 0xaf, 0xe7, 0x0, 0xff, 0xb7, 0xe7, 0x0, 0xff, 0xbf, 0xe7, 0x0, 0xff, 0xc7, 0xe7, 0x0, 0xff, 0xcf, 0xe7, 0x0, 0xff, 0xd7, 0xe7, 0x0, 0xff, 0xdf, 0xe7, 0x0, 0xff, 0xe7, 0xe7, 0x0, 0xff, 0xef, 0xe7, 0x0, 0xff, 0xf7, 0xe7, 0x0, 0xff, 0x0, 0xef, 0x0, 0xff, 0x8, 0xef, 0x0, 0xff, 0x10, 0xef, 0x0, 0xff, 0x18, 0xef, 0x0, 0xff, 0x20, 0xef, 0x0, 0xff, 0x28, 0xef, 0x0, 0xff, 0x30, 0xef, 0x0, 0xff, 0x38, 0xef, 0x0, 0xff, 0x40, 0xef, 0x0, 0xff, 0x48, 0xef, 0x0, 0xff, 0x50, 0xef, 0x0, 0xff, 0x58, 0xef, 0x0, 0xff, 0x60, 0xef, 0x0, 0xff, 0x68, 0xef, 0x0, 0xff, 0x70, 0xef, 0x0, 0xff, 0x78, 0xef, 0x0, 0xff, 0x80, 0xef, 0x0, 0xff, 0x87, 0xef, 0x0, 0xff, 0x8f, 0xef, 0x0, 0xff, 0x97, 0xef, 0x0, 0xff, 0x9f, 0xef, 0x0, 0xff, 0xa7, 0xef, 0x0, 0xff, 0xaf, 0xef, 0x0, 0xff, 0xb7, 0xef, 0x0, 0xff, 0xbf, 0xef, 0x0, 0xff, 0xc7, 0xef, 0x0, 0xff, 0xcf, 0xef, 0x0, 0xff, 0xd7, 0xef, 0x0, 0xff, 0xdf, 0xef, 0x0, 0xff, 0xe7, 0xef, 0x0, 0xff, 0xef, 0xef, 0x0, 0xff, 0xf7, 0xef, 0x0, 0xff, 0x0, 0xf7, 0x0, 0xff, 0x8, 0xf7, 0x0, 0xff, 0x10, 0xf7, 0x0, 0xff, 0x18, 0xf7, 0x0, 0xff, 0x20, 0xf7, 0x0, 0xff, 0x28, 0xf7, 0x0, 0xff, 0x30, 0xf7, 0x0, 0xff, 0x38, 0xf7, 0x0, 0xff, 0x40, 0xf7, 0x0, 0xff, 0x48, 0xf7, 0x0, 0xff, 0x50, 0xf7, 0x0, 0xff, 0x58, 0xf7, 0x0, 0xff, 0x60, 0xf7, 0x0, 0xff, 0x68, 0xf7, 0x0, 0xff, 0x70, 0xf7, 0x0, 0xff, 0x78, 0xf7, 0x0, 0xff, 0x80, 0xf7, 0x0, 0xff, 0x87, 0xf7, 0x0, 0xff, 0x8f, 0xf7, 0x0, 0xff, 0x97, 0xf7, 0x0, 0xff, 0x9f, 0xf7, 0x0, 0xff, 0xa7, 0xf7, 0x0, 0xff, 0xaf, 0xf7, 0x0, 0xff, 0xb7, 0xf7, 0x0, 0xff, 0xbf, 0xf7, 0x0, 0xff, 0xc7, 0xf7, 0x0, 0xff, 0xcf, 0xf7, 0x0, 0xff, 0xd7, 0xf7, 0x0, 0xff, 0xdf, 0xf7, 0x0, 0xff, 0xe7, 0xf7, 0x0, 0xff, 0xef, 0xf7, 0x0, 0xff, 0xf7, 0xf7, 0x0, 0xff}
 	wantStride := 128
 	wantRect := image.Rect(0, 0, 32, 32)
 
 	assert.Equal(t, wantPix, img.Pix)
+
+	// channels round to the nearest level like the other formats, so a half is 0x80 rather than the truncated 0x7f
+	assert.Equal(t, uint8(0x80), img.Pix[16*4])
 	assert.Equal(t, wantStride, img.Stride)
 	assert.Equal(t, wantRect, img.Rect)
 }
 
+func TestToImage16(t *testing.T) {
+	canv := NewCanvas(2, 2)
+	canv.Set(0, 0, color.NewColor(1, 0.5, 0))
+	canv.Set(1, 1, color.NewColor(2, -1, 0.25))
+
+	img := canv.ToImage16()
+
+	assert.Equal(t, image.Rect(0, 0, 2, 2), img.Rect)
+	assert.Equal(t, stdcolor.RGBA64{0xffff, 0x8000, 0, 0xffff}, img.RGBA64At(0, 0))
+	assert.Equal(t, stdcolor.RGBA64{0, 0, 0, 0xffff}, img.RGBA64At(1, 0))
+	assert.Equal(t, stdcolor.RGBA64{0xffff, 0, 0x4000, 0xffff}, img.RGBA64At(1, 1))
+}
+
 func BenchmarkCanvas_ToImage32(b *testing.B) {
 	canv := NewCanvas(32, 32)
 
@@ -0,0 +1,154 @@
+package canvas
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// exrChannel is one channel of an OpenEXR image, such as R, or the R of a layer in "normal.R"
+type exrChannel struct {
+	name  string
+	value func(x, y int) float64
+}
+
+// WriteEXR encodes the canvas as an uncompressed scanline OpenEXR image with 32-bit float channels,
+// which keeps the full range of every pixel
+func (c *Canvas) WriteEXR(w io.Writer) error {
+	return writeEXR(w, c.W, c.H, rgbChannels("", c))
+}
+
+// Layer is a named canvas in a multi-layer image
+type Layer struct {
+	// Name prefixes the channels of the layer, or is empty for the main R, G and B channels
+	Name   string
+	Canvas *Canvas
+}
+
+// WriteLayeredEXR encodes several canvases of the same size as the layers of one OpenEXR image
+func WriteLayeredEXR(w io.Writer, layers []Layer) error {
+	if len(layers) == 0 {
+		return errors.New("no layers to write")
+	}
+
+	width, height := layers[0].Canvas.W, layers[0].Canvas.H
+	channels := make([]exrChannel, 0, len(layers)*3)
+
+	for _, l := range layers {
+		if l.Canvas.W != width || l.Canvas.H != height {
+			return fmt.Errorf("layer %q is %dx%d, but the image is %dx%d", l.Name, l.Canvas.W, l.Canvas.H, width, height)
+		}
+
+		channels = append(channels, rgbChannels(l.Name, l.Canvas)...)
+	}
+
+	return writeEXR(w, width, height, channels)
+}
+
+// rgbChannels returns the red, green and blue channels of a canvas, with their names prefixed by a layer
+func rgbChannels(layer string, c *Canvas) []exrChannel {
+	if layer != "" {
+		layer += "."
+	}
+
+	return []exrChannel{
+		{layer + "R", func(x, y int) float64 { return c.Get(x, y).R }},
+		{layer + "G", func(x, y int) float64 { return c.Get(x, y).G }},
+		{layer + "B", func(x, y int) float64 { return c.Get(x, y).B }},
+	}
+}
+
+func writeEXR(w io.Writer, width, height int, channels []exrChannel) error {
+	// readers expect the channels in alphabetical order
+	sort.Slice(channels, func(i, j int) bool { return channels[i].name < channels[j].name })
+
+	header := new(bytes.Buffer)
+
+	// magic number, then version 2 for a single part scanline image
+	header.Write([]byte{0x76, 0x2f, 0x31, 0x01, 2, 0, 0, 0})
+
+	chlist := new(bytes.Buffer)
+	for _, ch := range channels {
+		chlist.WriteString(ch.name)
+		chlist.WriteByte(0)
+		// 32-bit float pixels, not perceptually linear, then three reserved bytes and x and y sampling
+		writeLE(chlist, int32(2), uint8(0), [3]uint8{}, int32(1), int32(1))
+	}
+	chlist.WriteByte(0)
+
+	writeEXRAttribute(header, "channels", "chlist", chlist.Bytes())
+	writeEXRAttribute(header, "compression", "compression", []byte{0})
+
+	window := new(bytes.Buffer)
+	writeLE(window, int32(0), int32(0), int32(width-1), int32(height-1))
+	writeEXRAttribute(header, "dataWindow", "box2i", window.Bytes())
+	writeEXRAttribute(header, "displayWindow", "box2i", window.Bytes())
+
+	// scanlines are stored from the top of the image down
+	writeEXRAttribute(header, "lineOrder", "lineOrder", []byte{0})
+
+	one := new(bytes.Buffer)
+	writeLE(one, float32(1))
+	writeEXRAttribute(header, "pixelAspectRatio", "float", one.Bytes())
+	writeEXRAttribute(header, "screenWindowCenter", "v2f", make([]byte, 8))
+	writeEXRAttribute(header, "screenWindowWidth", "float", one.Bytes())
+
+	header.WriteByte(0)
+
+	// an uncompressed image stores each scanline in its own block
+	blockSize := 8 + 4*width*len(channels)
+	offset := uint64(header.Len() + 8*height)
+
+	bw := bufio.NewWriter(w)
+
+	if _, err := bw.Write(header.Bytes()); err != nil {
+		return err
+	}
+
+	for y := 0; y < height; y++ {
+		if err := writeLE(bw, offset+uint64(y*blockSize)); err != nil {
+			return err
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		if err := writeLE(bw, int32(y), int32(blockSize-8)); err != nil {
+			return err
+		}
+
+		for _, ch := range channels {
+			for x := 0; x < width; x++ {
+				if err := writeLE(bw, math.Float32bits(float32(ch.value(x, y)))); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writeEXRAttribute(header *bytes.Buffer, name, kind string, value []byte) {
+	header.WriteString(name)
+	header.WriteByte(0)
+	header.WriteString(kind)
+	header.WriteByte(0)
+	writeLE(header, int32(len(value)))
+	header.Write(value)
+}
+
+// writeLE writes values in little endian byte order
+func writeLE(w io.Writer, values ...interface{}) error {
+	for _, v := range values {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readEXRHeader reads the attributes of an EXR header, and returns the offset of the data after it
+func readEXRHeader(data []byte) (map[string][]byte, int) {
+	attrs := make(map[string][]byte)
+	i := 8
+
+	readString := func() string {
+		end := bytes.IndexByte(data[i:], 0)
+		s := string(data[i : i+end])
+		i += end + 1
+		return s
+	}
+
+	for data[i] != 0 {
+		name := readString()
+		readString()
+		size := int(binary.LittleEndian.Uint32(data[i:]))
+		i += 4
+		attrs[name] = data[i : i+size]
+		i += size
+	}
+
+	return attrs, i + 1
+}
+
+func TestCanvas_WriteEXR(t *testing.T) {
+	c := NewCanvas(3, 2)
+	c.Set(0, 0, color.NewColor(10, 0.5, -1))
+	c.Set(2, 1, color.NewColor(0.25, 0, 3))
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, c.WriteEXR(buf))
+	data := buf.Bytes()
+
+	assert.Equal(t, []byte{0x76, 0x2f, 0x31, 0x01, 2, 0, 0, 0}, data[:8])
+
+	attrs, offset := readEXRHeader(data)
+
+	for _, name := range []string{"channels", "compression", "dataWindow", "displayWindow", "lineOrder",
+		"pixelAspectRatio", "screenWindowCenter", "screenWindowWidth"} {
+		assert.Contains(t, attrs, name)
+	}
+
+	// the channels are sorted by name
+	chlist := attrs["channels"]
+	assert.Equal(t, "B", string(chlist[0:1]))
+	assert.Equal(t, "G", string(chlist[18:19]))
+	assert.Equal(t, "R", string(chlist[36:37]))
+
+	assert.Equal(t, []byte{0}, attrs["compression"])
+	assert.Equal(t, []uint32{0, 0, 2, 1}, []uint32{
+		binary.LittleEndian.Uint32(attrs["dataWindow"][0:]),
+		binary.LittleEndian.Uint32(attrs["dataWindow"][4:]),
+		binary.LittleEndian.Uint32(attrs["dataWindow"][8:]),
+		binary.LittleEndian.Uint32(attrs["dataWindow"][12:]),
+	})
+
+	pixel := func(y int, channel, x int) float32 {
+		block := int(binary.LittleEndian.Uint64(data[offset+y*8:]))
+
+		assert.Equal(t, uint32(y), binary.LittleEndian.Uint32(data[block:]))
+		assert.Equal(t, uint32(3*3*4), binary.LittleEndian.Uint32(data[block+4:]))
+
+		return math.Float32frombits(binary.LittleEndian.Uint32(data[block+8+(channel*3+x)*4:]))
+	}
+
+	// channels are B, G, R
+	assert.Equal(t, float32(-1), pixel(0, 0, 0))
+	assert.Equal(t, float32(0.5), pixel(0, 1, 0))
+	assert.Equal(t, float32(10), pixel(0, 2, 0))
+	assert.Equal(t, float32(3), pixel(1, 0, 2))
+	assert.Equal(t, float32(0.25), pixel(1, 2, 2))
+
+	// the last block ends the file
+	lastBlock := int(binary.LittleEndian.Uint64(data[offset+8:]))
+	assert.Equal(t, len(data), lastBlock+8+3*3*4)
+}
+
+func TestWriteLayeredEXR(t *testing.T) {
+	beauty := NewCanvas(2, 1)
+	depth := NewCanvas(2, 1)
+	depth.Set(1, 0, color.Grey(7.5))
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, WriteLayeredEXR(buf, []Layer{{Canvas: beauty}, {Name: "depth", Canvas: depth}}))
+	data := buf.Bytes()
+
+	attrs, offset := readEXRHeader(data)
+
+	var names []string
+	for _, entry := range bytes.Split(attrs["channels"], []byte{0}) {
+		// each name is followed by the 16 bytes of its channel description
+		if len(entry) > 0 && entry[0] >= 'A' {
+			names = append(names, string(entry))
+		}
+	}
+	assert.Equal(t, []string{"B", "G", "R", "depth.B", "depth.G", "depth.R"}, names)
+
+	// the depth.R channel comes last, and holds the depth of the second pixel last
+	block := int(binary.LittleEndian.Uint64(data[offset:]))
+	assert.Equal(t, uint32(6*2*4), binary.LittleEndian.Uint32(data[block+4:]))
+	assert.Equal(t, float32(7.5), math.Float32frombits(binary.LittleEndian.Uint32(data[block+8+11*4:])))
+
+	t.Run("Layers must be the same size", func(t *testing.T) {
+		err := WriteLayeredEXR(new(bytes.Buffer), []Layer{{Canvas: beauty}, {Name: "depth", Canvas: NewCanvas(1, 1)}})
+		assert.EqualError(t, err, `layer "depth" is 1x1, but the image is 2x1`)
+		assert.Error(t, WriteLayeredEXR(new(bytes.Buffer), nil))
+	})
+}
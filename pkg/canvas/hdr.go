@@ -0,0 +1,262 @@
+package canvas
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// ReadHDR decodes a Radiance RGBE (.hdr) image, keeping its full range of values.
+// Only the standard top to bottom, left to right orientation is supported.
+func ReadHDR(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(magic, "#?") {
+		return nil, errors.New("not a radiance hdr file")
+	}
+
+	// the header ends with a blank line
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT=32-bit_rle_rgbe" {
+			return nil, errors.New("unsupported hdr format: " + line)
+		}
+	}
+
+	var w, h int
+
+	res, err := br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := fmt.Sscanf(res, "-Y %d +X %d", &h, &w); err != nil {
+		return nil, errors.New("unsupported hdr resolution: " + strings.TrimSpace(res))
+	}
+
+	c := NewCanvas(w, h)
+	if c == nil {
+		return nil, errors.New("hdr image is empty")
+	}
+
+	scanline := make([]byte, 4*w)
+
+	for y := 0; y < h; y++ {
+		if err := readHDRScanline(br, scanline, w); err != nil {
+			return nil, err
+		}
+
+		for x := 0; x < w; x++ {
+			r, g, b := rgbeToFloat(scanline[x*4], scanline[x*4+1], scanline[x*4+2], scanline[x*4+3])
+
+			p := c.Get(x, y)
+			p.R, p.G, p.B = r, g, b
+		}
+	}
+
+	return c, nil
+}
+
+// readHDRScanline reads one row of RGBE pixels, which may be run length encoded
+func readHDRScanline(br *bufio.Reader, scanline []byte, w int) error {
+	if _, err := io.ReadFull(br, scanline[:4]); err != nil {
+		return err
+	}
+
+	// rows outside of this width range, or not starting with the marker, are stored flat
+	if w < 8 || w > 0x7fff || scanline[0] != 2 || scanline[1] != 2 || scanline[2]&0x80 != 0 {
+		_, err := io.ReadFull(br, scanline[4:])
+		return err
+	}
+
+	if int(scanline[2])<<8|int(scanline[3]) != w {
+		return errors.New("hdr scanline width doesn't match the image")
+	}
+
+	// each channel is run length encoded separately
+	for channel := 0; channel < 4; channel++ {
+		for x := 0; x < w; {
+			count, err := br.ReadByte()
+			if err != nil {
+				return err
+			}
+
+			if count > 128 {
+				run := int(count) - 128
+
+				value, err := br.ReadByte()
+				if err != nil {
+					return err
+				}
+
+				if x+run > w {
+					return errors.New("hdr run overflows the scanline")
+				}
+
+				for ; run > 0; run-- {
+					scanline[x*4+channel] = value
+					x++
+				}
+			} else {
+				if count == 0 || x+int(count) > w {
+					return errors.New("invalid hdr run length")
+				}
+
+				for ; count > 0; count-- {
+					value, err := br.ReadByte()
+					if err != nil {
+						return err
+					}
+
+					scanline[x*4+channel] = value
+					x++
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// WriteHDR encodes the canvas as a run length encoded Radiance RGBE (.hdr) image,
+// which keeps the full range of every pixel with about 1% precision
+func (c *Canvas) WriteHDR(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	if _, err := fmt.Fprintf(bw, "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y %d +X %d\n", c.H, c.W); err != nil {
+		return err
+	}
+
+	scanline := make([]byte, 4*c.W)
+
+	for y := 0; y < c.H; y++ {
+		for x := 0; x < c.W; x++ {
+			p := c.Get(x, y)
+			scanline[x*4], scanline[x*4+1], scanline[x*4+2], scanline[x*4+3] = floatToRGBE(p.R, p.G, p.B)
+		}
+
+		if err := writeHDRScanline(bw, scanline, c.W); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeHDRScanline writes one row of RGBE pixels, run length encoding it if the width allows
+func writeHDRScanline(bw *bufio.Writer, scanline []byte, w int) error {
+	if w < 8 || w > 0x7fff {
+		_, err := bw.Write(scanline)
+		return err
+	}
+
+	if _, err := bw.Write([]byte{2, 2, byte(w >> 8), byte(w & 0xff)}); err != nil {
+		return err
+	}
+
+	channel := make([]byte, w)
+
+	for ch := 0; ch < 4; ch++ {
+		for x := 0; x < w; x++ {
+			channel[x] = scanline[x*4+ch]
+		}
+
+		if err := writeHDRRuns(bw, channel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeHDRRuns encodes one channel of a scanline as runs of a repeated byte, and dumps of differing bytes
+func writeHDRRuns(bw *bufio.Writer, data []byte) error {
+	const minRun = 4
+
+	for x := 0; x < len(data); {
+		// find the next run which is long enough to be worth encoding
+		start := x
+		run := 0
+
+		for start < len(data) {
+			run = 1
+			for start+run < len(data) && run < 127 && data[start+run] == data[start] {
+				run++
+			}
+
+			if run >= minRun {
+				break
+			}
+
+			start += run
+		}
+
+		// the bytes before the run are dumped as they are
+		for x < start {
+			count := start - x
+			if count > 128 {
+				count = 128
+			}
+
+			if err := bw.WriteByte(byte(count)); err != nil {
+				return err
+			}
+			if _, err := bw.Write(data[x : x+count]); err != nil {
+				return err
+			}
+
+			x += count
+		}
+
+		if run >= minRun {
+			if _, err := bw.Write([]byte{byte(128 + run), data[start]}); err != nil {
+				return err
+			}
+
+			x += run
+		}
+	}
+
+	return nil
+}
+
+// floatToRGBE converts a pixel to 8-bit values with an exponent shared by all three channels
+func floatToRGBE(r, g, b float64) (byte, byte, byte, byte) {
+	v := math.Max(r, math.Max(g, b))
+	if v < 1e-32 {
+		return 0, 0, 0, 0
+	}
+
+	m, e := math.Frexp(v)
+	scale := m * 256 / v
+
+	return byte(math.Max(r, 0) * scale), byte(math.Max(g, 0) * scale), byte(math.Max(b, 0) * scale), byte(e + 128)
+}
+
+// rgbeToFloat converts a pixel with a shared exponent to floating point values
+func rgbeToFloat(r, g, b, e byte) (float64, float64, float64) {
+	if e == 0 {
+		return 0, 0, 0
+	}
+
+	f := math.Ldexp(1, int(e)-(128+8))
+
+	return float64(r) * f, float64(g) * f, float64(b) * f
+}
@@ -0,0 +1,150 @@
+package canvas
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadHDR(t *testing.T) {
+	header := "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n"
+
+	testCases := []struct {
+		name string
+		data []byte
+		want []*color.Color
+		w, h int
+	}{
+		{
+			name: "flat pixels",
+			data: append([]byte(header+"-Y 1 +X 2\n"),
+				128, 64, 0, 129,
+				255, 255, 255, 0),
+			want: []*color.Color{
+				color.NewColor(1, 0.5, 0),
+				color.NewColor(0, 0, 0),
+			},
+			w: 2,
+			h: 1,
+		},
+		{
+			name: "run length encoded",
+			data: append([]byte(header+"-Y 1 +X 8\n"),
+				2, 2, 0, 8,
+				// red: a run of 8
+				128+8, 128,
+				// green: 8 literal values
+				8, 0, 0, 0, 0, 128, 128, 128, 128,
+				// blue: a run of 4 and a run of 4
+				128+4, 0, 128+4, 64,
+				// exponent
+				128+8, 130),
+			want: []*color.Color{
+				color.NewColor(2, 0, 0),
+				color.NewColor(2, 0, 0),
+				color.NewColor(2, 0, 0),
+				color.NewColor(2, 0, 0),
+				color.NewColor(2, 2, 1),
+				color.NewColor(2, 2, 1),
+				color.NewColor(2, 2, 1),
+				color.NewColor(2, 2, 1),
+			},
+			w: 8,
+			h: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ReadHDR(bytes.NewReader(tc.data))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.w, c.W)
+			assert.Equal(t, tc.h, c.H)
+
+			for i, want := range tc.want {
+				assert.Equal(t, want, c.Get(i%tc.w, i/tc.w))
+			}
+		})
+	}
+}
+
+func TestReadHDR_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "not hdr",
+			data: "P3\n1 1\n255\n",
+		},
+		{
+			name: "unsupported format",
+			data: "#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n",
+		},
+		{
+			name: "flipped orientation",
+			data: "#?RADIANCE\n\n+Y 1 +X 1\n",
+		},
+		{
+			name: "truncated pixels",
+			data: "#?RADIANCE\n\n-Y 2 +X 2\n\x01\x02",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadHDR(strings.NewReader(tc.data))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCanvas_WriteHDR(t *testing.T) {
+	testCases := []struct {
+		name  string
+		width int
+	}{
+		{
+			name:  "narrow images are stored flat",
+			width: 3,
+		},
+		{
+			name:  "wide images are run length encoded",
+			width: 300,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewCanvas(tc.width, 2)
+
+			// a mix of long runs and varied pixels
+			for x := 0; x < tc.width; x++ {
+				c.Set(x, 0, color.NewColor(100, 0.5, 0))
+				c.Set(x, 1, color.NewColor(float64(x)/10, 1, float64(x%3)))
+			}
+
+			buf := new(bytes.Buffer)
+			assert.NoError(t, c.WriteHDR(buf))
+
+			got, err := ReadHDR(buf)
+			assert.NoError(t, err)
+
+			for i := range c.Pix {
+				want := c.Pix[i]
+				// the shared exponent keeps about 1% of the brightest channel
+				tolerance := math.Max(want.R, math.Max(want.G, want.B)) / 100
+
+				assert.InDelta(t, want.R, got.Pix[i].R, tolerance)
+				assert.InDelta(t, want.G, got.Pix[i].G, tolerance)
+				assert.InDelta(t, want.B, got.Pix[i].B, tolerance)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package canvas
+
+import (
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Henelik/tricaster/pkg/color"
+)
+
+// LoadImage reads an image file into a canvas.
+// Radiance .hdr files keep their full range, and other formats are decoded from sRGB to linear colors from 0 to 1.
+func LoadImage(path string) (*Canvas, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".hdr") {
+		return ReadHDR(file)
+	}
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromImage(img), nil
+}
+
+// FromImage copies an sRGB encoded image into a new canvas of linear colors
+func FromImage(img image.Image) *Canvas {
+	bounds := img.Bounds()
+
+	c := NewCanvas(bounds.Dx(), bounds.Dy())
+	if c == nil {
+		return nil
+	}
+
+	for y := 0; y < c.H; y++ {
+		for x := 0; x < c.W; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+
+			c.Set(x, y, color.NewColor(
+				srgbDecode(float64(r)/0xffff),
+				srgbDecode(float64(g)/0xffff),
+				srgbDecode(float64(b)/0xffff)))
+		}
+	}
+
+	return c
+}
+
+// Sample returns the bilinearly filtered color at texture coordinates u and v, from 0 to 1.
+// u wraps around horizontally, and v is clamped vertically, with v=0 at the top of the image.
+func (c *Canvas) Sample(u, v float64) *color.Color {
+	x := u*float64(c.W) - 0.5
+	y := v*float64(c.H) - 0.5
+
+	x0 := int(math.Floor(x))
+	y0 := int(math.Floor(y))
+
+	fx := x - float64(x0)
+	fy := y - float64(y0)
+
+	top := c.wrapped(x0, y0).Lerp(c.wrapped(x0+1, y0), fx)
+	bottom := c.wrapped(x0, y0+1).Lerp(c.wrapped(x0+1, y0+1), fx)
+
+	return top.Lerp(bottom, fy)
+}
+
+// wrapped returns the pixel at x and y, wrapping x around the edges and clamping y
+func (c *Canvas) wrapped(x, y int) *color.Color {
+	x %= c.W
+	if x < 0 {
+		x += c.W
+	}
+
+	if y < 0 {
+		y = 0
+	} else if y >= c.H {
+		y = c.H - 1
+	}
+
+	return c.Get(x, y)
+}
@@ -0,0 +1,105 @@
+package canvas
+
+import (
+	"image"
+	imgcolor "image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, imgcolor.RGBA{R: 255, A: 255})
+	img.Set(1, 0, imgcolor.RGBA{G: 255, B: 255, A: 255})
+
+	gray := image.NewGray(image.Rect(0, 0, 1, 1))
+	gray.Set(0, 0, imgcolor.Gray{Y: 188})
+
+	c := FromImage(img)
+
+	// sRGB middle gray is decoded to about half brightness
+	assert.InDelta(t, 0.5, FromImage(gray).Get(0, 0).R, 0.01)
+
+	assert.Equal(t, 2, c.W)
+	assert.Equal(t, 1, c.H)
+	assert.Equal(t, color.NewColor(1, 0, 0), c.Get(0, 0))
+	assert.Equal(t, color.NewColor(0, 1, 1), c.Get(1, 0))
+}
+
+func TestLoadImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(2, 1, imgcolor.RGBA{R: 255, G: 255, B: 255, A: 255})
+
+	path := filepath.Join(t.TempDir(), "test.png")
+
+	file, err := os.Create(path)
+	assert.NoError(t, err)
+	assert.NoError(t, png.Encode(file, img))
+	assert.NoError(t, file.Close())
+
+	c, err := LoadImage(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, c.W)
+	assert.Equal(t, 2, c.H)
+	assert.Equal(t, color.White, c.Get(2, 1))
+	assert.Equal(t, color.Black, c.Get(0, 0))
+
+	_, err = LoadImage(filepath.Join(t.TempDir(), "missing.hdr"))
+	assert.Error(t, err)
+}
+
+func TestSample(t *testing.T) {
+	c := NewCanvas(2, 2)
+	c.Set(0, 0, color.NewColor(1, 0, 0))
+	c.Set(1, 0, color.NewColor(0, 1, 0))
+	c.Set(0, 1, color.NewColor(0, 0, 1))
+	c.Set(1, 1, color.NewColor(1, 1, 1))
+
+	testCases := []struct {
+		name string
+		u, v float64
+		want *color.Color
+	}{
+		{
+			name: "pixel center",
+			u:    0.25,
+			v:    0.25,
+			want: color.NewColor(1, 0, 0),
+		},
+		{
+			name: "between two pixels",
+			u:    0.5,
+			v:    0.25,
+			want: color.NewColor(0.5, 0.5, 0),
+		},
+		{
+			name: "wraps horizontally",
+			u:    1,
+			v:    0.75,
+			want: color.NewColor(0.5, 0.5, 1),
+		},
+		{
+			name: "clamps vertically",
+			u:    0.75,
+			v:    1,
+			want: color.NewColor(1, 1, 1),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := c.Sample(tc.u, tc.v)
+
+			assert.InDelta(t, tc.want.R, got.R, 1e-9)
+			assert.InDelta(t, tc.want.G, got.G, 1e-9)
+			assert.InDelta(t, tc.want.B, got.B, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package canvas
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WritePFM encodes the canvas as a little endian, floating point Portable FloatMap,
+// which keeps the full range of every pixel
+func (c *Canvas) WritePFM(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	// a negative scale marks the data as little endian
+	if _, err := fmt.Fprintf(bw, "PF\n%d %d\n-1.0\n", c.W, c.H); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 12)
+
+	// rows are stored from the bottom of the image to the top
+	for y := c.H - 1; y >= 0; y-- {
+		for x := 0; x < c.W; x++ {
+			p := c.Get(x, y)
+
+			binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(float32(p.R)))
+			binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(float32(p.G)))
+			binary.LittleEndian.PutUint32(buf[8:], math.Float32bits(float32(p.B)))
+
+			if _, err := bw.Write(buf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
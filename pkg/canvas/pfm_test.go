@@ -0,0 +1,35 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanvas_WritePFM(t *testing.T) {
+	c := NewCanvas(2, 2)
+	c.Set(0, 0, color.NewColor(10, 0.5, -1))
+	c.Set(1, 1, color.NewColor(0.25, 0, 3))
+
+	buf := new(bytes.Buffer)
+	assert.NoError(t, c.WritePFM(buf))
+
+	header := "PF\n2 2\n-1.0\n"
+	assert.Equal(t, header, buf.String()[:len(header)])
+
+	data := buf.Bytes()[len(header):]
+	assert.Len(t, data, 2*2*3*4)
+
+	value := func(i int) float32 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+
+	// the bottom row comes first
+	assert.Equal(t, []float32{0.25, 0, 3}, []float32{value(3), value(4), value(5)})
+	assert.Equal(t, []float32{10, 0.5, -1}, []float32{value(6), value(7), value(8)})
+}
@@ -0,0 +1,69 @@
+package canvas
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ppmLineLength is the longest line allowed in a plain PPM file
+const ppmLineLength = 70
+
+// WritePPM encodes the canvas as a Portable Pixmap with 8 or 16 bits per channel.
+// Plain files store each value as ASCII text, like the canvas_to_ppm of the book, while raw files store bytes.
+func (c *Canvas) WritePPM(w io.Writer, bitDepth int, plain bool) error {
+	if bitDepth != 8 && bitDepth != 16 {
+		return fmt.Errorf("unsupported PPM bit depth: %d", bitDepth)
+	}
+
+	maxValue := 1<<bitDepth - 1
+
+	magic := "P6"
+	if plain {
+		magic = "P3"
+	}
+
+	bw := bufio.NewWriter(w)
+
+	if _, err := fmt.Fprintf(bw, "%s\n%d %d\n%d\n", magic, c.W, c.H, maxValue); err != nil {
+		return err
+	}
+
+	for y := 0; y < c.H; y++ {
+		line := make([]byte, 0, ppmLineLength)
+
+		for x := 0; x < c.W; x++ {
+			p := c.Get(x, y)
+
+			for _, v := range []float64{p.R, p.G, p.B} {
+				q := quantize(v, maxValue)
+
+				if !plain {
+					if bitDepth == 16 {
+						bw.WriteByte(byte(q >> 8))
+					}
+					bw.WriteByte(byte(q))
+					continue
+				}
+
+				// wrap lines before they get too long, and end every row on a new line
+				value := strconv.Itoa(q)
+				if len(line) > 0 && len(line)+1+len(value) > ppmLineLength {
+					bw.Write(append(line, '\n'))
+					line = line[:0]
+				}
+				if len(line) > 0 {
+					line = append(line, ' ')
+				}
+				line = append(line, value...)
+			}
+		}
+
+		if plain {
+			bw.Write(append(line, '\n'))
+		}
+	}
+
+	return bw.Flush()
+}
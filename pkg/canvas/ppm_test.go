@@ -0,0 +1,77 @@
+package canvas
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanvas_WritePPM(t *testing.T) {
+	c := NewCanvas(5, 3)
+	c.Set(0, 0, color.NewColor(1.5, 0, 0))
+	c.Set(2, 1, color.NewColor(0, 0.5, 0))
+	c.Set(4, 2, color.NewColor(-0.5, 0, 1))
+
+	t.Run("Plain PPM matches the book", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, c.WritePPM(&buf, 8, true))
+
+		assert.Equal(t, `P3
+5 3
+255
+255 0 0 0 0 0 0 0 0 0 0 0 0 0 0
+0 0 0 0 0 0 0 128 0 0 0 0 0 0 0
+0 0 0 0 0 0 0 0 0 0 0 0 0 0 255
+`, buf.String())
+	})
+
+	t.Run("Plain PPM lines are at most 70 characters", func(t *testing.T) {
+		wide := NewCanvas(10, 2)
+		for i := range wide.Pix {
+			wide.Pix[i] = *color.NewColor(1, 0.8, 0.6)
+		}
+
+		var buf bytes.Buffer
+		assert.NoError(t, wide.WritePPM(&buf, 8, true))
+
+		lines := strings.Split(buf.String(), "\n")
+		assert.Equal(t, []string{
+			"P3",
+			"10 2",
+			"255",
+			"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204",
+			"153 255 204 153 255 204 153 255 204 153 255 204 153",
+			"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204",
+			"153 255 204 153 255 204 153 255 204 153 255 204 153",
+			"",
+		}, lines)
+	})
+
+	t.Run("Raw PPM", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, c.WritePPM(&buf, 8, false))
+
+		header := "P6\n5 3\n255\n"
+		assert.Equal(t, header, buf.String()[:len(header)])
+		assert.Equal(t, len(header)+5*3*3, buf.Len())
+		assert.Equal(t, []byte{255, 0, 0}, buf.Bytes()[len(header):len(header)+3])
+	})
+
+	t.Run("16-bit raw PPM is big endian", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, c.WritePPM(&buf, 16, false))
+
+		header := "P6\n5 3\n65535\n"
+		assert.Equal(t, header, buf.String()[:len(header)])
+		assert.Equal(t, len(header)+5*3*3*2, buf.Len())
+		assert.Equal(t, []byte{0xff, 0xff, 0, 0}, buf.Bytes()[len(header):len(header)+4])
+	})
+
+	t.Run("Other bit depths are an error", func(t *testing.T) {
+		assert.Error(t, c.WritePPM(&bytes.Buffer{}, 4, true))
+	})
+}
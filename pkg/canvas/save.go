@@ -0,0 +1,137 @@
+package canvas
+
+import (
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// Formats are the file extensions which Save can write
+var Formats = []string{".png", ".jpg", ".jpeg", ".ppm", ".tif", ".tiff", ".pfm", ".hdr", ".exr"}
+
+// SaveOptions control how a canvas is encoded by Save
+type SaveOptions struct {
+	ToneMapping ToneMapping
+	// BitDepth is 8 or 16 bits per channel for PNG, PPM and TIFF images, and defaults to 8
+	BitDepth int
+	// Quality is the JPEG quality from 1 to 100, and defaults to jpeg.DefaultQuality
+	Quality int
+	// Plain writes PPM images as ASCII text instead of raw bytes
+	Plain bool
+	// Raw skips the tone mapping, for canvases which hold data such as depths or normals rather than colors
+	Raw bool
+}
+
+// Save writes the canvas to a file, in the format given by its extension.
+// PNG, JPEG, PPM and TIFF images are tone mapped, while PFM, Radiance .hdr and OpenEXR images keep the linear colors of the render.
+func (c *Canvas) Save(path string, o SaveOptions) error {
+	bitDepth := o.BitDepth
+	if bitDepth == 0 {
+		bitDepth = 8
+	}
+
+	if bitDepth != 8 && bitDepth != 16 {
+		return fmt.Errorf("unsupported bit depth: %d", bitDepth)
+	}
+
+	// display formats are tone mapped, unless the canvas holds data
+	display := func() *Canvas {
+		if o.Raw {
+			return c
+		}
+		return c.ToneMapped(o.ToneMapping)
+	}
+
+	var encode func(w io.Writer) error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		encode = func(w io.Writer) error {
+			if bitDepth == 16 {
+				return png.Encode(w, display().ToImage16())
+			}
+			return png.Encode(w, display().ToImage())
+		}
+	case ".jpg", ".jpeg":
+		if bitDepth != 8 {
+			return errors.New("JPEG images only support a bit depth of 8")
+		}
+
+		quality := o.Quality
+		if quality == 0 {
+			quality = jpeg.DefaultQuality
+		}
+
+		encode = func(w io.Writer) error {
+			return jpeg.Encode(w, display().ToImage(), &jpeg.Options{Quality: quality})
+		}
+	case ".ppm":
+		encode = func(w io.Writer) error {
+			return display().WritePPM(w, bitDepth, o.Plain)
+		}
+	case ".tif", ".tiff":
+		encode = func(w io.Writer) error {
+			return display().WriteTIFF(w, bitDepth)
+		}
+	case ".pfm":
+		encode = c.WritePFM
+	case ".hdr":
+		encode = c.WriteHDR
+	case ".exr":
+		encode = c.WriteEXR
+	default:
+		return errors.New("unsupported image format: " + path)
+	}
+
+	return writeFile(path, encode)
+}
+
+// IsFloatFormat returns true if images with the extension of path keep the linear values of a canvas,
+// rather than levels from 0 to 1
+func IsFloatFormat(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pfm", ".hdr", ".exr":
+		return true
+	default:
+		return false
+	}
+}
+
+// SaveLayers writes canvases as the layers of a multi-layer OpenEXR file
+func SaveLayers(path string, layers []Layer) error {
+	if strings.ToLower(filepath.Ext(path)) != ".exr" {
+		return errors.New("layers can only be saved as OpenEXR: " + path)
+	}
+
+	return writeFile(path, func(w io.Writer) error {
+		return WriteLayeredEXR(w, layers)
+	})
+}
+
+// writeFile creates a file and fills it with an encoder
+func writeFile(path string, encode func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := encode(file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// quantize converts a channel value to an integer level from 0 to maxValue, rounding to the nearest
+func quantize(x float64, maxValue int) int {
+	return int(math.Round(util.Clamp(x, 0, 1) * float64(maxValue)))
+}
@@ -0,0 +1,113 @@
+package canvas
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanvas_Save(t *testing.T) {
+	c := NewCanvas(2, 1)
+	c.Set(0, 0, color.NewColor(4, 0.214, 0))
+
+	dir := t.TempDir()
+
+	t.Run("PNG images are tone mapped", func(t *testing.T) {
+		path := filepath.Join(dir, "out.png")
+		assert.NoError(t, c.Save(path, SaveOptions{}))
+
+		got, err := LoadImage(path)
+		assert.NoError(t, err)
+		assert.InDelta(t, 1, got.Get(0, 0).R, 1e-9)
+		assert.InDelta(t, 0.214, got.Get(0, 0).G, 0.005)
+	})
+
+	t.Run("HDR images keep their range", func(t *testing.T) {
+		path := filepath.Join(dir, "out.hdr")
+		assert.NoError(t, c.Save(path, SaveOptions{}))
+
+		got, err := LoadImage(path)
+		assert.NoError(t, err)
+		assert.InDelta(t, 4, got.Get(0, 0).R, 0.04)
+	})
+
+	t.Run("16-bit PNG images", func(t *testing.T) {
+		path := filepath.Join(dir, "out16.png")
+		assert.NoError(t, c.Save(path, SaveOptions{BitDepth: 16}))
+
+		file, err := os.Open(path)
+		assert.NoError(t, err)
+		defer file.Close()
+
+		img, err := png.Decode(file)
+		assert.NoError(t, err)
+		assert.IsType(t, &image.RGBA64{}, img)
+	})
+
+	t.Run("JPEG images", func(t *testing.T) {
+		path := filepath.Join(dir, "out.jpg")
+		assert.NoError(t, c.Save(path, SaveOptions{Quality: 100}))
+
+		got, err := LoadImage(path)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, got.W)
+		assert.Error(t, c.Save(path, SaveOptions{BitDepth: 16}))
+	})
+
+	t.Run("Plain PPM images", func(t *testing.T) {
+		path := filepath.Join(dir, "out.ppm")
+		assert.NoError(t, c.Save(path, SaveOptions{Plain: true}))
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "P3\n2 1\n255\n255 127 0 0 0 0\n", string(data))
+	})
+
+	t.Run("Raw images skip the tone mapping", func(t *testing.T) {
+		path := filepath.Join(dir, "raw.ppm")
+		assert.NoError(t, c.Save(path, SaveOptions{Plain: true, Raw: true}))
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "P3\n2 1\n255\n255 55 0 0 0 0\n", string(data))
+	})
+
+	t.Run("Layers", func(t *testing.T) {
+		layers := []Layer{{Canvas: c}, {Name: "albedo", Canvas: c}}
+		assert.NoError(t, SaveLayers(filepath.Join(dir, "layers.exr"), layers))
+		assert.Error(t, SaveLayers(filepath.Join(dir, "layers.png"), layers))
+	})
+
+	t.Run("Unsupported bit depths are an error", func(t *testing.T) {
+		assert.Error(t, c.Save(filepath.Join(dir, "out.tif"), SaveOptions{BitDepth: 12}))
+	})
+
+	for _, ext := range []string{".pfm", ".exr", ".PFM", ".ppm", ".tif", ".tiff"} {
+		t.Run("Saving "+ext, func(t *testing.T) {
+			path := filepath.Join(dir, "out"+ext)
+			assert.NoError(t, c.Save(path, SaveOptions{}))
+
+			info, err := os.Stat(path)
+			assert.NoError(t, err)
+			assert.NotZero(t, info.Size())
+		})
+	}
+
+	t.Run("Unknown formats are an error", func(t *testing.T) {
+		assert.Error(t, c.Save(filepath.Join(dir, "out.xyz"), SaveOptions{}))
+	})
+}
+
+func TestIsFloatFormat(t *testing.T) {
+	assert.True(t, IsFloatFormat("render.exr"))
+	assert.True(t, IsFloatFormat("render.HDR"))
+	assert.True(t, IsFloatFormat("render.pfm"))
+	assert.False(t, IsFloatFormat("render.png"))
+	assert.False(t, IsFloatFormat("render.tiff"))
+}
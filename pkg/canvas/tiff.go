@@ -0,0 +1,91 @@
+package canvas
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// TIFF tag types
+const (
+	tiffShort = 3
+	tiffLong  = 4
+)
+
+type tiffEntry struct {
+	tag   uint16
+	kind  uint16
+	count uint32
+	value uint32
+}
+
+// WriteTIFF encodes the canvas as an uncompressed little endian baseline TIFF, with 8 or 16 bits per channel
+func (c *Canvas) WriteTIFF(w io.Writer, bitDepth int) error {
+	if bitDepth != 8 && bitDepth != 16 {
+		return fmt.Errorf("unsupported TIFF bit depth: %d", bitDepth)
+	}
+
+	const entries = 10
+	maxValue := 1<<bitDepth - 1
+	bytesPerSample := bitDepth / 8
+
+	// the header is followed by the directory, then the bits per sample of each channel, then the pixels
+	ifdOffset := uint32(8)
+	bitsOffset := ifdOffset + 2 + entries*12 + 4
+	pixelOffset := bitsOffset + 3*2
+	pixelBytes := uint32(c.W * c.H * 3 * bytesPerSample)
+
+	// the entries must be sorted by tag
+	directory := [entries]tiffEntry{
+		{256, tiffLong, 1, uint32(c.W)}, // image width
+		{257, tiffLong, 1, uint32(c.H)}, // image length
+		{258, tiffShort, 3, bitsOffset}, // bits per sample
+		{259, tiffShort, 1, 1},          // no compression
+		{262, tiffShort, 1, 2},          // RGB
+		{273, tiffLong, 1, pixelOffset}, // strip offsets
+		{277, tiffShort, 1, 3},          // samples per pixel
+		{278, tiffLong, 1, uint32(c.H)}, // rows per strip
+		{279, tiffLong, 1, pixelBytes},  // strip byte counts
+		{284, tiffShort, 1, 1},          // chunky planar configuration
+	}
+
+	bw := bufio.NewWriter(w)
+
+	if err := writeLE(bw, []byte("II"), uint16(42), ifdOffset, uint16(entries)); err != nil {
+		return err
+	}
+
+	for _, e := range directory {
+		if err := writeLE(bw, e.tag, e.kind, e.count, e.value); err != nil {
+			return err
+		}
+	}
+
+	// no further directories
+	if err := writeLE(bw, uint32(0), uint16(bitDepth), uint16(bitDepth), uint16(bitDepth)); err != nil {
+		return err
+	}
+
+	for y := 0; y < c.H; y++ {
+		for x := 0; x < c.W; x++ {
+			p := c.Get(x, y)
+
+			for _, v := range []float64{p.R, p.G, p.B} {
+				q := quantize(v, maxValue)
+
+				var err error
+				if bitDepth == 16 {
+					err = writeLE(bw, uint16(q))
+				} else {
+					err = bw.WriteByte(byte(q))
+				}
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return bw.Flush()
+}
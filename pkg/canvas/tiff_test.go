@@ -0,0 +1,83 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readTIFFTags reads the tags of the first image file directory, keyed by tag
+func readTIFFTags(data []byte) map[uint16]uint32 {
+	le := binary.LittleEndian
+	ifd := le.Uint32(data[4:])
+	count := int(le.Uint16(data[ifd:]))
+	tags := make(map[uint16]uint32, count)
+
+	for i := 0; i < count; i++ {
+		entry := data[int(ifd)+2+i*12:]
+		tags[le.Uint16(entry)] = le.Uint32(entry[8:])
+	}
+
+	return tags
+}
+
+func TestCanvas_WriteTIFF(t *testing.T) {
+	c := NewCanvas(3, 2)
+	c.Set(0, 0, color.NewColor(1, 0.5, 0))
+	c.Set(2, 1, color.NewColor(0, 0, 2))
+
+	testCases := []struct {
+		name     string
+		bitDepth int
+		first    []byte
+		last     []byte
+	}{
+		{
+			name:     "8-bit",
+			bitDepth: 8,
+			first:    []byte{255, 128, 0},
+			last:     []byte{0, 0, 255},
+		},
+		{
+			name:     "16-bit",
+			bitDepth: 16,
+			first:    []byte{0xff, 0xff, 0x00, 0x80, 0, 0},
+			last:     []byte{0, 0, 0, 0, 0xff, 0xff},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, c.WriteTIFF(&buf, tc.bitDepth))
+
+			data := buf.Bytes()
+			assert.Equal(t, []byte{'I', 'I', 42, 0}, data[:4])
+
+			tags := readTIFFTags(data)
+			assert.Equal(t, uint32(3), tags[256])
+			assert.Equal(t, uint32(2), tags[257])
+			assert.Equal(t, uint32(1), tags[259])
+			assert.Equal(t, uint32(2), tags[262])
+
+			bits := tags[258]
+			for i := uint32(0); i < 3; i++ {
+				assert.Equal(t, uint16(tc.bitDepth), binary.LittleEndian.Uint16(data[bits+i*2:]))
+			}
+
+			offset, size := tags[273], tags[279]
+			assert.Equal(t, uint32(3*2*3*tc.bitDepth/8), size)
+			assert.Equal(t, int(offset+size), len(data))
+
+			pixels := data[offset:]
+			assert.Equal(t, tc.first, pixels[:len(tc.first)])
+			assert.Equal(t, tc.last, pixels[len(pixels)-len(tc.last):])
+		})
+	}
+
+	assert.Error(t, c.WriteTIFF(&bytes.Buffer{}, 32))
+}
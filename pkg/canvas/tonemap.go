@@ -0,0 +1,80 @@
+package canvas
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// ToneOperator compresses the unbounded brightness of a render into the 0 to 1 range of a display
+type ToneOperator int
+
+const (
+	// ToneLinear clips everything brighter than 1
+	ToneLinear ToneOperator = iota
+	// ToneReinhard rolls off highlights smoothly, so nothing is clipped
+	ToneReinhard
+	// ToneACES is a filmic curve, with more contrast than Reinhard and softly clipped highlights
+	ToneACES
+)
+
+// ToneMapping converts linear render colors into display colors for 8-bit images
+type ToneMapping struct {
+	Operator ToneOperator
+	// Exposure brightens the image by this many stops before the operator, or darkens it if negative
+	Exposure float64
+}
+
+// Apply returns the display color of a linear color, which is tone mapped and then sRGB encoded
+func (t ToneMapping) Apply(c *color.Color) *color.Color {
+	scale := math.Exp2(t.Exposure)
+
+	return color.NewColor(
+		srgbEncode(t.curve(c.R*scale)),
+		srgbEncode(t.curve(c.G*scale)),
+		srgbEncode(t.curve(c.B*scale)))
+}
+
+// ToneMapped returns a copy of the canvas with the tone mapping applied to every pixel
+func (c *Canvas) ToneMapped(t ToneMapping) *Canvas {
+	result := NewCanvas(c.W, c.H)
+
+	for i := range c.Pix {
+		result.Pix[i] = *t.Apply(&c.Pix[i])
+	}
+
+	return result
+}
+
+func (t ToneMapping) curve(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	switch t.Operator {
+	case ToneReinhard:
+		return x / (1 + x)
+	case ToneACES:
+		// Krzysztof Narkowicz's fit of the ACES filmic curve
+		return util.Clamp((x*(2.51*x+0.03))/(x*(2.43*x+0.59)+0.14), 0, 1)
+	default:
+		return util.Clamp(x, 0, 1)
+	}
+}
+
+// srgbEncode converts a linear value from 0 to 1 into the sRGB curve used by 8-bit images
+func srgbEncode(x float64) float64 {
+	if x <= 0.0031308 {
+		return x * 12.92
+	}
+	return 1.055*math.Pow(x, 1/2.4) - 0.055
+}
+
+// srgbDecode converts a value from an sRGB encoded image back into a linear value
+func srgbDecode(x float64) float64 {
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
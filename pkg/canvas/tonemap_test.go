@@ -0,0 +1,78 @@
+package canvas
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToneMapping_Apply(t *testing.T) {
+	testCases := []struct {
+		name  string
+		t     ToneMapping
+		color *color.Color
+		want  *color.Color
+	}{
+		{
+			name:  "Linear clips bright colors",
+			t:     ToneMapping{Operator: ToneLinear},
+			color: color.NewColor(4, 1, 0),
+			want:  color.NewColor(1, 1, 0),
+		},
+		{
+			name:  "Middle gray is sRGB encoded",
+			t:     ToneMapping{Operator: ToneLinear},
+			color: color.NewColor(0.214, 0.214, 0.214),
+			want:  color.NewColor(0.5, 0.5, 0.5),
+		},
+		{
+			name:  "Reinhard compresses bright colors without clipping",
+			t:     ToneMapping{Operator: ToneReinhard},
+			color: color.NewColor(1, 3, 0),
+			want:  color.NewColor(srgbEncode(0.5), srgbEncode(0.75), 0),
+		},
+		{
+			name:  "ACES keeps black and rolls off to white",
+			t:     ToneMapping{Operator: ToneACES},
+			color: color.NewColor(0, 100, -1),
+			want:  color.NewColor(0, 1, 0),
+		},
+		{
+			name:  "Exposure is measured in stops",
+			t:     ToneMapping{Operator: ToneLinear, Exposure: 2},
+			color: color.NewColor(0.0535, 0.0535, 0.0535),
+			want:  color.NewColor(0.5, 0.5, 0.5),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.t.Apply(tc.color)
+
+			assert.InDelta(t, tc.want.R, got.R, 0.005)
+			assert.InDelta(t, tc.want.G, got.G, 0.005)
+			assert.InDelta(t, tc.want.B, got.B, 0.005)
+		})
+	}
+}
+
+func TestSRGB(t *testing.T) {
+	for _, x := range []float64{0, 0.001, 0.2, 0.5, 1} {
+		assert.InDelta(t, x, srgbDecode(srgbEncode(x)), 1e-9)
+	}
+}
+
+func TestCanvas_ToneMapped(t *testing.T) {
+	c := NewCanvas(2, 1)
+	c.Set(0, 0, color.NewColor(2, 2, 2))
+
+	got := c.ToneMapped(ToneMapping{})
+
+	assert.True(t, color.White.Equal(got.Get(0, 0)))
+	assert.Equal(t, color.Black, got.Get(1, 0))
+	// white survives 8-bit conversion
+	assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff}, got.ToImage().Pix[:4])
+	// the original is left alone
+	assert.Equal(t, color.NewColor(2, 2, 2), c.Get(0, 0))
+}
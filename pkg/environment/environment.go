@@ -0,0 +1,40 @@
+package environment
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// Environment is the light arriving from far away, seen where rays don't hit anything
+type Environment interface {
+	// ColorAt returns the color seen looking along a normalized direction
+	ColorAt(dir *tuple.Tuple) *color.Color
+}
+
+// Solid is the same color in every direction
+type Solid struct {
+	Color *color.Color
+}
+
+func NewSolid(c *color.Color) *Solid {
+	return &Solid{Color: c}
+}
+
+func (s *Solid) ColorAt(dir *tuple.Tuple) *color.Color {
+	return s.Color
+}
+
+// Gradient is a sky which blends from the bottom color straight down to the top color straight up
+type Gradient struct {
+	Bottom *color.Color
+	Top    *color.Color
+}
+
+func NewGradient(bottom, top *color.Color) *Gradient {
+	return &Gradient{Bottom: bottom, Top: top}
+}
+
+func (g *Gradient) ColorAt(dir *tuple.Tuple) *color.Color {
+	return g.Bottom.Lerp(g.Top, util.Clamp((dir.Z+1)/2, 0, 1))
+}
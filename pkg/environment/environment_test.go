@@ -0,0 +1,49 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolid_ColorAt(t *testing.T) {
+	s := NewSolid(color.NewColor(0.2, 0.4, 0.6))
+
+	assert.Equal(t, color.NewColor(0.2, 0.4, 0.6), s.ColorAt(tuple.Up))
+	assert.Equal(t, color.NewColor(0.2, 0.4, 0.6), s.ColorAt(tuple.Right))
+}
+
+func TestGradient_ColorAt(t *testing.T) {
+	g := NewGradient(color.Black, color.NewColor(0, 0.5, 1))
+
+	testCases := []struct {
+		name string
+		dir  *tuple.Tuple
+		want *color.Color
+	}{
+		{
+			name: "straight up",
+			dir:  tuple.Up,
+			want: color.NewColor(0, 0.5, 1),
+		},
+		{
+			name: "straight down",
+			dir:  tuple.Down,
+			want: color.Black,
+		},
+		{
+			name: "horizon",
+			dir:  tuple.Forward,
+			want: color.NewColor(0, 0.25, 0.5),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, g.ColorAt(tc.dir))
+		})
+	}
+}
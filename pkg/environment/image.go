@@ -0,0 +1,35 @@
+package environment
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/canvas"
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// Image wraps an equirectangular (latitude-longitude) image around the scene.
+// The top row of the image is straight up, and the center column looks along +X.
+type Image struct {
+	Canvas *canvas.Canvas
+	// Strength scales the brightness of the image
+	Strength float64
+	// Rotation turns the image around the up axis, in radians
+	Rotation float64
+}
+
+func NewImage(c *canvas.Canvas, strength, rotation float64) *Image {
+	return &Image{
+		Canvas:   c,
+		Strength: strength,
+		Rotation: rotation,
+	}
+}
+
+func (i *Image) ColorAt(dir *tuple.Tuple) *color.Color {
+	u := 0.5 + (math.Atan2(dir.Y, dir.X)-i.Rotation)/(2*math.Pi)
+	v := math.Acos(util.Clamp(dir.Z, -1, 1)) / math.Pi
+
+	return i.Canvas.Sample(u, v).MultF(i.Strength)
+}
@@ -0,0 +1,60 @@
+package environment
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/canvas"
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImage_ColorAt(t *testing.T) {
+	// 4 columns, centered on each diagonal between the axes, in 2 rows for the upper and lower halves
+	c := canvas.NewCanvas(4, 2)
+	for x := 0; x < 4; x++ {
+		c.Set(x, 0, color.NewColor(float64(x), 1, 0))
+		c.Set(x, 1, color.NewColor(float64(x), 0, 1))
+	}
+
+	testCases := []struct {
+		name     string
+		rotation float64
+		dir      *tuple.Tuple
+		want     *color.Color
+	}{
+		{
+			name: "up",
+			dir:  tuple.NewVector(1, 1, 10).Norm(),
+			want: color.NewColor(2, 1, 0),
+		},
+		{
+			name: "down",
+			dir:  tuple.NewVector(1, 1, -10).Norm(),
+			want: color.NewColor(2, 0, 1),
+		},
+		{
+			name: "along x, between two columns",
+			dir:  tuple.NewVector(1, -0.0001, 0).Norm(),
+			want: color.NewColor(1.5, 0.5, 0.5),
+		},
+		{
+			name:     "rotated",
+			rotation: math.Pi / 2,
+			dir:      tuple.NewVector(0.0001, 1, 0).Norm(),
+			want:     color.NewColor(1.5, 0.5, 0.5),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewImage(c, 2, tc.rotation).ColorAt(tc.dir)
+
+			assert.InDelta(t, tc.want.R*2, got.R, 1e-3)
+			assert.InDelta(t, tc.want.G*2, got.G, 1e-3)
+			assert.InDelta(t, tc.want.B*2, got.B, 1e-3)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package geometry
 
 import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
 	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
@@ -12,6 +15,8 @@ type BasicGroup struct {
 	matrix                 *matrix.Matrix
 	inverseMatrix          *matrix.Matrix
 	inverseTransposeMatrix *matrix.Matrix
+	// bvh accelerates intersection tests once BuildBVH has been called
+	bvh *BVH
 }
 
 func NewBasicGroup(m *matrix.Matrix, parent GroupInterface, children ...Intersecter) *BasicGroup {
@@ -26,6 +31,10 @@ func NewBasicGroup(m *matrix.Matrix, parent GroupInterface, children ...Intersec
 		group.SetMatrix(matrix.Identity)
 	}
 
+	for _, child := range children {
+		child.SetParent(group)
+	}
+
 	return group
 }
 
@@ -41,10 +50,22 @@ func (group *BasicGroup) AddChild(child Intersecter) {
 	child.SetParent(group)
 }
 
+func (group *BasicGroup) GetMatrix() *matrix.Matrix {
+	return group.matrix
+}
+
 func (group *BasicGroup) SetParent(parent GroupInterface) {
 	group.parent = parent
 }
 
+func (group *BasicGroup) SetCastsShadow(casts bool) {
+	for _, child := range group.Children {
+		if c, ok := child.(ShadowCaster); ok {
+			c.SetCastsShadow(casts)
+		}
+	}
+}
+
 func (group *BasicGroup) WorldToGroup(p *tuple.Tuple) *tuple.Tuple {
 	if group.parent != nil {
 		return group.inverseMatrix.MultTuple(group.parent.WorldToGroup(p))
@@ -53,16 +74,55 @@ func (group *BasicGroup) WorldToGroup(p *tuple.Tuple) *tuple.Tuple {
 	return group.inverseMatrix.MultTuple(p)
 }
 
-func (group *BasicGroup) GroupToWorld(p *tuple.Tuple) *tuple.Tuple {
+// GroupToWorld converts a normal vector from group space to world space
+func (group *BasicGroup) GroupToWorld(n *tuple.Tuple) *tuple.Tuple {
+	worldNormal := group.inverseTransposeMatrix.MultTuple(n)
+	worldNormal.W = 0
+
 	if group.parent != nil {
-		return group.inverseTransposeMatrix.MultTuple(group.parent.WorldToGroup(p))
+		return group.parent.GroupToWorld(worldNormal.Norm())
+	}
+
+	return worldNormal.Norm()
+}
+
+// Bounds returns the bounds of every child, in the space of the group's parent
+func (group *BasicGroup) Bounds() *Bounds {
+	if group.bvh != nil {
+		return group.bvh.Bounds().Transform(group.matrix)
+	}
+
+	result := EmptyBounds()
+
+	for _, child := range group.Children {
+		result = result.Union(child.Bounds())
+	}
+
+	return result.Transform(group.matrix)
+}
+
+// BuildBVH builds a bounding volume hierarchy over the group's children and any nested containers.
+// Children must not be added or moved afterwards without rebuilding it.
+func (group *BasicGroup) BuildBVH() {
+	items := make([]Bounded, len(group.Children))
+
+	for i, child := range group.Children {
+		if b, ok := child.(BVHBuilder); ok {
+			b.BuildBVH()
+		}
+
+		items[i] = child
 	}
 
-	return group.inverseTransposeMatrix.MultTuple(p)
+	group.bvh = NewBVH(items)
 }
 
 func (group *BasicGroup) Intersects(r *ray.Ray) []ray.Intersection {
-	rt := r.Transform(group.matrix.Inverse())
+	rt := r.Transform(group.inverseMatrix)
+
+	if group.bvh != nil {
+		return group.bvh.Intersects(rt)
+	}
 
 	result := make([]ray.Intersection, 0, len(group.Children)*2)
 
@@ -72,3 +132,43 @@ func (group *BasicGroup) Intersects(r *ray.Ray) []ray.Intersection {
 
 	return result
 }
+
+// IntersectsClosest returns the nearest intersection of the group's children with 0 < t < tMax
+func (group *BasicGroup) IntersectsClosest(r *ray.Ray, tMax float64) (ray.Intersection, bool) {
+	rt := r.Transform(group.inverseMatrix)
+
+	if group.bvh != nil {
+		return group.bvh.IntersectsClosest(rt, tMax)
+	}
+
+	closest := *ray.NilIntersect
+	found := false
+
+	for _, child := range group.Children {
+		if inter, ok := ClosestIntersection(child, rt, tMax); ok {
+			closest = inter
+			tMax = inter.T
+			found = true
+		}
+	}
+
+	return closest, found
+}
+
+// NormalAt is never called on a group, since its intersections always refer to its children
+func (group *BasicGroup) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
+	panic("NormalAt called on a group")
+}
+
+// Shade is never called on a group, since its intersections always refer to its children
+func (group *BasicGroup) Shade(light light.Light, h *ray.Hit) *color.Color {
+	panic("Shade called on a group")
+}
+
+func (group *BasicGroup) GetMaterial() material.Material {
+	return nil
+}
+
+func (group *BasicGroup) GetIOR() float64 {
+	return 1
+}
@@ -40,10 +40,10 @@ func TestBasicGroup_Intersects(t *testing.T) {
 				tuple.NewVector(0, 0, 1),
 			),
 			want: []ray.Intersection{
-				{4, s1},
-				{6, s1},
-				{1, s2},
-				{3, s2},
+				{T: 4, P: s1},
+				{T: 6, P: s1},
+				{T: 1, P: s2},
+				{T: 3, P: s2},
 			},
 		},
 		{
@@ -54,8 +54,8 @@ func TestBasicGroup_Intersects(t *testing.T) {
 				tuple.NewVector(0, 0, 1),
 			),
 			want: []ray.Intersection{
-				{8, s3},
-				{12, s3},
+				{T: 8, P: s3},
+				{T: 12, P: s3},
 			},
 		},
 	}
@@ -85,3 +85,90 @@ func TestBasicGroup_WorldToGroup(t *testing.T) {
 		assert.True(t, p.Equal(tuple.NewPoint(0, -1, 0)))
 	})
 }
+
+func TestBasicGroup_GroupToWorld(t *testing.T) {
+	t.Run("Converting a normal from object to world space", func(t *testing.T) {
+		g1 := NewBasicGroup(matrix.RotationY(math.Pi/2), nil)
+		g2 := NewBasicGroup(matrix.Scaling(1, 2, 3), nil)
+
+		g1.AddChild(g2)
+
+		tri := NewTriangle(
+			tuple.NewPoint(0, 1, 0),
+			tuple.NewPoint(-1, 0, 0),
+			tuple.NewPoint(1, 0, 0),
+			matrix.Translation(5, 0, 0),
+			nil)
+
+		g2.AddChild(tri)
+
+		n := tri.NormalToWorld(tuple.NewVector(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3))
+
+		assert.InDelta(t, 0.2857, n.X, 1e-4)
+		assert.InDelta(t, 0.4286, n.Y, 1e-4)
+		assert.InDelta(t, -0.8571, n.Z, 1e-4)
+	})
+}
+
+func TestBasicGroup_ChildNormalAt(t *testing.T) {
+	// the group maps object space +z to world space -y, then moves it along x
+	m := matrix.Compose(matrix.Translation(10, 0, 0), matrix.RotationX(math.Pi/2))
+
+	testCases := []struct {
+		name  string
+		child Intersecter
+		point *tuple.Tuple
+		want  *tuple.Tuple
+	}{
+		{
+			name:  "The normal of a sphere in a group",
+			child: NewSphere(nil, nil),
+			point: tuple.NewPoint(10, -1, 0),
+			want:  tuple.NewVector(0, -1, 0),
+		},
+		{
+			name:  "The normal of a cube in a group",
+			child: NewCube(nil, nil),
+			point: tuple.NewPoint(10, -1, 0.5),
+			want:  tuple.NewVector(0, -1, 0),
+		},
+		{
+			name:  "The normal of a plane in a group",
+			child: NewPlane(nil, nil),
+			point: tuple.NewPoint(12, 0, 3),
+			want:  tuple.NewVector(0, -1, 0),
+		},
+		{
+			name:  "The normal of a cylinder in a group",
+			child: NewCylinder(-1, 1, false, nil, nil),
+			point: tuple.NewPoint(10, 0, 1),
+			want:  tuple.NewVector(0, 0, 1),
+		},
+		{
+			name:  "The normal of a cone in a group",
+			child: NewCone(-1, 0, false, nil, nil),
+			point: tuple.NewPoint(11, 1, 0),
+			want:  tuple.NewVector(1, -1, 0).Norm(),
+		},
+		{
+			name: "The normal of a triangle in a group",
+			child: NewTriangle(
+				tuple.NewPoint(0, 1, 0),
+				tuple.NewPoint(-1, 0, 0),
+				tuple.NewPoint(1, 0, 0),
+				nil,
+				nil),
+			point: tuple.NewPoint(10, 0, 0.5),
+			want:  tuple.NewVector(0, 1, 0),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			NewBasicGroup(m, nil, tc.child)
+
+			got := tc.child.(ray.Primitive).NormalAt(tc.point)
+
+			assert.True(t, tc.want.Equal(got), "got %v", got)
+		})
+	}
+}
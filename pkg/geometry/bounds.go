@@ -0,0 +1,152 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// Bounds is an axis-aligned bounding box.
+// Unbounded primitives such as planes have infinite components.
+type Bounds struct {
+	Min *tuple.Tuple
+	Max *tuple.Tuple
+}
+
+func NewBounds(min, max *tuple.Tuple) *Bounds {
+	return &Bounds{
+		Min: min,
+		Max: max,
+	}
+}
+
+// EmptyBounds returns bounds which contain nothing, and which any union will replace
+func EmptyBounds() *Bounds {
+	return NewBounds(
+		tuple.NewPoint(math.Inf(1), math.Inf(1), math.Inf(1)),
+		tuple.NewPoint(math.Inf(-1), math.Inf(-1), math.Inf(-1)))
+}
+
+// InfiniteBounds returns bounds which contain everything
+func InfiniteBounds() *Bounds {
+	return NewBounds(
+		tuple.NewPoint(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
+		tuple.NewPoint(math.Inf(1), math.Inf(1), math.Inf(1)))
+}
+
+// IsFinite returns true if no component of the bounds is infinite
+func (b *Bounds) IsFinite() bool {
+	return !math.IsInf(b.Min.X, 0) && !math.IsInf(b.Min.Y, 0) && !math.IsInf(b.Min.Z, 0) &&
+		!math.IsInf(b.Max.X, 0) && !math.IsInf(b.Max.Y, 0) && !math.IsInf(b.Max.Z, 0)
+}
+
+// AddPoint returns new bounds which also contain p
+func (b *Bounds) AddPoint(p *tuple.Tuple) *Bounds {
+	return NewBounds(
+		tuple.NewPoint(util.Min(b.Min.X, p.X), util.Min(b.Min.Y, p.Y), util.Min(b.Min.Z, p.Z)),
+		tuple.NewPoint(util.Max(b.Max.X, p.X), util.Max(b.Max.Y, p.Y), util.Max(b.Max.Z, p.Z)))
+}
+
+// Union returns new bounds which contain both b and o
+func (b *Bounds) Union(o *Bounds) *Bounds {
+	return b.AddPoint(o.Min).AddPoint(o.Max)
+}
+
+// Overlap returns the bounds where b and o intersect, which are empty if they don't
+func (b *Bounds) Overlap(o *Bounds) *Bounds {
+	return NewBounds(
+		tuple.NewPoint(util.Max(b.Min.X, o.Min.X), util.Max(b.Min.Y, o.Min.Y), util.Max(b.Min.Z, o.Min.Z)),
+		tuple.NewPoint(util.Min(b.Max.X, o.Max.X), util.Min(b.Max.Y, o.Max.Y), util.Min(b.Max.Z, o.Max.Z)))
+}
+
+// Transform returns axis-aligned bounds containing b transformed by m.
+// Infinite bounds stay infinite, since their corners can't be transformed.
+func (b *Bounds) Transform(m *matrix.Matrix) *Bounds {
+	if !b.IsFinite() {
+		return InfiniteBounds()
+	}
+
+	result := EmptyBounds()
+
+	for _, x := range []float64{b.Min.X, b.Max.X} {
+		for _, y := range []float64{b.Min.Y, b.Max.Y} {
+			for _, z := range []float64{b.Min.Z, b.Max.Z} {
+				result = result.AddPoint(m.MultTuple(tuple.NewPoint(x, y, z)))
+			}
+		}
+	}
+
+	return result
+}
+
+// Centroid returns the center of the bounds
+func (b *Bounds) Centroid() *tuple.Tuple {
+	return tuple.NewPoint(
+		(b.Min.X+b.Max.X)/2,
+		(b.Min.Y+b.Max.Y)/2,
+		(b.Min.Z+b.Max.Z)/2)
+}
+
+// SurfaceArea returns the area of the box's faces, or 0 for empty bounds
+func (b *Bounds) SurfaceArea() float64 {
+	dx := b.Max.X - b.Min.X
+	dy := b.Max.Y - b.Min.Y
+	dz := b.Max.Z - b.Min.Z
+
+	if dx < 0 || dy < 0 || dz < 0 {
+		return 0
+	}
+
+	return 2 * (dx*dy + dy*dz + dz*dx)
+}
+
+// Intersects checks if the ray passes through the box between tMin and tMax,
+// returning the t value where it enters.
+func (b *Bounds) Intersects(r *ray.Ray, tMin, tMax float64) (float64, bool) {
+	tEnter, tExit := tMin, tMax
+
+	for a := 0; a < 3; a++ {
+		origin, dir := axis(r.Origin, a), axis(r.Direction, a)
+		min, max := axis(b.Min, a), axis(b.Max, a)
+
+		// a ray parallel to the slab only hits it if it starts inside
+		if math.Abs(dir) < util.Epsilon {
+			if origin < min || origin > max {
+				return 0, false
+			}
+
+			continue
+		}
+
+		t0 := (min - origin) / dir
+		t1 := (max - origin) / dir
+
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+
+		tEnter = util.Max(tEnter, t0)
+		tExit = util.Min(tExit, t1)
+
+		if tEnter > tExit {
+			return 0, false
+		}
+	}
+
+	return tEnter, true
+}
+
+// axis returns the component of a tuple along the x (0), y (1) or z (2) axis
+func axis(t *tuple.Tuple, a int) float64 {
+	switch a {
+	case 0:
+		return t.X
+	case 1:
+		return t.Y
+	default:
+		return t.Z
+	}
+}
@@ -0,0 +1,153 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBounds_Primitives(t *testing.T) {
+	inf := math.Inf(1)
+
+	testCases := []struct {
+		name     string
+		p        Bounded
+		wantMin  *tuple.Tuple
+		wantMax  *tuple.Tuple
+		infinite bool
+	}{
+		{
+			name:    "A sphere has a bounding box",
+			p:       NewSphere(nil, nil),
+			wantMin: tuple.NewPoint(-1, -1, -1),
+			wantMax: tuple.NewPoint(1, 1, 1),
+		},
+		{
+			name:    "A transformed sphere has a bounding box in parent space",
+			p:       NewSphere(matrix.Translation(1, -3, 5).Mult(matrix.Scaling(0.5, 2, 4)), nil),
+			wantMin: tuple.NewPoint(0.5, -5, 1),
+			wantMax: tuple.NewPoint(1.5, -1, 9),
+		},
+		{
+			name:    "A cube has a bounding box",
+			p:       NewCube(nil, nil),
+			wantMin: tuple.NewPoint(-1, -1, -1),
+			wantMax: tuple.NewPoint(1, 1, 1),
+		},
+		{
+			name:     "A plane has infinite bounds",
+			p:        NewPlane(nil, nil),
+			infinite: true,
+		},
+		{
+			name:    "A bounded cylinder has a bounding box",
+			p:       NewCylinder(-5, 3, false, nil, nil),
+			wantMin: tuple.NewPoint(-1, -1, -5),
+			wantMax: tuple.NewPoint(1, 1, 3),
+		},
+		{
+			name:     "An unbounded cylinder has infinite bounds",
+			p:        NewCylinder(-inf, inf, false, nil, nil),
+			infinite: true,
+		},
+		{
+			name:    "A bounded cone has a bounding box",
+			p:       NewCone(-5, 3, false, nil, nil),
+			wantMin: tuple.NewPoint(-5, -5, -5),
+			wantMax: tuple.NewPoint(5, 5, 3),
+		},
+		{
+			name:     "An unbounded cone has infinite bounds",
+			p:        NewCone(-inf, inf, false, nil, nil),
+			infinite: true,
+		},
+		{
+			name: "A triangle has a bounding box",
+			p: NewTriangle(
+				tuple.NewPoint(-3, 7, 2),
+				tuple.NewPoint(6, 2, -4),
+				tuple.NewPoint(2, -1, -1),
+				nil, nil),
+			wantMin: tuple.NewPoint(-3, -1, -4),
+			wantMax: tuple.NewPoint(6, 7, 2),
+		},
+		{
+			name: "A group has a bounding box containing its children",
+			p: NewBasicGroup(nil, nil,
+				NewSphere(matrix.Translation(2, 5, -3).Mult(matrix.ScalingU(2)), nil),
+				NewSphere(matrix.Translation(-4, -1, 4).Mult(matrix.Scaling(0.5, 0.5, 2)), nil)),
+			wantMin: tuple.NewPoint(-4.5, -1.5, -5),
+			wantMax: tuple.NewPoint(4, 7, 6),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := tc.p.Bounds()
+
+			if tc.infinite {
+				assert.False(t, b.IsFinite())
+				return
+			}
+
+			assert.True(t, tc.wantMin.Equal(b.Min), "min %v", b.Min)
+			assert.True(t, tc.wantMax.Equal(b.Max), "max %v", b.Max)
+		})
+	}
+}
+
+func TestBounds_Union(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(-5, -2, 0), tuple.NewPoint(7, 4, 4)).
+		Union(NewBounds(tuple.NewPoint(8, -7, -2), tuple.NewPoint(14, 2, 8)))
+
+	assert.Equal(t, tuple.NewPoint(-5, -7, -2), b.Min)
+	assert.Equal(t, tuple.NewPoint(14, 4, 8), b.Max)
+
+	assert.Equal(t, b, EmptyBounds().Union(b))
+}
+
+func TestBounds_Transform(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1)).
+		Transform(matrix.RotationX(math.Pi / 4).Mult(matrix.RotationY(math.Pi / 4)))
+
+	assert.True(t, tuple.NewPoint(-1.4142, -1.7071, -1.7071).Sub(b.Min).Mag() < 1e-4)
+	assert.True(t, tuple.NewPoint(1.4142, 1.7071, 1.7071).Sub(b.Max).Mag() < 1e-4)
+
+	assert.False(t, InfiniteBounds().Transform(matrix.Translation(1, 2, 3)).IsFinite())
+}
+
+func TestBounds_Intersects(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(5, -2, 0), tuple.NewPoint(11, 4, 7))
+
+	testCases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+		want      bool
+	}{
+		{tuple.NewPoint(15, 1, 2), tuple.NewVector(-1, 0, 0), true},
+		{tuple.NewPoint(-5, -1, 4), tuple.NewVector(1, 0, 0), true},
+		{tuple.NewPoint(7, 6, 5), tuple.NewVector(0, -1, 0), true},
+		{tuple.NewPoint(9, -5, 6), tuple.NewVector(0, 1, 0), true},
+		{tuple.NewPoint(8, 2, 12), tuple.NewVector(0, 0, -1), true},
+		{tuple.NewPoint(6, 0, -5), tuple.NewVector(0, 0, 1), true},
+		{tuple.NewPoint(8, 1, 3.5), tuple.NewVector(0, 0, 1), true},
+		{tuple.NewPoint(9, -1, -8), tuple.NewVector(2, 4, 6), false},
+		{tuple.NewPoint(8, 3, -4), tuple.NewVector(6, 2, 4), false},
+		{tuple.NewPoint(9, -1, -2), tuple.NewVector(4, 6, 2), false},
+		{tuple.NewPoint(4, 0, 9), tuple.NewVector(0, 0, -1), false},
+		{tuple.NewPoint(8, 6, -1), tuple.NewVector(0, -1, 0), false},
+		{tuple.NewPoint(12, 5, 4), tuple.NewVector(-1, 0, 0), false},
+	}
+	for _, tc := range testCases {
+		_, got := b.Intersects(ray.NewRay(tc.origin, tc.direction.Norm()), math.Inf(-1), math.Inf(1))
+		assert.Equal(t, tc.want, got, "%v %v", tc.origin, tc.direction)
+	}
+
+	// a box behind the ray is missed when only positive t values are wanted
+	_, got := b.Intersects(ray.NewRay(tuple.NewPoint(15, 1, 2), tuple.NewVector(1, 0, 0)), 0, math.Inf(1))
+	assert.False(t, got)
+}
@@ -0,0 +1,342 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+const (
+	// bvhLeafSize is the number of items below which a node is never split
+	bvhLeafSize = 4
+	// bvhBins is the number of buckets used to estimate the surface area heuristic
+	bvhBins = 12
+	// bvhTraversalCost is the cost of visiting a node, relative to one intersection test
+	bvhTraversalCost = 0.125
+)
+
+// Bounded is anything which can be stored in a bounding volume hierarchy
+type Bounded interface {
+	Intersects(r *ray.Ray) []ray.Intersection
+	// Bounds returns the bounding box in the space of the parent
+	Bounds() *Bounds
+}
+
+// closestIntersecter is implemented by containers which can find their nearest
+// intersection without collecting every intersection of their children
+type closestIntersecter interface {
+	IntersectsClosest(r *ray.Ray, tMax float64) (ray.Intersection, bool)
+}
+
+// BVHBuilder is implemented by containers which can build hierarchies over their children
+type BVHBuilder interface {
+	BuildBVH()
+}
+
+// BVH is a bounding volume hierarchy built with the surface area heuristic.
+// Items with infinite bounds can't be partitioned, so they are kept aside and always tested.
+type BVH struct {
+	root      *bvhNode
+	unbounded []Bounded
+}
+
+type bvhNode struct {
+	bounds *Bounds
+	left   *bvhNode
+	right  *bvhNode
+	// items is only set on leaf nodes
+	items []Bounded
+}
+
+// bvhItem caches the bounds of an item while the tree is built
+type bvhItem struct {
+	item     Bounded
+	bounds   *Bounds
+	centroid *tuple.Tuple
+}
+
+func NewBVH(items []Bounded) *BVH {
+	bvh := &BVH{}
+
+	bounded := make([]bvhItem, 0, len(items))
+
+	for _, item := range items {
+		b := item.Bounds()
+		if !b.IsFinite() {
+			bvh.unbounded = append(bvh.unbounded, item)
+			continue
+		}
+
+		bounded = append(bounded, bvhItem{item, b, b.Centroid()})
+	}
+
+	if len(bounded) > 0 {
+		bvh.root = buildBVHNode(bounded)
+	}
+
+	return bvh
+}
+
+// Bounds returns the bounds of everything in the hierarchy
+func (bvh *BVH) Bounds() *Bounds {
+	if len(bvh.unbounded) > 0 {
+		return InfiniteBounds()
+	}
+
+	if bvh.root == nil {
+		return EmptyBounds()
+	}
+
+	return bvh.root.bounds
+}
+
+func buildBVHNode(items []bvhItem) *bvhNode {
+	node := &bvhNode{bounds: EmptyBounds()}
+
+	centroidBounds := EmptyBounds()
+
+	for _, item := range items {
+		node.bounds = node.bounds.Union(item.bounds)
+		centroidBounds = centroidBounds.AddPoint(item.centroid)
+	}
+
+	if len(items) <= bvhLeafSize {
+		node.items = leafItems(items)
+		return node
+	}
+
+	splitAxis, splitPos, ok := findSAHSplit(items, node.bounds, centroidBounds)
+	if !ok {
+		node.items = leafItems(items)
+		return node
+	}
+
+	// partition the items in place around the split position
+	i, j := 0, len(items)-1
+	for i <= j {
+		if axis(items[i].centroid, splitAxis) < splitPos {
+			i++
+		} else {
+			items[i], items[j] = items[j], items[i]
+			j--
+		}
+	}
+
+	if i == 0 || i == len(items) {
+		node.items = leafItems(items)
+		return node
+	}
+
+	node.left = buildBVHNode(items[:i])
+	node.right = buildBVHNode(items[i:])
+
+	return node
+}
+
+// findSAHSplit picks the axis and position which minimize the estimated traversal cost.
+// Returns false if a leaf is cheaper than any split.
+func findSAHSplit(items []bvhItem, bounds, centroidBounds *Bounds) (int, float64, bool) {
+	bestCost := float64(len(items))
+	bestAxis := -1
+	bestPos := 0.0
+
+	parentArea := bounds.SurfaceArea()
+	if parentArea == 0 {
+		return 0, 0, false
+	}
+
+	for a := 0; a < 3; a++ {
+		min := axis(centroidBounds.Min, a)
+		extent := axis(centroidBounds.Max, a) - min
+
+		if extent <= 0 {
+			continue
+		}
+
+		var counts [bvhBins]int
+		var binBounds [bvhBins]*Bounds
+
+		for i := range binBounds {
+			binBounds[i] = EmptyBounds()
+		}
+
+		for _, item := range items {
+			b := binIndex(axis(item.centroid, a), min, extent)
+			counts[b]++
+			binBounds[b] = binBounds[b].Union(item.bounds)
+		}
+
+		// sweep from the right to find the area and count of every right hand side
+		var rightAreas [bvhBins]float64
+		var rightCounts [bvhBins]int
+
+		right := EmptyBounds()
+		rightCount := 0
+
+		for i := bvhBins - 1; i > 0; i-- {
+			right = right.Union(binBounds[i])
+			rightCount += counts[i]
+			rightAreas[i] = right.SurfaceArea()
+			rightCounts[i] = rightCount
+		}
+
+		left := EmptyBounds()
+		leftCount := 0
+
+		for i := 0; i < bvhBins-1; i++ {
+			left = left.Union(binBounds[i])
+			leftCount += counts[i]
+
+			if leftCount == 0 || rightCounts[i+1] == 0 {
+				continue
+			}
+
+			cost := bvhTraversalCost +
+				(left.SurfaceArea()*float64(leftCount)+rightAreas[i+1]*float64(rightCounts[i+1]))/parentArea
+
+			if cost < bestCost {
+				bestCost = cost
+				bestAxis = a
+				bestPos = min + extent*float64(i+1)/bvhBins
+			}
+		}
+	}
+
+	return bestAxis, bestPos, bestAxis >= 0
+}
+
+func binIndex(centroid, min, extent float64) int {
+	b := int(bvhBins * (centroid - min) / extent)
+	if b >= bvhBins {
+		return bvhBins - 1
+	}
+
+	return b
+}
+
+func leafItems(items []bvhItem) []Bounded {
+	result := make([]Bounded, len(items))
+
+	for i, item := range items {
+		result[i] = item.item
+	}
+
+	return result
+}
+
+// Intersects returns every intersection of the items, in no particular order.
+// The ray is treated as a line, so intersections behind the origin are kept for refraction.
+func (bvh *BVH) Intersects(r *ray.Ray) []ray.Intersection {
+	result := make([]ray.Intersection, 0, 2*len(bvh.unbounded)+8)
+
+	for _, item := range bvh.unbounded {
+		result = append(result, item.Intersects(r)...)
+	}
+
+	if bvh.root != nil {
+		result = bvh.root.intersects(r, result)
+	}
+
+	return result
+}
+
+func (node *bvhNode) intersects(r *ray.Ray, result []ray.Intersection) []ray.Intersection {
+	if _, ok := node.bounds.Intersects(r, math.Inf(-1), math.Inf(1)); !ok {
+		return result
+	}
+
+	if node.items != nil {
+		for _, item := range node.items {
+			result = append(result, item.Intersects(r)...)
+		}
+
+		return result
+	}
+
+	result = node.left.intersects(r, result)
+
+	return node.right.intersects(r, result)
+}
+
+// IntersectsClosest returns the nearest intersection with 0 < t < tMax
+func (bvh *BVH) IntersectsClosest(r *ray.Ray, tMax float64) (ray.Intersection, bool) {
+	closest := *ray.NilIntersect
+	closest.T = tMax
+
+	found := false
+
+	for _, item := range bvh.unbounded {
+		if inter, ok := ClosestIntersection(item, r, closest.T); ok {
+			closest = inter
+			found = true
+		}
+	}
+
+	if bvh.root != nil {
+		if _, ok := bvh.root.bounds.Intersects(r, 0, closest.T); ok && bvh.root.intersectsClosest(r, &closest) {
+			found = true
+		}
+	}
+
+	return closest, found
+}
+
+// intersectsClosest updates closest with any nearer intersection below this node.
+// The caller has already checked that the ray passes through the node's bounds.
+func (node *bvhNode) intersectsClosest(r *ray.Ray, closest *ray.Intersection) bool {
+	found := false
+
+	if node.items != nil {
+		for _, item := range node.items {
+			if inter, ok := ClosestIntersection(item, r, closest.T); ok {
+				*closest = inter
+				found = true
+			}
+		}
+
+		return found
+	}
+
+	// visit the nearer child first, so the farther one is more likely to be culled
+	first, second := node.left, node.right
+
+	tFirst, okFirst := first.bounds.Intersects(r, 0, closest.T)
+	tSecond, okSecond := second.bounds.Intersects(r, 0, closest.T)
+
+	if okSecond && (!okFirst || tSecond < tFirst) {
+		first, second = second, first
+		tSecond, okSecond = tFirst, okFirst
+		okFirst = true
+	}
+
+	if okFirst && first.intersectsClosest(r, closest) {
+		found = true
+	}
+
+	if okSecond && tSecond < closest.T && second.intersectsClosest(r, closest) {
+		found = true
+	}
+
+	return found
+}
+
+// ClosestIntersection returns the nearest intersection of an item with 0 < t < tMax
+func ClosestIntersection(item Bounded, r *ray.Ray, tMax float64) (ray.Intersection, bool) {
+	if c, ok := item.(closestIntersecter); ok {
+		return c.IntersectsClosest(r, tMax)
+	}
+
+	closest := *ray.NilIntersect
+	found := false
+
+	for _, inter := range item.Intersects(r) {
+		if inter.T > 0 && inter.T < tMax {
+			closest = inter
+			tMax = inter.T
+			found = true
+		}
+	}
+
+	return closest, found
+}
@@ -0,0 +1,118 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// randomSpheres scatters small spheres through a 20 unit cube
+func randomSpheres(n int) []Bounded {
+	rng := rand.New(rand.NewSource(1))
+	items := make([]Bounded, n)
+
+	for i := range items {
+		items[i] = NewSphere(
+			matrix.Translation(rng.Float64()*20-10, rng.Float64()*20-10, rng.Float64()*20-10).
+				Mult(matrix.ScalingU(0.1+rng.Float64()*0.5)),
+			nil)
+	}
+
+	return items
+}
+
+func TestBVH_MatchesLinearSearch(t *testing.T) {
+	items := randomSpheres(200)
+	items = append(items, NewPlane(matrix.Translation(0, 0, -9), nil))
+
+	bvh := NewBVH(items)
+
+	assert.NotNil(t, bvh.root)
+	assert.Len(t, bvh.unbounded, 1)
+
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 200; i++ {
+		r := ray.NewRay(
+			tuple.NewPoint(rng.Float64()*30-15, rng.Float64()*30-15, rng.Float64()*30-15),
+			tuple.NewVector(rng.Float64()-0.5, rng.Float64()-0.5, rng.Float64()-0.5).Norm())
+
+		var want []ray.Intersection
+		for _, item := range items {
+			want = append(want, item.Intersects(r)...)
+		}
+
+		assert.ElementsMatch(t, want, bvh.Intersects(r))
+
+		wantClosest := ray.GetClosestPositive(want)
+		gotClosest, ok := bvh.IntersectsClosest(r, math.Inf(1))
+
+		assert.Equal(t, wantClosest.P != nil, ok)
+		if ok {
+			assert.Equal(t, *wantClosest, gotClosest)
+		}
+	}
+}
+
+func TestBVH_IntersectsClosestMaxDistance(t *testing.T) {
+	s := NewSphere(matrix.Translation(0, 0, 5), nil)
+	bvh := NewBVH([]Bounded{s})
+
+	r := ray.NewRay(tuple.Origin, tuple.Up)
+
+	inter, ok := bvh.IntersectsClosest(r, 10)
+	assert.True(t, ok)
+	assert.Equal(t, ray.Intersection{T: 4, P: s}, inter)
+
+	_, ok = bvh.IntersectsClosest(r, 3)
+	assert.False(t, ok)
+}
+
+func TestBVH_Empty(t *testing.T) {
+	bvh := NewBVH(nil)
+
+	assert.Empty(t, bvh.Intersects(ray.NewRay(tuple.Origin, tuple.Up)))
+
+	_, ok := bvh.IntersectsClosest(ray.NewRay(tuple.Origin, tuple.Up), math.Inf(1))
+	assert.False(t, ok)
+}
+
+func TestBasicGroup_BuildBVH(t *testing.T) {
+	// a group keeps returning the same intersections once its hierarchy is built
+	inner := NewBasicGroup(matrix.Translation(0, 0, 3), nil)
+	for _, item := range randomSpheres(50) {
+		inner.AddChild(item.(*Sphere))
+	}
+
+	outer := NewBasicGroup(matrix.ScalingU(2), nil, inner, NewSphere(nil, nil))
+
+	r := ray.NewRay(tuple.NewPoint(0.1, 0.2, -50), tuple.NewVector(0.001, 0.002, 1).Norm())
+
+	want := outer.Intersects(r)
+	wantClosest := ray.GetClosestPositive(want)
+
+	outer.BuildBVH()
+
+	assert.NotNil(t, inner.bvh)
+	assert.ElementsMatch(t, want, outer.Intersects(r))
+
+	gotClosest, ok := outer.IntersectsClosest(r, math.Inf(1))
+	assert.True(t, ok)
+	assert.Equal(t, *wantClosest, gotClosest)
+}
+
+func BenchmarkBVH_IntersectsClosest(b *testing.B) {
+	bvh := NewBVH(randomSpheres(10000))
+	r := ray.NewRay(tuple.NewPoint(-15, -15, -15), tuple.NewVector(1, 1, 1).Norm())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		bvh.IntersectsClosest(r, math.Inf(1))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
 	"github.com/Henelik/tricaster/pkg/util"
@@ -23,7 +24,9 @@ type Cone struct {
 	max    float64
 	closed bool
 	// the material
-	Mat material.Material
+	Mat      material.Material
+	parent   GroupInterface
+	noShadow bool
 }
 
 func NewCone(min, max float64, closed bool, m *matrix.Matrix, mat material.Material) *Cone {
@@ -58,6 +61,14 @@ func (cone *Cone) GetMatrix() *matrix.Matrix {
 	return cone.m
 }
 
+// Bounds is infinite if the cone has no minimum or maximum
+func (cone *Cone) Bounds() *Bounds {
+	// the radius of the cone at any height is the distance from its apex
+	r := util.Max(math.Abs(cone.min), math.Abs(cone.max))
+
+	return NewBounds(tuple.NewPoint(-r, -r, cone.min), tuple.NewPoint(r, r, cone.max)).Transform(cone.m)
+}
+
 func (cone *Cone) Intersects(r *ray.Ray) []ray.Intersection {
 	rt := r.Transform(cone.im)
 	inters := make([]ray.Intersection, 0, 4)
@@ -138,15 +149,12 @@ func checkConeCap(r *ray.Ray, t float64) bool {
 }
 
 func (cone *Cone) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
-	localPos := cone.im.MultTuple(pos)
-	if pos.X == 0.0 && pos.Y == 0.0 && pos.Z == 0.0 {
+	localPos := cone.WorldToObject(pos)
+	if localPos.X == 0.0 && localPos.Y == 0.0 && localPos.Z == 0.0 {
 		return tuple.NewVector(0, 0, 0)
 	}
 
-	n := cone.imt.MultTuple(cone.LocalNormalAt(localPos))
-	n.W = 0
-
-	return n.Norm()
+	return cone.NormalToWorld(cone.LocalNormalAt(localPos))
 }
 
 func (cone *Cone) LocalNormalAt(pos *tuple.Tuple) *tuple.Tuple {
@@ -169,7 +177,43 @@ func (cone *Cone) LocalNormalAt(pos *tuple.Tuple) *tuple.Tuple {
 	return tuple.NewVector(pos.X, pos.Y, z)
 }
 
-func (cone *Cone) Shade(light *light.PointLight, h *ray.Hit) *color.Color {
+// UVAt returns the surface coordinates of a scene point, wrapped around its axis
+func (cone *Cone) UVAt(pos *tuple.Tuple) (float64, float64) {
+	return pattern.CylindricalMap(cone.WorldToObject(pos))
+}
+
+func (cone *Cone) SetParent(group GroupInterface) {
+	cone.parent = group
+}
+
+func (cone *Cone) SetCastsShadow(casts bool) {
+	cone.noShadow = !casts
+}
+
+func (cone *Cone) CastsShadow() bool {
+	return !cone.noShadow
+}
+
+func (cone *Cone) WorldToObject(p *tuple.Tuple) *tuple.Tuple {
+	if cone.parent != nil {
+		return cone.im.MultTuple(cone.parent.WorldToGroup(p))
+	}
+
+	return cone.im.MultTuple(p)
+}
+
+func (cone *Cone) NormalToWorld(n *tuple.Tuple) *tuple.Tuple {
+	worldNormal := cone.imt.MultTuple(n)
+	worldNormal.W = 0
+
+	if cone.parent != nil {
+		return cone.parent.GroupToWorld(worldNormal.Norm())
+	}
+
+	return worldNormal.Norm()
+}
+
+func (cone *Cone) Shade(light light.Light, h *ray.Hit) *color.Color {
 	return cone.Mat.Lighting(light, h)
 }
 
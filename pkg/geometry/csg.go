@@ -0,0 +1,256 @@
+package geometry
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// CSGOperation is the rule used to combine the two sides of a CSG
+type CSGOperation int
+
+const (
+	// CSGUnion keeps the surfaces of both sides which aren't inside the other
+	CSGUnion CSGOperation = iota
+	// CSGIntersection keeps only the surfaces where both sides overlap
+	CSGIntersection
+	// CSGDifference keeps the left side, with the right side cut out of it
+	CSGDifference
+)
+
+// CSG combines two primitives or groups with constructive solid geometry.
+// Both sides should be closed solids, so a ray alternates between entering and leaving each of them.
+type CSG struct {
+	Operation              CSGOperation
+	Left                   Intersecter
+	Right                  Intersecter
+	parent                 GroupInterface
+	matrix                 *matrix.Matrix
+	inverseMatrix          *matrix.Matrix
+	inverseTransposeMatrix *matrix.Matrix
+}
+
+func NewCSG(op CSGOperation, left, right Intersecter, m *matrix.Matrix) *CSG {
+	csg := &CSG{
+		Operation: op,
+		Left:      left,
+		Right:     right,
+	}
+
+	if m != nil {
+		csg.SetMatrix(m)
+	} else {
+		csg.SetMatrix(matrix.Identity)
+	}
+
+	left.SetParent(csg)
+	right.SetParent(csg)
+
+	return csg
+}
+
+func (csg *CSG) SetMatrix(m *matrix.Matrix) {
+	csg.matrix = m
+	csg.inverseMatrix = m.Inverse()
+	csg.inverseTransposeMatrix = csg.inverseMatrix.Transpose()
+}
+
+func (csg *CSG) GetMatrix() *matrix.Matrix {
+	return csg.matrix
+}
+
+func (csg *CSG) SetParent(parent GroupInterface) {
+	csg.parent = parent
+}
+
+func (csg *CSG) SetCastsShadow(casts bool) {
+	for _, side := range []Intersecter{csg.Left, csg.Right} {
+		if c, ok := side.(ShadowCaster); ok {
+			c.SetCastsShadow(casts)
+		}
+	}
+}
+
+func (csg *CSG) WorldToGroup(p *tuple.Tuple) *tuple.Tuple {
+	if csg.parent != nil {
+		return csg.inverseMatrix.MultTuple(csg.parent.WorldToGroup(p))
+	}
+
+	return csg.inverseMatrix.MultTuple(p)
+}
+
+// GroupToWorld converts a normal vector from CSG space to world space
+func (csg *CSG) GroupToWorld(n *tuple.Tuple) *tuple.Tuple {
+	worldNormal := csg.inverseTransposeMatrix.MultTuple(n)
+	worldNormal.W = 0
+
+	if csg.parent != nil {
+		return csg.parent.GroupToWorld(worldNormal.Norm())
+	}
+
+	return worldNormal.Norm()
+}
+
+// Bounds returns the bounds of the combined solid, in the space of the CSG's parent
+func (csg *CSG) Bounds() *Bounds {
+	var result *Bounds
+
+	switch csg.Operation {
+	case CSGUnion:
+		result = csg.Left.Bounds().Union(csg.Right.Bounds())
+	case CSGIntersection:
+		result = csg.Left.Bounds().Overlap(csg.Right.Bounds())
+	default:
+		result = csg.Left.Bounds()
+	}
+
+	return result.Transform(csg.matrix)
+}
+
+// BuildBVH builds the bounding volume hierarchies of any groups inside the CSG
+func (csg *CSG) BuildBVH() {
+	for _, side := range []Intersecter{csg.Left, csg.Right} {
+		if b, ok := side.(BVHBuilder); ok {
+			b.BuildBVH()
+		}
+	}
+}
+
+// Intersects returns the intersections with the surface of the combined solid, sorted
+func (csg *CSG) Intersects(r *ray.Ray) []ray.Intersection {
+	rt := r.Transform(csg.inverseMatrix)
+
+	inters := append(csg.Left.Intersects(rt), csg.Right.Intersects(rt)...)
+
+	return csg.filter(ray.SortI(inters))
+}
+
+// filter keeps the sorted intersections which lie on the surface of the combined solid
+func (csg *CSG) filter(inters []ray.Intersection) []ray.Intersection {
+	result := make([]ray.Intersection, 0, len(inters))
+
+	// the ray is treated as a line, so it starts outside of both sides
+	inLeft, inRight := false, false
+
+	for _, inter := range inters {
+		leftHit := includes(csg.Left, inter.P)
+
+		if csg.allowed(leftHit, inLeft, inRight) {
+			inter.P = csgSurface{csg, inter.P.(csgPrimitive)}
+			result = append(result, inter)
+		}
+
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
+
+	return result
+}
+
+// allowed decides if an intersection is kept, given which side was hit and which sides the ray is inside
+func (csg *CSG) allowed(leftHit, inLeft, inRight bool) bool {
+	switch csg.Operation {
+	case CSGUnion:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case CSGIntersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	default:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	}
+}
+
+// includes checks if an intersected primitive belongs to an item, or to anything inside it
+func includes(item Intersecter, p ray.Primitive) bool {
+	// intersections from a nested CSG wrap the primitive that was hit
+	for {
+		s, ok := p.(csgSurface)
+		if !ok {
+			break
+		}
+
+		if s.csg == item {
+			return true
+		}
+
+		p = s.csgPrimitive
+	}
+
+	switch item := item.(type) {
+	case *BasicGroup:
+		for _, child := range item.Children {
+			if includes(child, p) {
+				return true
+			}
+		}
+
+		return false
+	case *CSG:
+		return includes(item.Left, p) || includes(item.Right, p)
+	default:
+		return interface{}(item) == interface{}(p)
+	}
+}
+
+// NormalAt is never called on a CSG, since its intersections always refer to its primitives
+func (csg *CSG) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
+	panic("NormalAt called on a CSG")
+}
+
+// Shade is never called on a CSG, since its intersections always refer to its primitives
+func (csg *CSG) Shade(light light.Light, h *ray.Hit) *color.Color {
+	panic("Shade called on a CSG")
+}
+
+func (csg *CSG) GetMaterial() material.Material {
+	return nil
+}
+
+// GetIOR returns the index of refraction inside the combined solid, which is taken from the left side
+func (csg *CSG) GetIOR() float64 {
+	if left, ok := csg.Left.(ray.IORHaver); ok {
+		return left.GetIOR()
+	}
+
+	return 1
+}
+
+// csgPrimitive is a primitive which can appear in the intersections of a CSG.
+// It has every method the renderer needs, so a csgSurface can be shaded like the primitive itself.
+type csgPrimitive interface {
+	ray.Primitive
+	SetParent(group GroupInterface)
+	SetMatrix(m *matrix.Matrix)
+	GetMatrix() *matrix.Matrix
+	GetMaterial() material.Material
+	GetIOR() float64
+	Bounds() *Bounds
+	CastsShadow() bool
+	SetCastsShadow(casts bool)
+}
+
+// csgSurface is an intersection with one of the primitives of a CSG.
+// It is shaded like the primitive, but refraction treats the whole CSG as a single solid.
+// It is a comparable value, so intersections with the same primitive are equal.
+type csgSurface struct {
+	csg *CSG
+	csgPrimitive
+}
+
+func (s csgSurface) UVAt(pos *tuple.Tuple) (float64, float64) {
+	if mapper, ok := s.csgPrimitive.(ray.UVMapper); ok {
+		return mapper.UVAt(pos)
+	}
+
+	return 0, 0
+}
+
+// Solid returns the outermost CSG, which the primitive is part of
+func (s csgSurface) Solid() ray.IORHaver {
+	return s.csg
+}
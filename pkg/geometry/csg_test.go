@@ -0,0 +1,243 @@
+package geometry
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSG_Allowed(t *testing.T) {
+	testCases := []struct {
+		op      CSGOperation
+		leftHit bool
+		inLeft  bool
+		inRight bool
+		want    bool
+	}{
+		{CSGUnion, true, true, true, false},
+		{CSGUnion, true, true, false, true},
+		{CSGUnion, true, false, true, false},
+		{CSGUnion, true, false, false, true},
+		{CSGUnion, false, true, true, false},
+		{CSGUnion, false, true, false, false},
+		{CSGUnion, false, false, true, true},
+		{CSGUnion, false, false, false, true},
+		{CSGIntersection, true, true, true, true},
+		{CSGIntersection, true, true, false, false},
+		{CSGIntersection, true, false, true, true},
+		{CSGIntersection, true, false, false, false},
+		{CSGIntersection, false, true, true, true},
+		{CSGIntersection, false, true, false, true},
+		{CSGIntersection, false, false, true, false},
+		{CSGIntersection, false, false, false, false},
+		{CSGDifference, true, true, true, false},
+		{CSGDifference, true, true, false, true},
+		{CSGDifference, true, false, true, false},
+		{CSGDifference, true, false, false, true},
+		{CSGDifference, false, true, true, true},
+		{CSGDifference, false, true, false, true},
+		{CSGDifference, false, false, true, false},
+		{CSGDifference, false, false, false, false},
+	}
+	for _, tc := range testCases {
+		name := fmt.Sprintf("%v, %v, %v, %v", tc.op, tc.leftHit, tc.inLeft, tc.inRight)
+		t.Run(name, func(t *testing.T) {
+			csg := NewCSG(tc.op, NewSphere(nil, nil), NewCube(nil, nil), nil)
+			assert.Equal(t, tc.want, csg.allowed(tc.leftHit, tc.inLeft, tc.inRight))
+		})
+	}
+}
+
+func TestCSG_Filter(t *testing.T) {
+	s := NewSphere(nil, nil)
+	c := NewCube(nil, nil)
+
+	inters := []ray.Intersection{
+		{T: 1, P: s},
+		{T: 2, P: c},
+		{T: 3, P: s},
+		{T: 4, P: c},
+	}
+
+	testCases := []struct {
+		name string
+		op   CSGOperation
+		want []float64
+	}{
+		{
+			name: "A union keeps the outermost surfaces",
+			op:   CSGUnion,
+			want: []float64{1, 4},
+		},
+		{
+			name: "An intersection keeps the overlapping surfaces",
+			op:   CSGIntersection,
+			want: []float64{2, 3},
+		},
+		{
+			name: "A difference keeps the left side outside of the right",
+			op:   CSGDifference,
+			want: []float64{1, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			csg := NewCSG(tc.op, s, c, nil)
+
+			got := csg.filter(inters)
+
+			assert.Len(t, got, len(tc.want))
+			for i, inter := range got {
+				assert.Equal(t, tc.want[i], inter.T)
+			}
+		})
+	}
+}
+
+func TestCSG_Intersects(t *testing.T) {
+	s1 := NewSphere(nil, nil)
+	s2 := NewSphere(matrix.Translation(0, 0, 0.5), nil)
+
+	testCases := []struct {
+		name string
+		csg  *CSG
+		r    *ray.Ray
+		want []float64
+	}{
+		{
+			name: "A ray misses a CSG",
+			csg:  NewCSG(CSGUnion, s1, NewCube(nil, nil), nil),
+			r:    ray.NewRay(tuple.NewPoint(0, 2, -5), tuple.Up),
+			want: []float64{},
+		},
+		{
+			name: "A ray hits a CSG",
+			csg:  NewCSG(CSGUnion, s1, s2, nil),
+			r:    ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.Up),
+			want: []float64{4, 6.5},
+		},
+		{
+			name: "A ray hits a transformed CSG",
+			csg:  NewCSG(CSGIntersection, s1, s2, matrix.Translation(0, 0, 1)),
+			r:    ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.Up),
+			want: []float64{5.5, 7},
+		},
+		{
+			name: "A ray hits a nested CSG",
+			csg: NewCSG(CSGDifference,
+				NewCSG(CSGUnion, s1, s2, nil),
+				NewCube(matrix.Translation(0, 0, -2), nil),
+				nil),
+			r:    ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.Up),
+			want: []float64{4, 6.5},
+		},
+		{
+			name: "A ray hits a nested CSG through the cut",
+			csg: NewCSG(CSGDifference,
+				NewCSG(CSGUnion, s1, s2, nil),
+				NewCube(matrix.Translation(0, 0, -1.5), nil),
+				nil),
+			r:    ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.Up),
+			want: []float64{4.5, 6.5},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.csg.Intersects(tc.r)
+
+			assert.Len(t, got, len(tc.want))
+			for i, inter := range got {
+				assert.InDelta(t, tc.want[i], inter.T, 1e-9)
+			}
+		})
+	}
+}
+
+func TestCSG_NormalAt(t *testing.T) {
+	csg := NewCSG(CSGDifference, NewCube(nil, nil), NewSphere(nil, nil), matrix.Translation(0, 0, 5))
+
+	inters := csg.Intersects(ray.NewRay(tuple.NewPoint(0.5, 0, 0), tuple.Up))
+
+	assert.Len(t, inters, 4)
+	// the ray enters the cube, and then passes through the hollow left by the sphere
+	assert.True(t, tuple.Down.Equal(inters[0].P.NormalAt(tuple.NewPoint(0.5, 0, 4))))
+	assert.True(t, tuple.NewVector(0.5, 0, -math.Sqrt(0.75)).Equal(
+		inters[1].P.NormalAt(tuple.NewPoint(0.5, 0, 5-math.Sqrt(0.75)))))
+	assert.True(t, tuple.Up.Equal(inters[3].P.NormalAt(tuple.NewPoint(0.5, 0, 6))))
+}
+
+func TestCSG_ComputeIORs(t *testing.T) {
+	glass := material.DefaultPhong.Copy()
+	glass.IOR = 1.5
+
+	csg := NewCSG(CSGUnion, NewSphere(nil, glass), NewSphere(matrix.Translation(0, 0, 0.5), glass), nil)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.Up)
+
+	inters := csg.Intersects(r)
+
+	testCases := []struct {
+		index  int
+		wantN1 float64
+		wantN2 float64
+	}{
+		{
+			index:  0,
+			wantN1: 1.0,
+			wantN2: 1.5,
+		},
+		{
+			index:  1,
+			wantN1: 1.5,
+			wantN2: 1.0,
+		},
+	}
+	for _, tc := range testCases {
+		name := fmt.Sprintf("%v, %v, %v", tc.index, tc.wantN1, tc.wantN2)
+		t.Run(name, func(t *testing.T) {
+			h := ray.NewHit(r, inters, tc.index)
+			assert.Equal(t, tc.wantN1, h.N1)
+			assert.Equal(t, tc.wantN2, h.N2)
+		})
+	}
+}
+
+func TestCSG_Bounds(t *testing.T) {
+	left := NewSphere(nil, nil)
+	right := NewSphere(matrix.Translation(1, 0, 0), nil)
+
+	testCases := []struct {
+		name string
+		op   CSGOperation
+		want *Bounds
+	}{
+		{
+			name: "The bounds of a union contain both sides",
+			op:   CSGUnion,
+			want: NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(2, 1, 1)),
+		},
+		{
+			name: "The bounds of an intersection contain the overlap",
+			op:   CSGIntersection,
+			want: NewBounds(tuple.NewPoint(0, -1, -1), tuple.NewPoint(1, 1, 1)),
+		},
+		{
+			name: "The bounds of a difference contain the left side",
+			op:   CSGDifference,
+			want: NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1)),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewCSG(tc.op, left, right, nil).Bounds()
+			assert.True(t, tc.want.Min.Equal(got.Min))
+			assert.True(t, tc.want.Max.Equal(got.Max))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
 	"github.com/Henelik/tricaster/pkg/util"
@@ -20,7 +21,9 @@ type Cube struct {
 	// the transposition of the inverse matrix
 	imt *matrix.Matrix
 	// the material
-	Mat material.Material
+	Mat      material.Material
+	parent   GroupInterface
+	noShadow bool
 }
 
 func NewCube(m *matrix.Matrix, mat material.Material) *Cube {
@@ -29,6 +32,8 @@ func NewCube(m *matrix.Matrix, mat material.Material) *Cube {
 		matrix.Identity,
 		matrix.Identity,
 		material.DefaultPhong,
+		nil,
+		false,
 	}
 
 	if m != nil {
@@ -52,6 +57,10 @@ func (c *Cube) GetMatrix() *matrix.Matrix {
 	return c.m
 }
 
+func (c *Cube) Bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1)).Transform(c.m)
+}
+
 func (c *Cube) Intersects(r *ray.Ray) []ray.Intersection {
 	rt := r.Transform(c.im)
 	xtmin, xtmax := checkAxis(rt.Origin.X, rt.Direction.X)
@@ -92,10 +101,7 @@ func checkAxis(origin, dir float64) (float64, float64) {
 }
 
 func (c *Cube) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
-	n := c.imt.MultTuple(c.LocalNormalAt(c.im.MultTuple(pos)))
-	n.W = 0
-
-	return n.Norm()
+	return c.NormalToWorld(c.LocalNormalAt(c.WorldToObject(pos)))
 }
 
 func (c *Cube) LocalNormalAt(pos *tuple.Tuple) *tuple.Tuple {
@@ -110,7 +116,43 @@ func (c *Cube) LocalNormalAt(pos *tuple.Tuple) *tuple.Tuple {
 	return tuple.NewVector(0, 0, pos.Z)
 }
 
-func (c *Cube) Shade(light *light.PointLight, h *ray.Hit) *color.Color {
+// UVAt returns the surface coordinates of a scene point, covering each face
+func (c *Cube) UVAt(pos *tuple.Tuple) (float64, float64) {
+	return pattern.CubicMap(c.WorldToObject(pos))
+}
+
+func (c *Cube) SetParent(group GroupInterface) {
+	c.parent = group
+}
+
+func (c *Cube) SetCastsShadow(casts bool) {
+	c.noShadow = !casts
+}
+
+func (c *Cube) CastsShadow() bool {
+	return !c.noShadow
+}
+
+func (c *Cube) WorldToObject(p *tuple.Tuple) *tuple.Tuple {
+	if c.parent != nil {
+		return c.im.MultTuple(c.parent.WorldToGroup(p))
+	}
+
+	return c.im.MultTuple(p)
+}
+
+func (c *Cube) NormalToWorld(n *tuple.Tuple) *tuple.Tuple {
+	worldNormal := c.imt.MultTuple(n)
+	worldNormal.W = 0
+
+	if c.parent != nil {
+		return c.parent.GroupToWorld(worldNormal.Norm())
+	}
+
+	return worldNormal.Norm()
+}
+
+func (c *Cube) Shade(light light.Light, h *ray.Hit) *color.Color {
 	return c.Mat.Lighting(light, h)
 }
 
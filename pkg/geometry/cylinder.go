@@ -7,6 +7,7 @@ import (
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
 	"github.com/Henelik/tricaster/pkg/util"
@@ -23,7 +24,9 @@ type Cylinder struct {
 	max    float64
 	closed bool
 	// the material
-	Mat material.Material
+	Mat      material.Material
+	parent   GroupInterface
+	noShadow bool
 }
 
 func NewCylinder(min, max float64, closed bool, m *matrix.Matrix, mat material.Material) *Cylinder {
@@ -58,6 +61,11 @@ func (cyl *Cylinder) GetMatrix() *matrix.Matrix {
 	return cyl.m
 }
 
+// Bounds is infinite if the cylinder has no minimum or maximum
+func (cyl *Cylinder) Bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(-1, -1, cyl.min), tuple.NewPoint(1, 1, cyl.max)).Transform(cyl.m)
+}
+
 func (cyl *Cylinder) Intersects(r *ray.Ray) []ray.Intersection {
 	rt := r.Transform(cyl.im)
 	inters := make([]ray.Intersection, 0, 2)
@@ -130,10 +138,7 @@ func checkCylinderCap(r *ray.Ray, t float64) bool {
 }
 
 func (cyl *Cylinder) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
-	n := cyl.imt.MultTuple(cyl.LocalNormalAt(cyl.im.MultTuple(pos)))
-	n.W = 0
-
-	return n.Norm()
+	return cyl.NormalToWorld(cyl.LocalNormalAt(cyl.WorldToObject(pos)))
 }
 
 func (cyl *Cylinder) LocalNormalAt(pos *tuple.Tuple) *tuple.Tuple {
@@ -151,7 +156,43 @@ func (cyl *Cylinder) LocalNormalAt(pos *tuple.Tuple) *tuple.Tuple {
 	return tuple.NewVector(pos.X, pos.Y, 0)
 }
 
-func (cyl *Cylinder) Shade(light *light.PointLight, h *ray.Hit) *color.Color {
+// UVAt returns the surface coordinates of a scene point, wrapped around its axis
+func (cyl *Cylinder) UVAt(pos *tuple.Tuple) (float64, float64) {
+	return pattern.CylindricalMap(cyl.WorldToObject(pos))
+}
+
+func (cyl *Cylinder) SetParent(group GroupInterface) {
+	cyl.parent = group
+}
+
+func (cyl *Cylinder) SetCastsShadow(casts bool) {
+	cyl.noShadow = !casts
+}
+
+func (cyl *Cylinder) CastsShadow() bool {
+	return !cyl.noShadow
+}
+
+func (cyl *Cylinder) WorldToObject(p *tuple.Tuple) *tuple.Tuple {
+	if cyl.parent != nil {
+		return cyl.im.MultTuple(cyl.parent.WorldToGroup(p))
+	}
+
+	return cyl.im.MultTuple(p)
+}
+
+func (cyl *Cylinder) NormalToWorld(n *tuple.Tuple) *tuple.Tuple {
+	worldNormal := cyl.imt.MultTuple(n)
+	worldNormal.W = 0
+
+	if cyl.parent != nil {
+		return cyl.parent.GroupToWorld(worldNormal.Norm())
+	}
+
+	return worldNormal.Norm()
+}
+
+func (cyl *Cylinder) Shade(light light.Light, h *ray.Hit) *color.Color {
 	return cyl.Mat.Lighting(light, h)
 }
 
@@ -8,6 +8,7 @@ import (
 type Intersecter interface {
 	Intersects(r *ray.Ray) []ray.Intersection
 	SetParent(group GroupInterface)
+	Bounds() *Bounds
 }
 
 type GroupInterface interface {
@@ -15,3 +16,9 @@ type GroupInterface interface {
 	WorldToGroup(p *tuple.Tuple) *tuple.Tuple
 	GroupToWorld(p *tuple.Tuple) *tuple.Tuple
 }
+
+// ShadowCaster is implemented by objects which can be stopped from blocking light, so they cast no shadow.
+// Groups pass the setting on to their children.
+type ShadowCaster interface {
+	SetCastsShadow(casts bool)
+}
@@ -0,0 +1,92 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// DefaultMeshLightSteps is the number of samples along each side of a mesh light
+const DefaultMeshLightSteps = 3
+
+// MeshLight lights the scene from the glowing surfaces of an object.
+// Shadow rays are aimed at random visible points on the parts of the object which use its material.
+type MeshLight struct {
+	Object Bounded
+	Mat    material.Emitter
+	// Steps is the square root of the number of samples
+	Steps  int
+	bounds *Bounds
+	center *tuple.Tuple
+}
+
+func NewMeshLight(object Bounded, mat material.Emitter, steps int) *MeshLight {
+	bounds := object.Bounds()
+
+	return &MeshLight{
+		Object: object,
+		Mat:    mat,
+		Steps:  steps,
+		bounds: bounds,
+		center: bounds.Centroid(),
+	}
+}
+
+// GetColor returns the emission at the center of the object
+func (l *MeshLight) GetColor() *color.Color {
+	return l.Mat.EmissionAt(l.center)
+}
+
+// Illuminate treats the light as coming from the center of the object
+func (l *MeshLight) Illuminate(pos *tuple.Tuple) (*tuple.Tuple, float64, *color.Color) {
+	v := l.center.Sub(pos)
+
+	return v.Norm(), v.Mag(), l.Mat.EmissionAt(l.center)
+}
+
+// Samples returns up to Steps*Steps points on the glowing surface which face pos.
+// Returns nothing if none of the surface can be seen.
+func (l *MeshLight) Samples(pos *tuple.Tuple) []*tuple.Tuple {
+	n := l.Steps * l.Steps
+	result := make([]*tuple.Tuple, 0, n)
+
+	// aim at random points in the bounds, some of which miss the object.
+	// Like the other area lights, the points are always the same for the same pos.
+	for attempt := 0; attempt < 4*n && len(result) < n; attempt++ {
+		cell := 3 * attempt
+		target := tuple.NewPoint(
+			l.bounds.Min.X+light.Jitter(pos, cell)*(l.bounds.Max.X-l.bounds.Min.X),
+			l.bounds.Min.Y+light.Jitter(pos, cell+1)*(l.bounds.Max.Y-l.bounds.Min.Y),
+			l.bounds.Min.Z+light.Jitter(pos, cell+2)*(l.bounds.Max.Z-l.bounds.Min.Z))
+
+		v := target.Sub(pos)
+		if v.Mag() < util.Epsilon {
+			continue
+		}
+
+		r := ray.NewRay(pos, v.Norm())
+
+		inter, ok := ClosestIntersection(l.Object, r, math.Inf(1))
+		if !ok || !l.IsSource(inter.P) {
+			continue
+		}
+
+		result = append(result, r.Position(inter.T))
+	}
+
+	return result
+}
+
+// IsSource returns true if the primitive is part of the light's glowing surface
+func (l *MeshLight) IsSource(p ray.Primitive) bool {
+	if m, ok := p.(interface{ GetMaterial() material.Material }); ok {
+		return interface{}(m.GetMaterial()) == interface{}(l.Mat)
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeshLight_Samples(t *testing.T) {
+	glow := material.NewEmissiveMat(color.White, 1)
+	s := NewSphere(matrix.Translation(0, 0, 5), glow)
+
+	l := NewMeshLight(s, glow, 3)
+
+	samples := l.Samples(tuple.Origin)
+	assert.NotEmpty(t, samples)
+	assert.LessOrEqual(t, len(samples), 9)
+
+	// every sample lies on the side of the sphere facing the point
+	for _, sample := range samples {
+		assert.InDelta(t, 1, sample.Sub(tuple.NewPoint(0, 0, 5)).Mag(), 1e-9)
+		assert.LessOrEqual(t, sample.Z, 5.0)
+	}
+
+	// the samples look random, but are always the same for the same point
+	assert.Equal(t, samples, l.Samples(tuple.Origin))
+
+	dir, dist, col := l.Illuminate(tuple.Origin)
+	assert.True(t, tuple.Up.Equal(dir))
+	assert.Equal(t, 5.0, dist)
+	assert.True(t, color.White.Equal(col))
+}
+
+func TestMeshLight_IsSource(t *testing.T) {
+	glow := material.NewEmissiveMat(color.White, 1)
+	lamp := NewSphere(nil, glow)
+	shade := NewCube(matrix.Translation(0, 0, 2), nil)
+
+	l := NewMeshLight(NewBasicGroup(nil, nil, lamp, shade), glow, 3)
+
+	assert.True(t, l.IsSource(lamp))
+	assert.False(t, l.IsSource(shade))
+}
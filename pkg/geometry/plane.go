@@ -7,6 +7,7 @@ import (
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
 	"github.com/Henelik/tricaster/pkg/util"
@@ -17,16 +18,21 @@ type Plane struct {
 	m *matrix.Matrix
 	// the inverse transformation matrix
 	im *matrix.Matrix
-	// the plane's normal vector
+	// the transposition of the inverse matrix
+	imt *matrix.Matrix
+	// the plane's normal vector, in the space of its parent
 	n *tuple.Tuple
 	// the material
-	Mat material.Material
+	Mat      material.Material
+	parent   GroupInterface
+	noShadow bool
 }
 
 func NewPlane(m *matrix.Matrix, mat material.Material) *Plane {
 	p := &Plane{
 		m:   matrix.Identity,
 		im:  matrix.Identity,
+		imt: matrix.Identity,
 		n:   tuple.Up,
 		Mat: material.DefaultPhong,
 	}
@@ -45,13 +51,24 @@ func NewPlane(m *matrix.Matrix, mat material.Material) *Plane {
 func (p *Plane) SetMatrix(m *matrix.Matrix) {
 	p.m = m
 	p.im = m.Inverse()
-	p.n = p.im.MultTuple(tuple.Up)
+	p.imt = p.im.Transpose()
+
+	n := p.imt.MultTuple(tuple.Up)
+	n.W = 0
+	p.n = n.Norm()
 }
 
 func (p *Plane) GetMatrix() *matrix.Matrix {
 	return p.m
 }
 
+// Bounds is always infinite, since a plane has no edges
+func (p *Plane) Bounds() *Bounds {
+	return NewBounds(
+		tuple.NewPoint(math.Inf(-1), math.Inf(-1), 0),
+		tuple.NewPoint(math.Inf(1), math.Inf(1), 0)).Transform(p.m)
+}
+
 func (p *Plane) Intersects(r *ray.Ray) []ray.Intersection {
 	rt := r.Transform(p.im)
 
@@ -65,10 +82,39 @@ func (p *Plane) Intersects(r *ray.Ray) []ray.Intersection {
 }
 
 func (p *Plane) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
+	if p.parent != nil {
+		return p.parent.GroupToWorld(p.n)
+	}
+
 	return p.n
 }
 
-func (p *Plane) Shade(light *light.PointLight, h *ray.Hit) *color.Color {
+// UVAt returns the surface coordinates of a scene point, repeating every unit square
+func (p *Plane) UVAt(pos *tuple.Tuple) (float64, float64) {
+	return pattern.PlanarMap(p.WorldToObject(pos))
+}
+
+func (p *Plane) SetParent(group GroupInterface) {
+	p.parent = group
+}
+
+func (p *Plane) SetCastsShadow(casts bool) {
+	p.noShadow = !casts
+}
+
+func (p *Plane) CastsShadow() bool {
+	return !p.noShadow
+}
+
+func (p *Plane) WorldToObject(pos *tuple.Tuple) *tuple.Tuple {
+	if p.parent != nil {
+		return p.im.MultTuple(p.parent.WorldToGroup(pos))
+	}
+
+	return p.im.MultTuple(pos)
+}
+
+func (p *Plane) Shade(light light.Light, h *ray.Hit) *color.Color {
 	return p.Mat.Lighting(light, h)
 }
 
@@ -40,7 +40,7 @@ func TestPlane_Intersects(t *testing.T) {
 				tuple.NewPoint(0, 0, 1),
 				tuple.Down,
 			),
-			want: []ray.Intersection{{1, p}},
+			want: []ray.Intersection{{T: 1, P: p}},
 		},
 		{
 			name: "A ray intersecting a plane from below",
@@ -48,7 +48,7 @@ func TestPlane_Intersects(t *testing.T) {
 				tuple.NewPoint(0, 0, -1),
 				tuple.Up,
 			),
-			want: []ray.Intersection{{1, p}},
+			want: []ray.Intersection{{T: 1, P: p}},
 		},
 	}
 	for _, tc := range testCases {
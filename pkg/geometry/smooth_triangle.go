@@ -0,0 +1,172 @@
+package geometry
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// SmoothTriangle is a triangle which interpolates its normal between per-vertex normals
+type SmoothTriangle struct {
+	// the transformation matrix
+	m *matrix.Matrix
+	// the inverse transformation matrix
+	im *matrix.Matrix
+	// the transposition of the inverse matrix
+	imt *matrix.Matrix
+	// the vertices, in object space
+	P1 *tuple.Tuple
+	P2 *tuple.Tuple
+	P3 *tuple.Tuple
+	// the vertex normals, in object space
+	N1 *tuple.Tuple
+	N2 *tuple.Tuple
+	N3 *tuple.Tuple
+	// the edges from P1 to P2 and from P1 to P3
+	e1 *tuple.Tuple
+	e2 *tuple.Tuple
+	// the texture coordinates of each vertex, or nil to use barycentric coordinates
+	texCoords *[3][2]float64
+	// the material
+	Mat      material.Material
+	parent   GroupInterface
+	noShadow bool
+}
+
+func NewSmoothTriangle(p1, p2, p3, n1, n2, n3 *tuple.Tuple, m *matrix.Matrix, mat material.Material) *SmoothTriangle {
+	tri := &SmoothTriangle{
+		m:   matrix.Identity,
+		im:  matrix.Identity,
+		imt: matrix.Identity,
+		P1:  p1,
+		P2:  p2,
+		P3:  p3,
+		N1:  n1,
+		N2:  n2,
+		N3:  n3,
+		e1:  p2.Sub(p1),
+		e2:  p3.Sub(p1),
+		Mat: material.DefaultPhong,
+	}
+
+	if m != nil {
+		tri.SetMatrix(m)
+	}
+
+	if mat != nil {
+		tri.Mat = mat
+	}
+
+	return tri
+}
+
+func (tri *SmoothTriangle) SetMatrix(m *matrix.Matrix) {
+	tri.m = m
+	tri.im = m.Inverse()
+	tri.imt = tri.im.Transpose()
+}
+
+func (tri *SmoothTriangle) GetMatrix() *matrix.Matrix {
+	return tri.m
+}
+
+func (tri *SmoothTriangle) Bounds() *Bounds {
+	return EmptyBounds().AddPoint(tri.P1).AddPoint(tri.P2).AddPoint(tri.P3).Transform(tri.m)
+}
+
+func (tri *SmoothTriangle) Intersects(r *ray.Ray) []ray.Intersection {
+	rt := r.Transform(tri.im)
+
+	inter, ok := intersectTriangle(rt, tri.P1, tri.e1, tri.e2)
+	if !ok {
+		return []ray.Intersection{}
+	}
+
+	inter.P = tri
+
+	return []ray.Intersection{inter}
+}
+
+func (tri *SmoothTriangle) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
+	u, v := barycentric(tri.WorldToObject(pos), tri.P1, tri.e1, tri.e2)
+
+	return tri.NormalToWorld(tri.LocalNormalAt(u, v))
+}
+
+// LocalNormalAt interpolates the vertex normals at the given barycentric coordinates
+func (tri *SmoothTriangle) LocalNormalAt(u, v float64) *tuple.Tuple {
+	return tri.N2.Mult(u).
+		Add(tri.N3.Mult(v)).
+		Add(tri.N1.Mult(1 - u - v))
+}
+
+// UVAt returns the texture coordinates of a scene point on the triangle,
+// which are its barycentric coordinates if the triangle has no texture coordinates
+func (tri *SmoothTriangle) UVAt(pos *tuple.Tuple) (float64, float64) {
+	u, v := barycentric(tri.WorldToObject(pos), tri.P1, tri.e1, tri.e2)
+
+	return interpolateTexCoords(tri.texCoords, u, v)
+}
+
+// NormalAtIntersection interpolates the vertex normals at a hit found by Intersects,
+// using the barycentric coordinates it was found with
+func (tri *SmoothTriangle) NormalAtIntersection(i ray.Intersection) *tuple.Tuple {
+	return tri.NormalToWorld(tri.LocalNormalAt(i.U, i.V))
+}
+
+// UVAtIntersection returns the texture coordinates of a hit found by Intersects,
+// from the barycentric coordinates it was found with
+func (tri *SmoothTriangle) UVAtIntersection(i ray.Intersection) (float64, float64) {
+	return interpolateTexCoords(tri.texCoords, i.U, i.V)
+}
+
+// SetTexCoords sets the texture coordinates of each vertex
+func (tri *SmoothTriangle) SetTexCoords(t1, t2, t3 [2]float64) {
+	tri.texCoords = &[3][2]float64{t1, t2, t3}
+}
+
+func (tri *SmoothTriangle) SetParent(group GroupInterface) {
+	tri.parent = group
+}
+
+func (tri *SmoothTriangle) SetCastsShadow(casts bool) {
+	tri.noShadow = !casts
+}
+
+func (tri *SmoothTriangle) CastsShadow() bool {
+	return !tri.noShadow
+}
+
+func (tri *SmoothTriangle) WorldToObject(p *tuple.Tuple) *tuple.Tuple {
+	if tri.parent != nil {
+		return tri.im.MultTuple(tri.parent.WorldToGroup(p))
+	}
+
+	return tri.im.MultTuple(p)
+}
+
+func (tri *SmoothTriangle) NormalToWorld(n *tuple.Tuple) *tuple.Tuple {
+	worldNormal := tri.imt.MultTuple(n)
+	worldNormal.W = 0
+
+	if tri.parent != nil {
+		return tri.parent.GroupToWorld(worldNormal.Norm())
+	}
+
+	return worldNormal.Norm()
+}
+
+func (tri *SmoothTriangle) Shade(light light.Light, h *ray.Hit) *color.Color {
+	return tri.Mat.Lighting(light, h)
+}
+
+func (tri *SmoothTriangle) GetMaterial() material.Material {
+	return tri.Mat
+}
+
+func (tri *SmoothTriangle) GetIOR() float64 {
+	return tri.Mat.GetIOR()
+}
@@ -0,0 +1,59 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSmoothTriangle() *SmoothTriangle {
+	return NewSmoothTriangle(
+		tuple.NewPoint(0, 1, 0),
+		tuple.NewPoint(-1, 0, 0),
+		tuple.NewPoint(1, 0, 0),
+		tuple.NewVector(0, 1, 0),
+		tuple.NewVector(-1, 0, 0),
+		tuple.NewVector(1, 0, 0),
+		nil, nil)
+}
+
+func TestSmoothTriangle_Intersects(t *testing.T) {
+	// An intersection with a smooth triangle stores u/v
+	tri := newTestSmoothTriangle()
+
+	r := ray.NewRay(tuple.NewPoint(-0.2, 0.3, -5), tuple.NewVector(0, 0, 1))
+	xs := tri.Intersects(r)
+
+	assert.Len(t, xs, 1)
+	assert.Equal(t, 5.0, xs[0].T)
+	assert.InDelta(t, 0.45, xs[0].U, 1e-9)
+	assert.InDelta(t, 0.25, xs[0].V, 1e-9)
+
+	h := ray.NewHit(r, xs, 0)
+	assert.InDelta(t, 0.45, h.U, 1e-9)
+	assert.InDelta(t, 0.25, h.V, 1e-9)
+	// the hit's normal comes from the same u/v
+	assert.True(t, tri.NormalAt(h.Pos).Equal(h.NormalV))
+}
+
+func TestSmoothTriangle_NormalAt(t *testing.T) {
+	// A smooth triangle uses u/v to interpolate the normal
+	tri := newTestSmoothTriangle()
+
+	n := tri.NormalAt(tuple.NewPoint(-0.2, 0.3, 0))
+
+	assert.InDelta(t, -0.5547, n.X, 1e-4)
+	assert.InDelta(t, 0.83205, n.Y, 1e-4)
+	assert.InDelta(t, 0, n.Z, 1e-4)
+}
+
+func TestSmoothTriangle_LocalNormalAt(t *testing.T) {
+	tri := newTestSmoothTriangle()
+
+	assert.True(t, tri.N1.Equal(tri.LocalNormalAt(0, 0)))
+	assert.True(t, tri.N2.Equal(tri.LocalNormalAt(1, 0)))
+	assert.True(t, tri.N3.Equal(tri.LocalNormalAt(0, 1)))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
 )
@@ -17,9 +18,10 @@ type Sphere struct {
 	// the inverse transformation matrix
 	im *matrix.Matrix
 	// the transposition of the inverse matrix
-	imt    *matrix.Matrix
-	Mat    material.Material
-	parent GroupInterface
+	imt      *matrix.Matrix
+	Mat      material.Material
+	parent   GroupInterface
+	noShadow bool
 }
 
 func NewSphere(m *matrix.Matrix, mat material.Material) *Sphere {
@@ -29,6 +31,7 @@ func NewSphere(m *matrix.Matrix, mat material.Material) *Sphere {
 		matrix.Identity,
 		material.DefaultPhong,
 		nil,
+		false,
 	}
 
 	if m != nil {
@@ -47,12 +50,17 @@ func NewSphere(m *matrix.Matrix, mat material.Material) *Sphere {
 func (s *Sphere) SetMatrix(m *matrix.Matrix) {
 	s.m = m
 	s.im = m.Inverse()
+	s.imt = s.im.Transpose()
 }
 
 func (s *Sphere) GetMatrix() *matrix.Matrix {
 	return s.m
 }
 
+func (s *Sphere) Bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1)).Transform(s.m)
+}
+
 func (s *Sphere) Intersects(r *ray.Ray) []ray.Intersection {
 	rt := r.Transform(s.im)
 	sphereToRay := rt.Origin.Sub(tuple.Origin)
@@ -71,16 +79,26 @@ func (s *Sphere) Intersects(r *ray.Ray) []ray.Intersection {
 }
 
 func (s *Sphere) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
-	worldNormal := s.imt.MultTuple(s.im.MultTuple(pos).Sub(tuple.Origin))
-	worldNormal.W = 0
+	return s.NormalToWorld(s.WorldToObject(pos).Sub(tuple.Origin))
+}
 
-	return worldNormal.Norm()
+// UVAt returns the surface coordinates of a scene point, wrapped around the sphere
+func (s *Sphere) UVAt(pos *tuple.Tuple) (float64, float64) {
+	return pattern.SphericalMap(s.WorldToObject(pos))
 }
 
 func (s *Sphere) SetParent(group GroupInterface) {
 	s.parent = group
 }
 
+func (s *Sphere) SetCastsShadow(casts bool) {
+	s.noShadow = !casts
+}
+
+func (s *Sphere) CastsShadow() bool {
+	return !s.noShadow
+}
+
 func (s *Sphere) WorldToObject(p *tuple.Tuple) *tuple.Tuple {
 	if s.parent != nil {
 		return s.im.MultTuple(s.parent.WorldToGroup(p))
@@ -89,7 +107,18 @@ func (s *Sphere) WorldToObject(p *tuple.Tuple) *tuple.Tuple {
 	return s.im.MultTuple(p)
 }
 
-func (s *Sphere) Shade(light *light.PointLight, h *ray.Hit) *color.Color {
+func (s *Sphere) NormalToWorld(n *tuple.Tuple) *tuple.Tuple {
+	worldNormal := s.imt.MultTuple(n)
+	worldNormal.W = 0
+
+	if s.parent != nil {
+		return s.parent.GroupToWorld(worldNormal.Norm())
+	}
+
+	return worldNormal.Norm()
+}
+
+func (s *Sphere) Shade(light light.Light, h *ray.Hit) *color.Color {
 	return s.Mat.Lighting(light, h)
 }
 
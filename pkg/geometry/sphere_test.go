@@ -25,7 +25,7 @@ func TestSphere_Intersects(t *testing.T) {
 				tuple.NewPoint(0, 0, -5),
 				tuple.Up,
 			),
-			want: []ray.Intersection{{4, s}, {6, s}},
+			want: []ray.Intersection{{T: 4, P: s}, {T: 6, P: s}},
 		},
 		{
 			name: "A ray intersects a sphere at a tangent",
@@ -33,7 +33,7 @@ func TestSphere_Intersects(t *testing.T) {
 				tuple.NewPoint(0, 1, -5),
 				tuple.Up,
 			),
-			want: []ray.Intersection{{5, s}, {5, s}},
+			want: []ray.Intersection{{T: 5, P: s}, {T: 5, P: s}},
 		},
 		{
 			name: "A ray misses a sphere",
@@ -49,7 +49,7 @@ func TestSphere_Intersects(t *testing.T) {
 				tuple.Origin,
 				tuple.Up,
 			),
-			want: []ray.Intersection{{-1, s}, {1, s}},
+			want: []ray.Intersection{{T: -1, P: s}, {T: 1, P: s}},
 		},
 		{
 			name: "A sphere is behind a ray",
@@ -57,7 +57,7 @@ func TestSphere_Intersects(t *testing.T) {
 				tuple.NewPoint(0, 0, 5),
 				tuple.Up,
 			),
-			want: []ray.Intersection{{-6, s}, {-4, s}},
+			want: []ray.Intersection{{T: -6, P: s}, {T: -4, P: s}},
 		},
 	}
 	for _, tc := range testCases {
@@ -77,8 +77,8 @@ func TestSphere_IntersectsTransformed(t *testing.T) {
 	// Intersecting a scaled sphere with a ray
 	s := NewSphere(matrix.Scaling(2, 2, 2), material.DefaultPhong)
 	want := []ray.Intersection{
-		{3, s},
-		{7, s},
+		{T: 3, P: s},
+		{T: 7, P: s},
 	}
 
 	assert.Equal(t, want, s.Intersects(r))
@@ -142,3 +142,14 @@ func TestSphere_NormalAt(t *testing.T) {
 		})
 	}
 }
+
+func TestSphere_UVAt(t *testing.T) {
+	s := NewSphere(matrix.Translation(0, 0, 2).Mult(matrix.Scaling(2, 2, 2)), material.DefaultPhong)
+
+	u, v := s.UVAt(tuple.NewPoint(0, -2, 2))
+	assert.InDelta(t, 0.75, u, 1e-9)
+	assert.InDelta(t, 0.5, v, 1e-9)
+
+	u, v = s.UVAt(tuple.NewPoint(0, 0, 4))
+	assert.InDelta(t, 1, v, 1e-9)
+}
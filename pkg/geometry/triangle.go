@@ -0,0 +1,221 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+type Triangle struct {
+	// the transformation matrix
+	m *matrix.Matrix
+	// the inverse transformation matrix
+	im *matrix.Matrix
+	// the transposition of the inverse matrix
+	imt *matrix.Matrix
+	// the vertices, in object space
+	P1 *tuple.Tuple
+	P2 *tuple.Tuple
+	P3 *tuple.Tuple
+	// the edges from P1 to P2 and from P1 to P3
+	e1 *tuple.Tuple
+	e2 *tuple.Tuple
+	// the object space normal
+	n *tuple.Tuple
+	// the texture coordinates of each vertex, or nil to use barycentric coordinates
+	texCoords *[3][2]float64
+	// the material
+	Mat      material.Material
+	parent   GroupInterface
+	noShadow bool
+}
+
+func NewTriangle(p1, p2, p3 *tuple.Tuple, m *matrix.Matrix, mat material.Material) *Triangle {
+	tri := &Triangle{
+		m:   matrix.Identity,
+		im:  matrix.Identity,
+		imt: matrix.Identity,
+		P1:  p1,
+		P2:  p2,
+		P3:  p3,
+		e1:  p2.Sub(p1),
+		e2:  p3.Sub(p1),
+		Mat: material.DefaultPhong,
+	}
+
+	tri.n = tri.e2.CrossProd(tri.e1).Norm()
+
+	if m != nil {
+		tri.SetMatrix(m)
+	}
+
+	if mat != nil {
+		tri.Mat = mat
+	}
+
+	return tri
+}
+
+func (tri *Triangle) SetMatrix(m *matrix.Matrix) {
+	tri.m = m
+	tri.im = m.Inverse()
+	tri.imt = tri.im.Transpose()
+}
+
+func (tri *Triangle) GetMatrix() *matrix.Matrix {
+	return tri.m
+}
+
+func (tri *Triangle) Bounds() *Bounds {
+	return EmptyBounds().AddPoint(tri.P1).AddPoint(tri.P2).AddPoint(tri.P3).Transform(tri.m)
+}
+
+func (tri *Triangle) Intersects(r *ray.Ray) []ray.Intersection {
+	rt := r.Transform(tri.im)
+
+	inter, ok := intersectTriangle(rt, tri.P1, tri.e1, tri.e2)
+	if !ok {
+		return []ray.Intersection{}
+	}
+
+	inter.P = tri
+
+	return []ray.Intersection{inter}
+}
+
+// intersectTriangle finds where an object space ray meets a triangle, using the Möller–Trumbore algorithm.
+// The intersection has the t value and barycentric coordinates of the hit, but no primitive.
+func intersectTriangle(r *ray.Ray, p1, e1, e2 *tuple.Tuple) (ray.Intersection, bool) {
+	dirCrossE2 := r.Direction.CrossProd(e2)
+	det := e1.DotProd(dirCrossE2)
+
+	// the ray is parallel to the triangle
+	if math.Abs(det) < util.Epsilon {
+		return ray.Intersection{}, false
+	}
+
+	f := 1 / det
+	p1ToOrigin := r.Origin.Sub(p1)
+
+	u := f * p1ToOrigin.DotProd(dirCrossE2)
+	if u < 0 || u > 1 {
+		return ray.Intersection{}, false
+	}
+
+	originCrossE1 := p1ToOrigin.CrossProd(e1)
+
+	v := f * r.Direction.DotProd(originCrossE1)
+	if v < 0 || u+v > 1 {
+		return ray.Intersection{}, false
+	}
+
+	return ray.Intersection{T: f * e2.DotProd(originCrossE1), U: u, V: v}, true
+}
+
+// barycentric returns the weights of the second and third vertices at an object space point
+func barycentric(pos, p1, e1, e2 *tuple.Tuple) (float64, float64) {
+	p1ToPos := pos.Sub(p1)
+
+	d11 := e1.DotProd(e1)
+	d12 := e1.DotProd(e2)
+	d22 := e2.DotProd(e2)
+	dp1 := p1ToPos.DotProd(e1)
+	dp2 := p1ToPos.DotProd(e2)
+
+	denom := d11*d22 - d12*d12
+	if denom == 0 {
+		return 0, 0
+	}
+
+	return (d22*dp1 - d12*dp2) / denom, (d11*dp2 - d12*dp1) / denom
+}
+
+// interpolateTexCoords blends the texture coordinates of a triangle's vertices at barycentric coordinates u and v.
+// Returns u and v if there are no texture coordinates.
+func interpolateTexCoords(texCoords *[3][2]float64, u, v float64) (float64, float64) {
+	if texCoords == nil {
+		return u, v
+	}
+
+	w := 1 - u - v
+
+	return texCoords[0][0]*w + texCoords[1][0]*u + texCoords[2][0]*v,
+		texCoords[0][1]*w + texCoords[1][1]*u + texCoords[2][1]*v
+}
+
+func (tri *Triangle) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
+	return tri.NormalToWorld(tri.n)
+}
+
+// UVAt returns the texture coordinates of a scene point on the triangle,
+// which are its barycentric coordinates if the triangle has no texture coordinates
+func (tri *Triangle) UVAt(pos *tuple.Tuple) (float64, float64) {
+	u, v := barycentric(tri.WorldToObject(pos), tri.P1, tri.e1, tri.e2)
+
+	return interpolateTexCoords(tri.texCoords, u, v)
+}
+
+// NormalAtIntersection returns the normal of a hit found by Intersects, which is the same everywhere on the triangle
+func (tri *Triangle) NormalAtIntersection(i ray.Intersection) *tuple.Tuple {
+	return tri.NormalToWorld(tri.n)
+}
+
+// UVAtIntersection returns the texture coordinates of a hit found by Intersects,
+// from the barycentric coordinates it was found with
+func (tri *Triangle) UVAtIntersection(i ray.Intersection) (float64, float64) {
+	return interpolateTexCoords(tri.texCoords, i.U, i.V)
+}
+
+// SetTexCoords sets the texture coordinates of each vertex
+func (tri *Triangle) SetTexCoords(t1, t2, t3 [2]float64) {
+	tri.texCoords = &[3][2]float64{t1, t2, t3}
+}
+
+func (tri *Triangle) SetParent(group GroupInterface) {
+	tri.parent = group
+}
+
+func (tri *Triangle) SetCastsShadow(casts bool) {
+	tri.noShadow = !casts
+}
+
+func (tri *Triangle) CastsShadow() bool {
+	return !tri.noShadow
+}
+
+func (tri *Triangle) WorldToObject(p *tuple.Tuple) *tuple.Tuple {
+	if tri.parent != nil {
+		return tri.im.MultTuple(tri.parent.WorldToGroup(p))
+	}
+
+	return tri.im.MultTuple(p)
+}
+
+func (tri *Triangle) NormalToWorld(n *tuple.Tuple) *tuple.Tuple {
+	worldNormal := tri.imt.MultTuple(n)
+	worldNormal.W = 0
+
+	if tri.parent != nil {
+		return tri.parent.GroupToWorld(worldNormal.Norm())
+	}
+
+	return worldNormal.Norm()
+}
+
+func (tri *Triangle) Shade(light light.Light, h *ray.Hit) *color.Color {
+	return tri.Mat.Lighting(light, h)
+}
+
+func (tri *Triangle) GetMaterial() material.Material {
+	return tri.Mat
+}
+
+func (tri *Triangle) GetIOR() float64 {
+	return tri.Mat.GetIOR()
+}
@@ -0,0 +1,96 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTriangle(t *testing.T) {
+	// Constructing a triangle
+	tri := NewTriangle(
+		tuple.NewPoint(0, 1, 0),
+		tuple.NewPoint(-1, 0, 0),
+		tuple.NewPoint(1, 0, 0),
+		nil, nil)
+
+	assert.Equal(t, tuple.NewVector(-1, -1, 0), tri.e1)
+	assert.Equal(t, tuple.NewVector(1, -1, 0), tri.e2)
+	assert.True(t, tuple.NewVector(0, 0, -1).Equal(tri.n))
+}
+
+func TestTriangle_Intersects(t *testing.T) {
+	tri := NewTriangle(
+		tuple.NewPoint(0, 1, 0),
+		tuple.NewPoint(-1, 0, 0),
+		tuple.NewPoint(1, 0, 0),
+		nil, nil)
+
+	testCases := []struct {
+		name string
+		r    *ray.Ray
+		want []ray.Intersection
+	}{
+		{
+			name: "Intersecting a ray parallel to the triangle",
+			r:    ray.NewRay(tuple.NewPoint(0, -1, -2), tuple.NewVector(0, 1, 0)),
+			want: []ray.Intersection{},
+		},
+		{
+			name: "A ray misses the p1-p3 edge",
+			r:    ray.NewRay(tuple.NewPoint(1, 1, -2), tuple.NewVector(0, 0, 1)),
+			want: []ray.Intersection{},
+		},
+		{
+			name: "A ray misses the p1-p2 edge",
+			r:    ray.NewRay(tuple.NewPoint(-1, 1, -2), tuple.NewVector(0, 0, 1)),
+			want: []ray.Intersection{},
+		},
+		{
+			name: "A ray misses the p2-p3 edge",
+			r:    ray.NewRay(tuple.NewPoint(0, -1, -2), tuple.NewVector(0, 0, 1)),
+			want: []ray.Intersection{},
+		},
+		{
+			name: "A ray strikes a triangle",
+			r:    ray.NewRay(tuple.NewPoint(0, 0.5, -2), tuple.NewVector(0, 0, 1)),
+			want: []ray.Intersection{{T: 2, P: tri, U: 0.25, V: 0.25}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tri.Intersects(tc.r))
+		})
+	}
+}
+
+func TestTriangle_NormalAt(t *testing.T) {
+	// The normal of a triangle is constant everywhere
+	tri := NewTriangle(
+		tuple.NewPoint(0, 1, 0),
+		tuple.NewPoint(-1, 0, 0),
+		tuple.NewPoint(1, 0, 0),
+		nil, nil)
+
+	assert.Equal(t, tri.n, tri.NormalAt(tuple.NewPoint(0, 0.5, 0)))
+	assert.Equal(t, tri.n, tri.NormalAt(tuple.NewPoint(-0.5, 0.75, 0)))
+	assert.Equal(t, tri.n, tri.NormalAt(tuple.NewPoint(0.5, 0.25, 0)))
+}
+
+func TestTriangle_UVAt(t *testing.T) {
+	// The hit of a triangle carries the barycentric coordinates of the intersection
+	tri := NewTriangle(
+		tuple.NewPoint(0, 1, 0),
+		tuple.NewPoint(-1, 0, 0),
+		tuple.NewPoint(1, 0, 0),
+		nil, nil)
+
+	r := ray.NewRay(tuple.NewPoint(-0.2, 0.3, -2), tuple.NewVector(0, 0, 1))
+	h := ray.NewHit(r, tri.Intersects(r), 0)
+
+	assert.InDelta(t, 0.45, h.U, 1e-9)
+	assert.InDelta(t, 0.25, h.V, 1e-9)
+}
@@ -0,0 +1,30 @@
+package light
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// DirectionalLight emits parallel rays from infinitely far away, like the sun
+type DirectionalLight struct {
+	// Direction is the direction the light travels in
+	Direction *tuple.Tuple
+	Color     *color.Color
+}
+
+func NewDirectionalLight(direction *tuple.Tuple, col *color.Color) *DirectionalLight {
+	return &DirectionalLight{
+		Direction: direction.Norm(),
+		Color:     col,
+	}
+}
+
+func (l *DirectionalLight) GetColor() *color.Color {
+	return l.Color
+}
+
+func (l *DirectionalLight) Illuminate(pos *tuple.Tuple) (*tuple.Tuple, float64, *color.Color) {
+	return l.Direction.Neg(), math.Inf(1), l.Color
+}
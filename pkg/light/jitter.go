@@ -0,0 +1,28 @@
+package light
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// Jitter returns an offset from 0 to 1 for the i-th sample of an area light seen from pos.
+// The offsets look random, but are always the same for the same point, so renders can be repeated
+// and the workers of a render don't all wait on one random number generator.
+func Jitter(pos *tuple.Tuple, i int) float64 {
+	h := uint64(i)
+	for _, x := range []float64{pos.X, pos.Y, pos.Z} {
+		h = mix(h ^ math.Float64bits(x))
+	}
+
+	return float64(mix(h)>>11) / (1 << 53)
+}
+
+// mix scrambles the bits of a number, so that nearby inputs give unrelated results (the splitmix64 finalizer)
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,33 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitter(t *testing.T) {
+	p := tuple.NewPoint(1, 2, 3)
+
+	// the same point and index always give the same offset
+	assert.Equal(t, Jitter(p, 4), Jitter(tuple.NewPoint(1, 2, 3), 4))
+
+	// but they are spread evenly between 0 and 1
+	const count = 10000
+	sum := 0.0
+	seen := make(map[float64]bool)
+
+	for i := 0; i < count; i++ {
+		x := Jitter(p, i)
+		assert.True(t, x >= 0 && x < 1)
+
+		seen[x] = true
+		sum += x
+	}
+
+	assert.Len(t, seen, count)
+	assert.InDelta(t, 0.5, sum/count, 0.01)
+	assert.NotEqual(t, Jitter(p, 0), Jitter(tuple.NewPoint(1, 2, 3.001), 0))
+}
@@ -5,7 +5,34 @@ import (
 	"github.com/Henelik/tricaster/pkg/tuple"
 )
 
+// Light is a source of direct illumination
+type Light interface {
+	// Illuminate returns the normalized vector from pos toward the light,
+	// the distance to the light, and the color of the light arriving at pos
+	Illuminate(pos *tuple.Tuple) (*tuple.Tuple, float64, *color.Color)
+	// GetColor returns the color the light gives off, before it's shaped by a spot light's cone
+	GetColor() *color.Color
+}
+
+// AreaLight is a light with a surface, which casts soft shadows
+type AreaLight interface {
+	Light
+	// Samples returns jittered points spread over the part of the light visible from pos
+	Samples(pos *tuple.Tuple) []*tuple.Tuple
+}
+
+// PointLight emits light equally in every direction from a single point
 type PointLight struct {
 	Pos   *tuple.Tuple
 	Color *color.Color
 }
+
+func (l *PointLight) GetColor() *color.Color {
+	return l.Color
+}
+
+func (l *PointLight) Illuminate(pos *tuple.Tuple) (*tuple.Tuple, float64, *color.Color) {
+	v := l.Pos.Sub(pos)
+
+	return v.Norm(), v.Mag(), l.Color
+}
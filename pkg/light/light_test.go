@@ -0,0 +1,78 @@
+package light
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointLight_Illuminate(t *testing.T) {
+	l := &PointLight{Pos: tuple.NewPoint(0, 0, 10), Color: color.White}
+
+	dir, dist, col := l.Illuminate(tuple.NewPoint(0, 0, 2))
+
+	assert.Equal(t, tuple.Up, dir)
+	assert.Equal(t, 8.0, dist)
+	assert.Equal(t, color.White, col)
+}
+
+func TestDirectionalLight_Illuminate(t *testing.T) {
+	l := NewDirectionalLight(tuple.NewVector(0, 0, -5), color.Red)
+
+	dir, dist, col := l.Illuminate(tuple.NewPoint(3, -4, 2))
+
+	assert.Equal(t, tuple.Up, dir)
+	assert.True(t, math.IsInf(dist, 1))
+	assert.Equal(t, color.Red, col)
+}
+
+func TestSpotLight_Illuminate(t *testing.T) {
+	l := NewSpotLight(tuple.NewPoint(0, 0, 10), tuple.Down, math.Pi/4, math.Pi/8, color.White)
+
+	testCases := []struct {
+		name string
+		pos  *tuple.Tuple
+		want float64
+	}{
+		{
+			name: "A point on the axis of the cone is fully lit",
+			pos:  tuple.NewPoint(0, 0, 0),
+			want: 1,
+		},
+		{
+			name: "A point inside the falloff is fully lit",
+			pos:  tuple.NewPoint(math.Tan(math.Pi/8)*10-0.01, 0, 0),
+			want: 1,
+		},
+		{
+			name: "A point outside the cone is unlit",
+			pos:  tuple.NewPoint(0, 10.01, 0),
+			want: 0,
+		},
+		{
+			name: "A point behind the light is unlit",
+			pos:  tuple.NewPoint(0, 0, 20),
+			want: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, _, col := l.Illuminate(tc.pos)
+
+			assert.True(t, l.Pos.Sub(tc.pos).Norm().Equal(dir))
+			assert.InDelta(t, tc.want, col.R, 1e-9)
+		})
+	}
+
+	// A point within the falloff is partially lit, fading toward the edge of the cone
+	_, _, inner := l.Illuminate(tuple.NewPoint(math.Tan(math.Pi*5/32)*10, 0, 0))
+	_, _, outer := l.Illuminate(tuple.NewPoint(math.Tan(math.Pi*7/32)*10, 0, 0))
+
+	assert.True(t, inner.R < 1)
+	assert.True(t, outer.R > 0)
+	assert.True(t, outer.R < inner.R)
+}
@@ -0,0 +1,62 @@
+package light
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// RectLight is a rectangular area light
+type RectLight struct {
+	// Pos is the center of the rectangle
+	Pos *tuple.Tuple
+	// U and V are the edges of the rectangle
+	U     *tuple.Tuple
+	V     *tuple.Tuple
+	Color *color.Color
+	// Steps is the number of cells along each edge, so each shadow test uses Steps*Steps rays
+	Steps int
+}
+
+func NewRectLight(pos, u, v *tuple.Tuple, steps int, col *color.Color) *RectLight {
+	if steps < 1 {
+		steps = 1
+	}
+
+	return &RectLight{
+		Pos:   pos,
+		U:     u,
+		V:     v,
+		Color: col,
+		Steps: steps,
+	}
+}
+
+func (l *RectLight) GetColor() *color.Color {
+	return l.Color
+}
+
+func (l *RectLight) Illuminate(pos *tuple.Tuple) (*tuple.Tuple, float64, *color.Color) {
+	v := l.Pos.Sub(pos)
+
+	return v.Norm(), v.Mag(), l.Color
+}
+
+// Samples returns one jittered point in each cell of the rectangle
+func (l *RectLight) Samples(pos *tuple.Tuple) []*tuple.Tuple {
+	result := make([]*tuple.Tuple, 0, l.Steps*l.Steps)
+
+	corner := l.Pos.Sub(l.U.Mult(0.5)).Sub(l.V.Mult(0.5))
+	n := float64(l.Steps)
+
+	for i := 0; i < l.Steps; i++ {
+		for j := 0; j < l.Steps; j++ {
+			cell := 2 * (i*l.Steps + j)
+			u := (float64(i) + Jitter(pos, cell)) / n
+			v := (float64(j) + Jitter(pos, cell+1)) / n
+
+			result = append(result, corner.Add(l.U.Mult(u)).Add(l.V.Mult(v)))
+		}
+	}
+
+	return result
+}
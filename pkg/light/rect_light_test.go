@@ -0,0 +1,36 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRectLight_Samples(t *testing.T) {
+	l := NewRectLight(tuple.NewPoint(0, 0, 10), tuple.NewVector(2, 0, 0), tuple.NewVector(0, 4, 0), 4, color.White)
+
+	samples := l.Samples(tuple.Origin)
+	assert.Len(t, samples, 16)
+
+	// every cell of the grid holds exactly one sample
+	cells := make(map[[2]int]bool)
+	for _, s := range samples {
+		assert.Equal(t, 10.0, s.Z)
+		assert.True(t, s.X >= -1 && s.X <= 1)
+		assert.True(t, s.Y >= -2 && s.Y <= 2)
+
+		cells[[2]int{int((s.X + 1) / 0.5), int((s.Y + 2) / 1)}] = true
+	}
+	assert.Len(t, cells, 16)
+
+	// the jitter depends only on the point the light is seen from
+	assert.Equal(t, samples, l.Samples(tuple.Origin))
+	assert.NotEqual(t, samples, l.Samples(tuple.NewPoint(0, 0, 1)))
+
+	dir, dist, _ := l.Illuminate(tuple.Origin)
+	assert.Equal(t, tuple.Up, dir)
+	assert.Equal(t, 10.0, dist)
+}
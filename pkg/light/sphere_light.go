@@ -0,0 +1,61 @@
+package light
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// SphereLight is a spherical area light
+type SphereLight struct {
+	Pos    *tuple.Tuple
+	Radius float64
+	Color  *color.Color
+	// Steps is the number of strata in radius and angle, so each shadow test uses Steps*Steps rays
+	Steps int
+}
+
+func NewSphereLight(pos *tuple.Tuple, radius float64, steps int, col *color.Color) *SphereLight {
+	if steps < 1 {
+		steps = 1
+	}
+
+	return &SphereLight{
+		Pos:    pos,
+		Radius: radius,
+		Color:  col,
+		Steps:  steps,
+	}
+}
+
+func (l *SphereLight) GetColor() *color.Color {
+	return l.Color
+}
+
+func (l *SphereLight) Illuminate(pos *tuple.Tuple) (*tuple.Tuple, float64, *color.Color) {
+	v := l.Pos.Sub(pos)
+
+	return v.Norm(), v.Mag(), l.Color
+}
+
+// Samples returns stratified, jittered points on the disk which the sphere presents to pos
+func (l *SphereLight) Samples(pos *tuple.Tuple) []*tuple.Tuple {
+	result := make([]*tuple.Tuple, 0, l.Steps*l.Steps)
+
+	u, v := l.Pos.Sub(pos).Norm().Basis()
+	n := float64(l.Steps)
+
+	for i := 0; i < l.Steps; i++ {
+		for j := 0; j < l.Steps; j++ {
+			// taking the square root of the radius keeps the samples uniform over the disk's area
+			cell := 2 * (i*l.Steps + j)
+			r := l.Radius * math.Sqrt((float64(i)+Jitter(pos, cell))/n)
+			theta := 2 * math.Pi * (float64(j) + Jitter(pos, cell+1)) / n
+
+			result = append(result, l.Pos.Add(u.Mult(r*math.Cos(theta))).Add(v.Mult(r*math.Sin(theta))))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,23 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSphereLight_Samples(t *testing.T) {
+	l := NewSphereLight(tuple.NewPoint(0, 0, 10), 2, 3, color.White)
+
+	samples := l.Samples(tuple.Origin)
+	assert.Len(t, samples, 9)
+
+	// the samples lie on the disk facing the point
+	for _, s := range samples {
+		assert.InDelta(t, 10, s.Z, 1e-9)
+		assert.True(t, s.Sub(l.Pos).Mag() <= 2)
+	}
+}
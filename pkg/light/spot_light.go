@@ -0,0 +1,64 @@
+package light
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// SpotLight emits light from a point within a cone
+type SpotLight struct {
+	Pos *tuple.Tuple
+	// Direction is the axis of the cone
+	Direction *tuple.Tuple
+	Color     *color.Color
+	// the cosines of the angles where the falloff ends and begins
+	cosOuter float64
+	cosInner float64
+}
+
+// NewSpotLight creates a spot light whose cone has the given half-angle, in radians.
+// The light fades out over the outermost falloff radians of the cone.
+func NewSpotLight(pos, direction *tuple.Tuple, angle, falloff float64, col *color.Color) *SpotLight {
+	falloff = util.Clamp(falloff, 0, angle)
+
+	return &SpotLight{
+		Pos:       pos,
+		Direction: direction.Norm(),
+		Color:     col,
+		cosOuter:  math.Cos(angle),
+		cosInner:  math.Cos(angle - falloff),
+	}
+}
+
+func (l *SpotLight) GetColor() *color.Color {
+	return l.Color
+}
+
+func (l *SpotLight) Illuminate(pos *tuple.Tuple) (*tuple.Tuple, float64, *color.Color) {
+	v := l.Pos.Sub(pos)
+	lightV := v.Norm()
+
+	return lightV, v.Mag(), l.Color.MultF(l.Intensity(lightV.Neg()))
+}
+
+// Intensity returns how much of the light is emitted in a direction, from 0 outside
+// the cone to 1 inside the falloff
+func (l *SpotLight) Intensity(dir *tuple.Tuple) float64 {
+	cos := dir.DotProd(l.Direction)
+
+	if cos <= l.cosOuter {
+		return 0
+	}
+
+	if cos >= l.cosInner {
+		return 1
+	}
+
+	// smoothly interpolate across the falloff
+	x := (cos - l.cosOuter) / (l.cosInner - l.cosOuter)
+
+	return x * x * (3 - 2*x)
+}
@@ -0,0 +1,114 @@
+package material
+
+import (
+	"github.com/Henelik/tricaster/pkg/pattern"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// bumpDelta is the distance across which the slope of a height map is measured
+const bumpDelta = 0.0001
+
+// Bump perturbs the shading normal of a surface, to add detail without adding geometry
+type Bump interface {
+	// NormalAt returns the perturbed normal at a hit
+	NormalAt(h *ray.Hit) *tuple.Tuple
+}
+
+// HeightBump treats the brightness of a pattern as the height of the surface, so bright areas are raised.
+// Strength scales the height, and a negative strength sinks bright areas instead.
+type HeightBump struct {
+	Pattern  pattern.Pattern
+	Strength float64
+}
+
+func NewHeightBump(p pattern.Pattern, strength float64) *HeightBump {
+	return &HeightBump{
+		Pattern:  p,
+		Strength: strength,
+	}
+}
+
+func (b *HeightBump) NormalAt(h *ray.Hit) *tuple.Tuple {
+	t1, t2 := h.NormalV.Basis()
+
+	height := b.heightAt(h, h.Pos)
+	slope1 := (b.heightAt(h, h.Pos.Add(t1.Mult(bumpDelta))) - height) / bumpDelta
+	slope2 := (b.heightAt(h, h.Pos.Add(t2.Mult(bumpDelta))) - height) / bumpDelta
+
+	// the normal leans away from the uphill directions
+	return h.NormalV.
+		Sub(t1.Mult(slope1 * b.Strength)).
+		Sub(t2.Mult(slope2 * b.Strength)).
+		Norm()
+}
+
+// heightAt returns the height of the surface at a point near a hit
+func (b *HeightBump) heightAt(h *ray.Hit, pos *tuple.Tuple) float64 {
+	u, v := surfaceAt(h, pos)
+
+	return average(patternAt(b.Pattern, &ray.Hit{Pos: pos, U: u, V: v}))
+}
+
+// NormalMap reads tangent space normals from the colors of a pattern, usually an image texture,
+// where red, green and blue run from -1 to 1 along the u, v and normal directions.
+// Strength scales how far the normals lean, and 1 uses the normals of the map unchanged.
+type NormalMap struct {
+	Pattern  pattern.Pattern
+	Strength float64
+}
+
+func NewNormalMap(p pattern.Pattern, strength float64) *NormalMap {
+	return &NormalMap{
+		Pattern:  p,
+		Strength: strength,
+	}
+}
+
+func (b *NormalMap) NormalAt(h *ray.Hit) *tuple.Tuple {
+	c := patternAt(b.Pattern, h)
+	tangent, bitangent := tangentFrame(h)
+
+	return tangent.Mult((c.R*2 - 1) * b.Strength).
+		Add(bitangent.Mult((c.G*2 - 1) * b.Strength)).
+		Add(h.NormalV.Mult(c.B*2 - 1)).
+		Norm()
+}
+
+// tangentFrame returns the directions in which the surface coordinates u and v increase at a hit,
+// or any two directions along the surface if the primitive has no surface coordinates
+func tangentFrame(h *ray.Hit) (*tuple.Tuple, *tuple.Tuple) {
+	t1, t2 := h.NormalV.Basis()
+
+	u0, v0 := surfaceAt(h, h.Pos)
+	u1, v1 := surfaceAt(h, h.Pos.Add(t1.Mult(bumpDelta)))
+	u2, v2 := surfaceAt(h, h.Pos.Add(t2.Mult(bumpDelta)))
+
+	tangent := t1.Mult(u1 - u0).Add(t2.Mult(u2 - u0))
+	if tangent.Mag() == 0 {
+		return t1, t2
+	}
+
+	tangent = tangent.Norm()
+
+	// the bitangent is kept perpendicular, on the side where v increases
+	bitangent := h.NormalV.CrossProd(tangent)
+	if bitangent.DotProd(t1.Mult(v1-v0).Add(t2.Mult(v2-v0))) < 0 {
+		bitangent = bitangent.Neg()
+	}
+
+	return tangent, bitangent
+}
+
+// surfaceAt returns the surface coordinates of a point near a hit, on the primitive which was hit
+func surfaceAt(h *ray.Hit, pos *tuple.Tuple) (float64, float64) {
+	if h.Inters == nil {
+		return h.U, h.V
+	}
+
+	if mapper, ok := h.Inters[h.Index].P.(ray.UVMapper); ok {
+		return mapper.UVAt(pos)
+	}
+
+	return 0, 0
+}
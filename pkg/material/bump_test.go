@@ -0,0 +1,74 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/pattern"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeightBump_NormalAt(t *testing.T) {
+	// the gradient gets brighter toward +X, so the surface slopes upward along X
+	slope := pattern.NewGradientPattern(nil, pattern.NewSolidPattern(color.Black), pattern.NewSolidPattern(color.White))
+
+	testCases := []struct {
+		name string
+		bump *HeightBump
+		want *tuple.Tuple
+	}{
+		{
+			name: "A flat pattern leaves the normal alone",
+			bump: NewHeightBump(pattern.NewSolidPattern(color.White), 1),
+			want: tuple.Up,
+		},
+		{
+			name: "The normal leans away from the slope",
+			bump: NewHeightBump(slope, 1),
+			want: tuple.NewVector(-1, 0, 1).Norm(),
+		},
+		{
+			name: "Strength scales the slope",
+			bump: NewHeightBump(slope, 0.5),
+			want: tuple.NewVector(-0.5, 0, 1).Norm(),
+		},
+		{
+			name: "Negative strength sinks bright areas",
+			bump: NewHeightBump(slope, -1),
+			want: tuple.NewVector(1, 0, 1).Norm(),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &ray.Hit{Pos: tuple.NewPoint(0.5, 0.5, 0), NormalV: tuple.Up}
+			got := tc.bump.NormalAt(h)
+
+			assert.InDelta(t, tc.want.X, got.X, 1e-6)
+			assert.InDelta(t, tc.want.Y, got.Y, 1e-6)
+			assert.InDelta(t, tc.want.Z, got.Z, 1e-6)
+		})
+	}
+}
+
+func TestNormalMap_NormalAt(t *testing.T) {
+	h := &ray.Hit{Pos: tuple.Origin, NormalV: tuple.Up}
+	t1, _ := tuple.Up.Basis()
+
+	t.Run("A flat normal map leaves the normal alone", func(t *testing.T) {
+		got := NewNormalMap(pattern.NewSolidPattern(color.NewColor(0.5, 0.5, 1)), 1).NormalAt(h)
+		assert.True(t, tuple.Up.Equal(got))
+	})
+
+	t.Run("Red leans the normal along the tangent", func(t *testing.T) {
+		got := NewNormalMap(pattern.NewSolidPattern(color.NewColor(1, 0.5, 1)), 1).NormalAt(h)
+		assert.True(t, t1.Add(tuple.Up).Norm().Equal(got))
+	})
+
+	t.Run("Strength scales the lean", func(t *testing.T) {
+		got := NewNormalMap(pattern.NewSolidPattern(color.NewColor(1, 0.5, 1)), 0)
+		assert.True(t, tuple.Up.Equal(got.NormalAt(h)))
+	})
+}
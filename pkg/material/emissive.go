@@ -0,0 +1,55 @@
+package material
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/pattern"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// EmissiveMat glows with its own light, which also lights the objects around it
+type EmissiveMat struct {
+	Color    *color.Color // used as a fallback if there is no pattern
+	Pattern  pattern.Pattern
+	Strength float64
+}
+
+func NewEmissiveMat(c *color.Color, strength float64) *EmissiveMat {
+	return &EmissiveMat{
+		Color:    c,
+		Strength: strength,
+	}
+}
+
+// Lighting is always black, since an emissive surface doesn't reflect any light
+func (m *EmissiveMat) Lighting(light light.Light, h *ray.Hit) *color.Color {
+	return color.Black
+}
+
+func (m *EmissiveMat) EmissionAt(pos *tuple.Tuple) *color.Color {
+	if m.Pattern != nil {
+		return m.Pattern.Process(pos).MultF(m.Strength)
+	}
+	return m.Color.MultF(m.Strength)
+}
+
+func (m *EmissiveMat) EmissionAtHit(h *ray.Hit) *color.Color {
+	return m.ColorAtHit(h).MultF(m.Strength)
+}
+
+// ColorAtHit returns the color of the light at a hit, before it's scaled by the strength
+func (m *EmissiveMat) ColorAtHit(h *ray.Hit) *color.Color {
+	if m.Pattern != nil {
+		return patternAt(m.Pattern, h)
+	}
+	return m.Color
+}
+
+func (m *EmissiveMat) IsLight() bool {
+	return true
+}
+
+func (m *EmissiveMat) GetIOR() float64 {
+	return 1
+}
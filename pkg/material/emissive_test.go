@@ -0,0 +1,50 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/pattern"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmissiveMat(t *testing.T) {
+	testCases := []struct {
+		name string
+		mat  *EmissiveMat
+		pos  *tuple.Tuple
+		want *color.Color
+	}{
+		{
+			name: "The emission is the color scaled by the strength",
+			mat:  NewEmissiveMat(color.NewColor(1, 0.5, 0.25), 2),
+			pos:  tuple.Origin,
+			want: color.NewColor(2, 1, 0.5),
+		},
+		{
+			name: "The emission follows the pattern",
+			mat: &EmissiveMat{
+				Pattern:  pattern.SolidPat(0, 1, 0),
+				Strength: 3,
+			},
+			pos:  tuple.NewPoint(1.5, 0, 0),
+			want: color.NewColor(0, 3, 0),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, tc.want.Equal(tc.mat.EmissionAt(tc.pos)))
+			assert.True(t, tc.want.Equal(tc.mat.ColorAtHit(&ray.Hit{Pos: tc.pos}).MultF(tc.mat.Strength)))
+			assert.True(t, tc.mat.IsLight())
+
+			// an emissive surface doesn't reflect light
+			h := &ray.Hit{Pos: tc.pos, EyeV: tuple.Up, NormalV: tuple.Up}
+			l := &light.PointLight{Pos: tuple.NewPoint(0, 0, 10), Color: color.White}
+			assert.True(t, color.Black.Equal(tc.mat.Lighting(l, h)))
+		})
+	}
+}
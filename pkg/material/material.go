@@ -3,10 +3,57 @@ package material
 import (
 	"github.com/Henelik/tricaster/pkg/color"
 	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
 )
 
 type Material interface {
-	Lighting(light *light.PointLight, h *ray.Hit) *color.Color
+	Lighting(light light.Light, h *ray.Hit) *color.Color
 	GetIOR() float64
 }
+
+// Emitter is implemented by materials which give off light of their own.
+// Emitted light is added once to a surface's color, rather than once for each light.
+type Emitter interface {
+	// EmissionAt returns the light given off at a scene point
+	EmissionAt(pos *tuple.Tuple) *color.Color
+	// EmissionAtHit is like EmissionAt, but lets a pattern use the surface coordinates of the hit
+	EmissionAtHit(h *ray.Hit) *color.Color
+	// IsLight returns true if the emitted light should illuminate other objects
+	IsLight() bool
+}
+
+// Absorber is implemented by materials which absorb some of the light traveling through them
+type Absorber interface {
+	// Transmittance returns the fraction of light which survives traveling a distance through the material
+	Transmittance(distance float64) *color.Color
+}
+
+// Sampler is implemented by materials which choose the random bounces of a path tracer themselves
+type Sampler interface {
+	// Sample returns a random direction for light to leave a hit, the weight of the light it carries,
+	// and whether it's a diffuse bounce. Returns nil if the light is absorbed.
+	// The random numbers from 0 to 1 come from random.
+	Sample(h *ray.Hit, random func() float64) (*tuple.Tuple, *color.Color, bool)
+}
+
+// Colorer is implemented by materials with a base color, which a pattern may vary over their surface
+type Colorer interface {
+	// ColorAtHit returns the base color of the surface at a hit
+	ColorAtHit(h *ray.Hit) *color.Color
+}
+
+// Bumper is implemented by materials which can perturb the normals of their surface
+type Bumper interface {
+	// GetBump returns the material's bump, or nil for a smooth surface
+	GetBump() Bump
+}
+
+// patternAt returns the color of a pattern at a hit, giving it the surface coordinates of the hit if it uses them
+func patternAt(p pattern.Pattern, h *ray.Hit) *color.Color {
+	if s, ok := p.(pattern.SurfacePattern); ok {
+		return s.ProcessSurface(h.Pos, h.U, h.V)
+	}
+	return p.Process(h.Pos)
+}
@@ -0,0 +1,305 @@
+package material
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/pattern"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+const (
+	// DefaultPBRIOR gives non-metals the usual 4% reflection when seen head on
+	DefaultPBRIOR = 1.5
+	// clearcoatReflectance is the reflection of a clearcoat seen head on
+	clearcoatReflectance = 0.04
+	// minAlpha keeps perfectly smooth surfaces from dividing by zero
+	minAlpha = 0.002
+	// minCos keeps surfaces seen edge-on from dividing by zero
+	minCos = 1e-4
+)
+
+// PBRMat is a physically based metallic-roughness material, using the GGX microfacet model.
+// Lights are scaled by pi, so a white diffuse surface facing a light reflects all of it, like PhongMat.
+type PBRMat struct {
+	// Ambient is only used by the whitted integrator, since path tracing gathers indirect light
+	Ambient float64
+	Color   *color.Color // used as a fallback if there is no pattern
+	Pattern pattern.Pattern
+	// Metallic blends from a dielectric (0) to a metal (1), which has no diffuse light
+	// and reflections tinted by its color
+	Metallic float64
+	// Roughness blends from a mirror (0) to a completely rough surface (1)
+	Roughness float64
+	// SpecularTint tints the reflections of dielectrics toward their color
+	SpecularTint float64
+	// Clearcoat is the strength of a second, colorless specular layer on top of the surface
+	Clearcoat          float64
+	ClearcoatRoughness float64
+	IOR                float64
+	// Emission is the light given off by the surface, if it glows
+	Emission *color.Color
+	// Bump perturbs the normals of the surface, or is nil for a smooth surface
+	Bump Bump
+}
+
+func NewPBRMat(c *color.Color, metallic, roughness float64) *PBRMat {
+	return &PBRMat{
+		Ambient:   0.1,
+		Color:     c,
+		Metallic:  metallic,
+		Roughness: roughness,
+		IOR:       DefaultPBRIOR,
+	}
+}
+
+func (m *PBRMat) Lighting(l light.Light, h *ray.Hit) *color.Color {
+	_, _, lightColor := l.Illuminate(h.Pos)
+	ambient := m.ColorAtHit(h).MultCol(lightColor).MultF(m.Ambient)
+	if h.Shadow >= 1 {
+		return ambient
+	}
+	return ambient.Add(m.Direct(l, h))
+}
+
+// AmbientAt returns the ambient light reflected at a hit, independent of the lights
+func (m *PBRMat) AmbientAt(h *ray.Hit, lightColor *color.Color) *color.Color {
+	return m.ColorAtHit(h).MultCol(lightColor).MultF(m.Ambient)
+}
+
+// Direct returns the light reflected from a single light, without ambient light.
+// Only the unblocked fraction of the light contributes.
+func (m *PBRMat) Direct(l light.Light, h *ray.Hit) *color.Color {
+	if h.Shadow >= 1 {
+		return color.Black
+	}
+
+	lightV, _, lightColor := l.Illuminate(h.Pos)
+	if h.Tint != nil {
+		lightColor = lightColor.MultCol(h.Tint)
+	}
+
+	nDotL := lightV.DotProd(h.NormalV)
+	if nDotL <= 0 {
+		return color.Black
+	}
+
+	return m.Eval(h, lightV).
+		MultCol(lightColor).
+		MultF(math.Pi * nDotL * (1 - h.Shadow))
+}
+
+// Eval returns the fraction of light arriving from direction l which is reflected toward the eye at a hit
+func (m *PBRMat) Eval(h *ray.Hit, l *tuple.Tuple) *color.Color {
+	v := h.EyeV
+	n := h.NormalV
+
+	nDotL := l.DotProd(n)
+	if nDotL <= 0 {
+		return color.Black
+	}
+
+	nDotV := util.Max(v.DotProd(n), minCos)
+
+	half := v.Add(l).Norm()
+	nDotH := half.DotProd(n)
+	vDotH := util.Max(v.DotProd(half), 0)
+
+	base := m.ColorAtHit(h)
+
+	alpha := roughnessAlpha(m.Roughness)
+	fresnel := schlickColor(m.specularColor(base), vDotH)
+
+	specular := fresnel.MultF(ggxD(nDotH, alpha) * smithG(nDotV, nDotL, alpha) / (4 * nDotV * nDotL))
+
+	// light which isn't reflected by the surface enters it, and metals absorb all of it
+	diffuse := base.MultCol(color.White.Sub(fresnel)).MultF((1 - m.Metallic) / math.Pi)
+
+	result := diffuse.Add(specular)
+
+	if m.Clearcoat > 0 {
+		coatAlpha := roughnessAlpha(m.ClearcoatRoughness)
+		coatFresnel := m.Clearcoat * schlick(clearcoatReflectance, vDotH)
+
+		coat := coatFresnel * ggxD(nDotH, coatAlpha) * smithG(nDotV, nDotL, coatAlpha) / (4 * nDotV * nDotL)
+
+		// the coat reflects some light before it reaches the surface below
+		result = result.MultF(1 - coatFresnel).Add(color.Grey(coat))
+	}
+
+	return result
+}
+
+// Sample picks a diffuse, specular or clearcoat bounce in proportion to how much light each reflects,
+// and importance samples the chosen lobe
+func (m *PBRMat) Sample(h *ray.Hit, random func() float64) (*tuple.Tuple, *color.Color, bool) {
+	n := h.NormalV
+	v := h.EyeV
+	nDotV := util.Max(v.DotProd(n), minCos)
+
+	base := m.ColorAtHit(h)
+
+	pDiffuse := (1 - m.Metallic) * average(base)
+	pSpecular := average(schlickColor(m.specularColor(base), nDotV))
+	pCoat := m.Clearcoat * schlick(clearcoatReflectance, nDotV)
+
+	total := pDiffuse + pSpecular + pCoat
+	if total <= 0 {
+		return nil, nil, false
+	}
+
+	alpha := roughnessAlpha(m.Roughness)
+	coatAlpha := roughnessAlpha(m.ClearcoatRoughness)
+
+	var dir *tuple.Tuple
+
+	choice := random() * total
+	diffuse := choice < pDiffuse
+
+	switch {
+	case diffuse:
+		dir = n.CosineSample(random)
+	case choice < pDiffuse+pSpecular:
+		dir = v.Neg().Reflect(sampleGGX(n, alpha, random))
+	default:
+		dir = v.Neg().Reflect(sampleGGX(n, coatAlpha, random))
+	}
+
+	nDotL := dir.DotProd(n)
+	if nDotL <= 0 {
+		return nil, nil, false
+	}
+
+	// the chance of choosing this direction from any of the lobes
+	pdf := (pDiffuse*nDotL/math.Pi +
+		pSpecular*ggxPDF(n, v, dir, alpha) +
+		pCoat*ggxPDF(n, v, dir, coatAlpha)) / total
+
+	if pdf <= 0 {
+		return nil, nil, false
+	}
+
+	return dir, m.Eval(h, dir).MultF(nDotL / pdf), diffuse
+}
+
+// ColorAt returns the base color at a scene point, from the pattern if there is one
+func (m *PBRMat) ColorAt(pos *tuple.Tuple) *color.Color {
+	if m.Pattern != nil {
+		return m.Pattern.Process(pos)
+	}
+	return m.Color
+}
+
+// ColorAtHit is like ColorAt, but lets the pattern use the surface coordinates of the hit
+func (m *PBRMat) ColorAtHit(h *ray.Hit) *color.Color {
+	if m.Pattern != nil {
+		return patternAt(m.Pattern, h)
+	}
+	return m.Color
+}
+
+func (m *PBRMat) EmissionAt(pos *tuple.Tuple) *color.Color {
+	if m.Emission == nil {
+		return color.Black
+	}
+	return m.Emission
+}
+
+// EmissionAtHit is the same everywhere on the surface, since emission has no pattern
+func (m *PBRMat) EmissionAtHit(h *ray.Hit) *color.Color {
+	return m.EmissionAt(h.Pos)
+}
+
+func (m *PBRMat) IsLight() bool {
+	return m.Emission != nil && !m.Emission.Equal(color.Black)
+}
+
+func (m *PBRMat) GetIOR() float64 {
+	return m.IOR
+}
+
+func (m *PBRMat) GetBump() Bump {
+	return m.Bump
+}
+
+// specularColor returns the reflection seen head on, which metals tint with their color
+func (m *PBRMat) specularColor(base *color.Color) *color.Color {
+	r := (m.IOR - 1) / (m.IOR + 1)
+
+	dielectric := color.White.Lerp(base, m.SpecularTint).MultF(r * r)
+
+	return dielectric.Lerp(base, m.Metallic)
+}
+
+// roughnessAlpha converts a roughness to the GGX alpha, which looks more linear to the eye when squared
+func roughnessAlpha(roughness float64) float64 {
+	return util.Max(roughness*roughness, minAlpha)
+}
+
+// ggxD returns the density of microfacets facing along a half vector
+func ggxD(nDotH, alpha float64) float64 {
+	a2 := alpha * alpha
+	d := nDotH*nDotH*(a2-1) + 1
+
+	return a2 / (math.Pi * d * d)
+}
+
+// smithG returns the fraction of microfacets which are neither shadowed nor hidden
+func smithG(nDotV, nDotL, alpha float64) float64 {
+	return smithG1(nDotV, alpha) * smithG1(nDotL, alpha)
+}
+
+func smithG1(nDotX, alpha float64) float64 {
+	a2 := alpha * alpha
+
+	return 2 * nDotX / (nDotX + math.Sqrt(a2+(1-a2)*nDotX*nDotX))
+}
+
+// sampleGGX returns a random microfacet normal around n, in proportion to the GGX distribution
+func sampleGGX(n *tuple.Tuple, alpha float64, random func() float64) *tuple.Tuple {
+	u, w := n.Basis()
+
+	r1 := random()
+	r2 := random()
+
+	phi := 2 * math.Pi * r1
+	cosTheta := math.Sqrt((1 - r2) / (1 + (alpha*alpha-1)*r2))
+	sinTheta := math.Sqrt(util.Max(0, 1-cosTheta*cosTheta))
+
+	return u.Mult(sinTheta * math.Cos(phi)).
+		Add(w.Mult(sinTheta * math.Sin(phi))).
+		Add(n.Mult(cosTheta)).
+		Norm()
+}
+
+// ggxPDF returns the chance of sampleGGX producing a reflection from v to l
+func ggxPDF(n, v, l *tuple.Tuple, alpha float64) float64 {
+	half := v.Add(l).Norm()
+
+	vDotH := v.DotProd(half)
+	if vDotH <= 0 {
+		return 0
+	}
+
+	nDotH := util.Max(half.DotProd(n), 0)
+
+	return ggxD(nDotH, alpha) * nDotH / (4 * vDotH)
+}
+
+// schlick approximates the fresnel reflection of a surface seen at an angle
+func schlick(f0, cos float64) float64 {
+	return f0 + (1-f0)*math.Pow(1-cos, 5)
+}
+
+func schlickColor(f0 *color.Color, cos float64) *color.Color {
+	f := math.Pow(1-cos, 5)
+
+	return f0.Add(color.White.Sub(f0).MultF(f))
+}
+
+func average(c *color.Color) float64 {
+	return (c.R + c.G + c.B) / 3
+}
@@ -0,0 +1,170 @@
+package material
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPBR_Lighting(t *testing.T) {
+	// with no reflection head on, a fully rough dielectric is lit like a phong surface with no specular
+	matte := NewPBRMat(color.NewColor(1, 0.5, 0.25), 0, 1)
+	matte.IOR = 1
+
+	testCases := []struct {
+		name   string
+		light  light.Light
+		shadow float64
+		want   *color.Color
+	}{
+		{
+			name:  "Lighting with the eye between the light and the surface",
+			light: &light.PointLight{Pos: tuple.NewPoint(0, 0, 10), Color: color.White},
+			want:  color.NewColor(1.1, 0.55, 0.275),
+		},
+		{
+			name:  "Lighting with the light behind the surface",
+			light: &light.PointLight{Pos: tuple.NewPoint(0, 0, -10), Color: color.White},
+			want:  color.NewColor(0.1, 0.05, 0.025),
+		},
+		{
+			name:   "Lighting with the surface in shadow",
+			light:  &light.PointLight{Pos: tuple.NewPoint(0, 0, 10), Color: color.White},
+			shadow: 1,
+			want:   color.NewColor(0.1, 0.05, 0.025),
+		},
+		{
+			name:   "Lighting with the surface partially in shadow",
+			light:  &light.PointLight{Pos: tuple.NewPoint(0, 0, 10), Color: color.White},
+			shadow: 0.5,
+			want:   color.NewColor(0.6, 0.3, 0.15),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := matte.Lighting(tc.light,
+				&ray.Hit{
+					Pos:     tuple.Origin,
+					EyeV:    tuple.Up,
+					NormalV: tuple.Up,
+					Shadow:  tc.shadow,
+				})
+			assert.True(t, tc.want.Equal(result))
+		})
+	}
+}
+
+func TestPBR_Eval(t *testing.T) {
+	gold := color.NewColor(1, 0.75, 0)
+
+	eye := tuple.NewVector(0, 1, 1).Norm()
+	mirror := tuple.NewVector(0, -1, 1).Norm()
+	away := tuple.NewVector(0, 1, 0.2).Norm()
+
+	t.Run("Metals have no diffuse light, and reflections tinted by their color", func(t *testing.T) {
+		metal := NewPBRMat(gold, 1, 0.3)
+
+		// seen head on, fresnel reflection doesn't brighten toward white
+		got := metal.Eval(&ray.Hit{Pos: tuple.Origin, EyeV: tuple.Up, NormalV: tuple.Up}, tuple.Up)
+
+		assert.Greater(t, got.R, 0.0)
+		assert.InDelta(t, 0.75*got.R, got.G, 1e-9)
+		assert.InDelta(t, 0, got.B, 1e-9)
+	})
+
+	t.Run("Smoother surfaces have sharper highlights", func(t *testing.T) {
+		smooth := NewPBRMat(gold, 1, 0.1)
+		rough := NewPBRMat(gold, 1, 0.6)
+
+		assert.Greater(t, smooth.Eval(&ray.Hit{Pos: tuple.Origin, EyeV: eye, NormalV: tuple.Up}, mirror).R, rough.Eval(&ray.Hit{Pos: tuple.Origin, EyeV: eye, NormalV: tuple.Up}, mirror).R)
+		assert.Less(t, smooth.Eval(&ray.Hit{Pos: tuple.Origin, EyeV: eye, NormalV: tuple.Up}, away).R, rough.Eval(&ray.Hit{Pos: tuple.Origin, EyeV: eye, NormalV: tuple.Up}, away).R)
+	})
+
+	t.Run("A clearcoat adds a colorless highlight", func(t *testing.T) {
+		plastic := NewPBRMat(color.NewColor(0, 0, 1), 0, 0.8)
+		coated := NewPBRMat(color.NewColor(0, 0, 1), 0, 0.8)
+		coated.Clearcoat = 1
+		coated.ClearcoatRoughness = 0.05
+
+		without := plastic.Eval(&ray.Hit{Pos: tuple.Origin, EyeV: eye, NormalV: tuple.Up}, mirror)
+		with := coated.Eval(&ray.Hit{Pos: tuple.Origin, EyeV: eye, NormalV: tuple.Up}, mirror)
+
+		assert.Greater(t, with.R, without.R)
+		assert.Greater(t, with.G, without.G)
+	})
+
+	t.Run("Light from below the surface isn't reflected", func(t *testing.T) {
+		assert.Equal(t, color.Black, NewPBRMat(gold, 0, 0.5).Eval(&ray.Hit{Pos: tuple.Origin, EyeV: eye, NormalV: tuple.Up}, tuple.Down))
+	})
+}
+
+func TestPBR_Sample(t *testing.T) {
+	testCases := []struct {
+		name      string
+		metallic  float64
+		roughness float64
+		clearcoat float64
+		min       float64
+		max       float64
+	}{
+		{
+			name:      "A white dielectric reflects almost all light",
+			metallic:  0,
+			roughness: 0.5,
+			min:       0.9,
+			max:       1.05,
+		},
+		{
+			name:      "A white metal reflects almost all light",
+			metallic:  1,
+			roughness: 0.3,
+			min:       0.85,
+			max:       1.05,
+		},
+		{
+			name:      "A clearcoat doesn't add light",
+			metallic:  0,
+			roughness: 0.5,
+			clearcoat: 1,
+			min:       0.85,
+			max:       1.05,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mat := NewPBRMat(color.White, tc.metallic, tc.roughness)
+			mat.Clearcoat = tc.clearcoat
+			mat.ClearcoatRoughness = 0.1
+
+			h := &ray.Hit{
+				Pos:     tuple.Origin,
+				EyeV:    tuple.NewVector(0, 1, 2).Norm(),
+				NormalV: tuple.Up,
+			}
+
+			const count = 20000
+			sum := 0.0
+			random := rand.New(rand.NewSource(1)).Float64
+
+			for i := 0; i < count; i++ {
+				dir, weight, _ := mat.Sample(h, random)
+				if dir == nil {
+					continue
+				}
+
+				assert.Greater(t, dir.DotProd(h.NormalV), 0.0)
+				sum += weight.R
+			}
+
+			// the average weight is the fraction of light reflected
+			assert.GreaterOrEqual(t, sum/count, tc.min)
+			assert.LessOrEqual(t, sum/count, tc.max)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
 )
 
 var (
@@ -43,45 +44,112 @@ type PhongMat struct {
 	IOR          float64
 	Color        *color.Color // used as a fallback if there is no pattern
 	Pattern      pattern.Pattern
+	// Emission is the light given off by the surface, if it glows
+	Emission *color.Color
+	// Absorption is the color of white light after traveling AbsorptionDistance through a transparent material.
+	// Thicker parts of the material absorb more light, and nil absorbs none.
+	Absorption         *color.Color
+	AbsorptionDistance float64
+	// Bump perturbs the normals of the surface, or is nil for a smooth surface
+	Bump Bump
 }
 
-func (m *PhongMat) Lighting(light *light.PointLight, h *ray.Hit) *color.Color {
-	var col *color.Color
-	if m.Pattern != nil {
-		col = m.Pattern.Process(h.Pos)
-	} else {
-		col = m.Color
+func (m *PhongMat) Lighting(l light.Light, h *ray.Hit) *color.Color {
+	_, _, lightColor := l.Illuminate(h.Pos)
+	ambient := m.ColorAtHit(h).MultCol(lightColor).MultF(m.Ambient)
+	if h.Shadow >= 1 {
+		return ambient
+	}
+	return ambient.Add(m.Direct(l, h))
+}
+
+// AmbientAt returns the ambient light reflected at a hit, which stands in for light bouncing around the scene.
+// Unlike Lighting, it doesn't depend on any one light, so it's only added once however many lights there are.
+func (m *PhongMat) AmbientAt(h *ray.Hit, lightColor *color.Color) *color.Color {
+	return m.ColorAtHit(h).MultCol(lightColor).MultF(m.Ambient)
+}
+
+// Direct returns the diffuse and specular light reflected from a single light, without ambient light.
+// Only the unblocked fraction of the light contributes.
+func (m *PhongMat) Direct(l light.Light, h *ray.Hit) *color.Color {
+	if h.Shadow >= 1 {
+		return color.Black
 	}
-	if h.InShadow {
-		return col.MultCol(light.Color).MultF(m.Ambient)
+	lightV, _, lightColor := l.Illuminate(h.Pos)
+	if h.Tint != nil {
+		lightColor = lightColor.MultCol(h.Tint)
 	}
-	effectiveColor := col.MultCol(light.Color)
-	lightV := light.Pos.Sub(h.Pos).Norm()
-	ambient := effectiveColor.MultF(m.Ambient)
+	effectiveColor := m.ColorAtHit(h).MultCol(lightColor)
 	// light_dot_normal represents the cosine of the angle between the
 	// light vector and the normal vector. A negative number means the
 	// light is on the other side of the surface.
 	lightDotNormal := lightV.DotProd(h.NormalV)
-	diffuse := color.Black
+	if lightDotNormal < 0 {
+		return color.Black
+	}
+	// compute the diffuse contribution
+	diffuse := effectiveColor.MultF(m.Diffuse * lightDotNormal)
 	specular := color.Black
-	if lightDotNormal >= 0 {
-		// compute the diffuse contribution
-		diffuse = effectiveColor.MultF(m.Diffuse * lightDotNormal)
-
-		if m.Specular != 0 {
-			// reflect_dot_eye represents the cosine of the angle between the
-			// reflection vector and the eye vector. A negative number means the
-			// light reflects away from the eye.
-			reflectDotEye := lightV.Neg().Reflect(h.NormalV).DotProd(h.EyeV)
-
-			if reflectDotEye > 0 {
-				// compute the specular reflection
-				factor := math.Pow(reflectDotEye, m.Shininess)
-				specular = light.Color.MultF(m.Specular * factor)
-			}
+	if m.Specular != 0 {
+		// reflect_dot_eye represents the cosine of the angle between the
+		// reflection vector and the eye vector. A negative number means the
+		// light reflects away from the eye.
+		reflectDotEye := lightV.Neg().Reflect(h.NormalV).DotProd(h.EyeV)
+
+		if reflectDotEye > 0 {
+			// compute the specular reflection
+			factor := math.Pow(reflectDotEye, m.Shininess)
+			specular = lightColor.MultF(m.Specular * factor)
 		}
 	}
-	return ambient.Add(diffuse.Add(specular))
+	return diffuse.Add(specular).MultF(1 - h.Shadow)
+}
+
+// ColorAt returns the surface color at a scene point, from the pattern if there is one
+func (m *PhongMat) ColorAt(pos *tuple.Tuple) *color.Color {
+	if m.Pattern != nil {
+		return m.Pattern.Process(pos)
+	}
+	return m.Color
+}
+
+// ColorAtHit is like ColorAt, but lets the pattern use the surface coordinates of the hit
+func (m *PhongMat) ColorAtHit(h *ray.Hit) *color.Color {
+	if m.Pattern != nil {
+		return patternAt(m.Pattern, h)
+	}
+	return m.Color
+}
+
+func (m *PhongMat) EmissionAt(pos *tuple.Tuple) *color.Color {
+	if m.Emission == nil {
+		return color.Black
+	}
+	return m.Emission
+}
+
+// EmissionAtHit is the same everywhere on the surface, since emission has no pattern
+func (m *PhongMat) EmissionAtHit(h *ray.Hit) *color.Color {
+	return m.EmissionAt(h.Pos)
+}
+
+func (m *PhongMat) IsLight() bool {
+	return m.Emission != nil && !m.Emission.Equal(color.Black)
+}
+
+// Transmittance follows the Beer-Lambert law, so light fades exponentially with distance
+func (m *PhongMat) Transmittance(distance float64) *color.Color {
+	if m.Absorption == nil {
+		return color.White
+	}
+	scale := distance
+	if m.AbsorptionDistance > 0 {
+		scale /= m.AbsorptionDistance
+	}
+	return color.NewColor(
+		math.Pow(m.Absorption.R, scale),
+		math.Pow(m.Absorption.G, scale),
+		math.Pow(m.Absorption.B, scale))
 }
 
 // Copy returns a new duplicate material
@@ -100,3 +168,7 @@ func (m *PhongMat) CopyWithColor(c *color.Color) *PhongMat {
 func (m *PhongMat) GetIOR() float64 {
 	return m.IOR
 }
+
+func (m *PhongMat) GetBump() Bump {
+	return m.Bump
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Henelik/tricaster/pkg/color"
 	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
 
@@ -18,7 +19,7 @@ func TestPhong(t *testing.T) {
 		eyeV    *tuple.Tuple
 		normalV *tuple.Tuple
 		light   *light.PointLight
-		shadow  bool
+		shadow  float64
 		want    *color.Color
 	}{
 		{
@@ -79,21 +80,99 @@ func TestPhong(t *testing.T) {
 				tuple.NewPoint(0, 0, 10),
 				color.White,
 			},
-			shadow: true,
+			shadow: 1,
 			want:   color.NewColor(0.1, 0.1, 0.1),
 		},
+		{
+			name:    "Lighting with the surface partially in shadow",
+			eyeV:    tuple.NewVector(0, 0, -1),
+			normalV: tuple.NewVector(0, 0, -1),
+			light: &light.PointLight{
+				Pos:   tuple.NewPoint(0, 0, -10),
+				Color: color.White,
+			},
+			shadow: 0.5,
+			want:   color.NewColor(1, 1, 1),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			result := DefaultPhong.Lighting(tc.light,
 				&ray.Hit{
-					Pos:      tuple.Origin,
-					EyeV:     tc.eyeV,
-					NormalV:  tc.normalV,
-					InShadow: tc.shadow,
+					Pos:     tuple.Origin,
+					EyeV:    tc.eyeV,
+					NormalV: tc.normalV,
+					Shadow:  tc.shadow,
 				})
 			assert.True(t, tc.want.Equal(result))
 			// assert.Equal(t, tc.want, result)
 		})
 	}
 }
+
+func TestPhong_AmbientAt(t *testing.T) {
+	l := &light.PointLight{Pos: tuple.NewPoint(0, 0, -10), Color: color.NewColor(1, 0.5, 0)}
+	h := &ray.Hit{Pos: tuple.Origin, EyeV: tuple.NewVector(0, 0, -1), NormalV: tuple.NewVector(0, 0, -1), Shadow: 1}
+
+	// in full shadow, only the ambient light is left
+	assert.True(t, DefaultPhong.Lighting(l, h).Equal(DefaultPhong.AmbientAt(h, l.Color)))
+	assert.True(t, color.NewColor(0.1, 0.05, 0).Equal(DefaultPhong.AmbientAt(h, l.Color)))
+}
+
+func TestPhong_Transmittance(t *testing.T) {
+	tinted := DefaultPhong.Copy()
+	tinted.Absorption = color.NewColor(0.5, 1, 0.25)
+	tinted.AbsorptionDistance = 2
+
+	testCases := []struct {
+		name     string
+		mat      *PhongMat
+		distance float64
+		want     *color.Color
+	}{
+		{
+			name:     "A material without absorption lets all light through",
+			mat:      DefaultPhong,
+			distance: 100,
+			want:     color.White,
+		},
+		{
+			name:     "Light has the absorption color after the absorption distance",
+			mat:      tinted,
+			distance: 2,
+			want:     color.NewColor(0.5, 1, 0.25),
+		},
+		{
+			name:     "Light fades exponentially with distance",
+			mat:      tinted,
+			distance: 4,
+			want:     color.NewColor(0.25, 1, 0.0625),
+		},
+		{
+			name:     "No light is absorbed over no distance",
+			mat:      tinted,
+			distance: 0,
+			want:     color.White,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, tc.want.Equal(tc.mat.Transmittance(tc.distance)))
+		})
+	}
+}
+
+func TestPhong_ColorAtHit(t *testing.T) {
+	m := DefaultPhong.Copy()
+	m.Pattern = pattern.NewUVPattern(nil, nil, pattern.NewUVChecker(2, 2, color.Black, color.White))
+
+	h := &ray.Hit{Pos: tuple.Origin, U: 0.75, V: 0.25}
+	assert.Equal(t, color.White, m.ColorAtHit(h))
+
+	// patterns without surface coordinates only see the position
+	m.Pattern = pattern.NewSolidPattern(color.Red)
+	assert.Equal(t, color.Red, m.ColorAtHit(h))
+
+	m.Pattern = nil
+	assert.Equal(t, m.Color, m.ColorAtHit(h))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/Henelik/tricaster/pkg/color"
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/pattern"
+	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
 )
 
@@ -11,18 +12,41 @@ var DefaultShadeless = &ShadelessMat{
 	Color: color.White,
 }
 
+// ShadelessMat is a flat color which ignores lights.
+// Its color is emitted, but it doesn't light the objects around it.
 type ShadelessMat struct {
 	Color   *color.Color // used as a fallback if there is no pattern
 	Pattern pattern.Pattern
 }
 
-func (m ShadelessMat) Lighting(light *light.PointLight, pos, eyeV, normalV *tuple.Tuple, inShadow bool) *color.Color {
+// Lighting is always black, since a shadeless surface doesn't reflect any light
+func (m *ShadelessMat) Lighting(light light.Light, h *ray.Hit) *color.Color {
+	return color.Black
+}
+
+func (m *ShadelessMat) EmissionAt(pos *tuple.Tuple) *color.Color {
 	if m.Pattern != nil {
 		return m.Pattern.Process(pos)
 	}
 	return m.Color
 }
 
+// ColorAtHit is like EmissionAt, but lets the pattern use the surface coordinates of the hit
+func (m *ShadelessMat) ColorAtHit(h *ray.Hit) *color.Color {
+	if m.Pattern != nil {
+		return patternAt(m.Pattern, h)
+	}
+	return m.Color
+}
+
+func (m *ShadelessMat) EmissionAtHit(h *ray.Hit) *color.Color {
+	return m.ColorAtHit(h)
+}
+
+func (m *ShadelessMat) IsLight() bool {
+	return false
+}
+
 // CopyWithColor returns a new material with modified color
 func (m *ShadelessMat) CopyWithColor(c *color.Color) *ShadelessMat {
 	mat := *m
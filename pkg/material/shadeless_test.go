@@ -6,6 +6,8 @@ import (
 
 	"github.com/Henelik/tricaster/pkg/color"
 	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/pattern"
+	"github.com/Henelik/tricaster/pkg/ray"
 	"github.com/Henelik/tricaster/pkg/tuple"
 
 	"github.com/stretchr/testify/assert"
@@ -25,8 +27,8 @@ func TestShadeless(t *testing.T) {
 			eyeV:    tuple.NewVector(0, 0, -1),
 			normalV: tuple.NewVector(0, 0, -1),
 			light: &light.PointLight{
-				tuple.NewPoint(0, 0, -10),
-				color.White,
+				Pos:   tuple.NewPoint(0, 0, -10),
+				Color: color.White,
 			},
 			want: color.White,
 		},
@@ -35,8 +37,8 @@ func TestShadeless(t *testing.T) {
 			eyeV:    tuple.NewVector(0, math.Sqrt2/2, -math.Sqrt2/2),
 			normalV: tuple.NewVector(0, 0, -1),
 			light: &light.PointLight{
-				tuple.NewPoint(0, 0, -10),
-				color.White,
+				Pos:   tuple.NewPoint(0, 0, -10),
+				Color: color.White,
 			},
 			want: color.White,
 		},
@@ -45,8 +47,8 @@ func TestShadeless(t *testing.T) {
 			eyeV:    tuple.NewVector(0, 0, -1),
 			normalV: tuple.NewVector(0, 0, -1),
 			light: &light.PointLight{
-				tuple.NewPoint(0, 10, -10),
-				color.White,
+				Pos:   tuple.NewPoint(0, 10, -10),
+				Color: color.White,
 			},
 			want: color.White,
 		},
@@ -55,8 +57,8 @@ func TestShadeless(t *testing.T) {
 			eyeV:    tuple.NewVector(0, -math.Sqrt2/2, -math.Sqrt2/2),
 			normalV: tuple.NewVector(0, 0, -1),
 			light: &light.PointLight{
-				tuple.NewPoint(0, 10, -10),
-				color.White,
+				Pos:   tuple.NewPoint(0, 10, -10),
+				Color: color.White,
 			},
 			want: color.White,
 		},
@@ -65,8 +67,8 @@ func TestShadeless(t *testing.T) {
 			eyeV:    tuple.NewVector(0, 0, -1),
 			normalV: tuple.NewVector(0, 0, -1),
 			light: &light.PointLight{
-				tuple.NewPoint(0, 0, 10),
-				color.White,
+				Pos:   tuple.NewPoint(0, 0, 10),
+				Color: color.White,
 			},
 			want: color.White,
 		},
@@ -75,8 +77,8 @@ func TestShadeless(t *testing.T) {
 			eyeV:    tuple.NewVector(0, 0, -1),
 			normalV: tuple.NewVector(0, 0, -1),
 			light: &light.PointLight{
-				tuple.NewPoint(0, 0, 10),
-				color.White,
+				Pos:   tuple.NewPoint(0, 0, 10),
+				Color: color.White,
 			},
 			shadow: true,
 			want:   color.White,
@@ -84,13 +86,27 @@ func TestShadeless(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := DefaultShadeless.Lighting(
-				tc.light,
-				tuple.Origin,
-				tc.eyeV,
-				tc.normalV,
-				tc.shadow)
-			assert.True(t, tc.want.Equal(result))
+			h := &ray.Hit{
+				Pos:     tuple.Origin,
+				EyeV:    tc.eyeV,
+				NormalV: tc.normalV,
+			}
+			if tc.shadow {
+				h.Shadow = 1
+			}
+
+			// the color is emitted, and no light is reflected
+			assert.True(t, color.Black.Equal(DefaultShadeless.Lighting(tc.light, h)))
+			assert.True(t, tc.want.Equal(DefaultShadeless.EmissionAt(h.Pos)))
 		})
 	}
 }
+
+func TestShadeless_ColorAtHit(t *testing.T) {
+	m := DefaultShadeless.CopyWithColor(color.Red)
+	h := &ray.Hit{Pos: tuple.Origin, U: 0.25, V: 0.25}
+	assert.Equal(t, color.Red, m.ColorAtHit(h))
+
+	m.Pattern = pattern.NewUVPattern(nil, nil, pattern.NewUVChecker(2, 2, color.Black, color.White))
+	assert.Equal(t, color.Black, m.ColorAtHit(h))
+}
@@ -0,0 +1,127 @@
+package noise
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// Perlin is 3D gradient noise, which varies smoothly and randomly through space.
+// Different seeds give unrelated noise.
+type Perlin struct {
+	perm [512]int
+}
+
+func NewPerlin(seed int64) *Perlin {
+	p := &Perlin{}
+
+	for i, v := range rand.New(rand.NewSource(seed)).Perm(256) {
+		p.perm[i] = v
+		p.perm[i+256] = v
+	}
+
+	return p
+}
+
+// Noise returns the noise at a point, from about -1 to 1.
+// It is 0 at every integer point, and features are about one unit across.
+func (p *Perlin) Noise(x, y, z float64) float64 {
+	xf, yf, zf := math.Floor(x), math.Floor(y), math.Floor(z)
+
+	xi := int(xf) & 255
+	yi := int(yf) & 255
+	zi := int(zf) & 255
+
+	x -= xf
+	y -= yf
+	z -= zf
+
+	u, v, w := fade(x), fade(y), fade(z)
+
+	a := p.perm[xi] + yi
+	aa := p.perm[a] + zi
+	ab := p.perm[a+1] + zi
+	b := p.perm[xi+1] + yi
+	ba := p.perm[b] + zi
+	bb := p.perm[b+1] + zi
+
+	return util.Lerp(
+		util.Lerp(
+			util.Lerp(grad(p.perm[aa], x, y, z), grad(p.perm[ba], x-1, y, z), u),
+			util.Lerp(grad(p.perm[ab], x, y-1, z), grad(p.perm[bb], x-1, y-1, z), u),
+			v),
+		util.Lerp(
+			util.Lerp(grad(p.perm[aa+1], x, y, z-1), grad(p.perm[ba+1], x-1, y, z-1), u),
+			util.Lerp(grad(p.perm[ab+1], x, y-1, z-1), grad(p.perm[bb+1], x-1, y-1, z-1), u),
+			v),
+		w)
+}
+
+// FBM sums octaves of noise, each with twice the detail and half the strength of the last.
+// The result is scaled back to about -1 to 1.
+func (p *Perlin) FBM(x, y, z float64, octaves int) float64 {
+	return p.sum(x, y, z, octaves, false)
+}
+
+// Turbulence is like FBM, but sums the absolute value of each octave, for sharp creases.
+// The result is from 0 to about 1.
+func (p *Perlin) Turbulence(x, y, z float64, octaves int) float64 {
+	return p.sum(x, y, z, octaves, true)
+}
+
+func (p *Perlin) sum(x, y, z float64, octaves int, abs bool) float64 {
+	if octaves < 1 {
+		octaves = 1
+	}
+
+	var result, total float64
+
+	amplitude := 1.0
+
+	for i := 0; i < octaves; i++ {
+		n := p.Noise(x, y, z)
+		if abs {
+			n = math.Abs(n)
+		}
+
+		result += n * amplitude
+		total += amplitude
+
+		x, y, z = x*2, y*2, z*2
+		amplitude /= 2
+	}
+
+	return result / total
+}
+
+// fade eases the interpolation between lattice points, so the noise has no visible grid
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// grad returns the dot product of the offset from a lattice point with one of 12 gradient directions
+func grad(hash int, x, y, z float64) float64 {
+	h := hash & 15
+
+	u := y
+	if h < 8 {
+		u = x
+	}
+
+	v := z
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 {
+		v = x
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+
+	return u + v
+}
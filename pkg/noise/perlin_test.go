@@ -0,0 +1,52 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerlin_Noise(t *testing.T) {
+	p := NewPerlin(0)
+
+	t.Run("Noise is zero on the lattice", func(t *testing.T) {
+		assert.Equal(t, 0.0, p.Noise(0, 0, 0))
+		assert.Equal(t, 0.0, p.Noise(3, -7, 12))
+	})
+
+	t.Run("Noise is repeatable", func(t *testing.T) {
+		assert.Equal(t, p.Noise(1.3, 2.7, -0.4), NewPerlin(0).Noise(1.3, 2.7, -0.4))
+	})
+
+	t.Run("Noise depends on the seed", func(t *testing.T) {
+		assert.NotEqual(t, p.Noise(1.3, 2.7, -0.4), NewPerlin(1).Noise(1.3, 2.7, -0.4))
+	})
+
+	t.Run("Noise is continuous", func(t *testing.T) {
+		assert.InDelta(t, p.Noise(1.5, 2.5, 3.5), p.Noise(1.5001, 2.5, 3.5), 0.001)
+	})
+
+	t.Run("Noise stays in range", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			x := float64(i) * 0.137
+			n := p.Noise(x, x*0.71, -x*1.3)
+			assert.True(t, n >= -1 && n <= 1)
+		}
+	})
+}
+
+func TestPerlin_FBM(t *testing.T) {
+	p := NewPerlin(42)
+
+	// a single octave is plain noise
+	assert.Equal(t, p.Noise(0.3, 0.6, 0.9), p.FBM(0.3, 0.6, 0.9, 1))
+
+	for i := 0; i < 1000; i++ {
+		x := float64(i) * 0.173
+		f := p.FBM(x, -x*0.37, x*1.1, 5)
+		assert.True(t, f >= -1 && f <= 1)
+
+		turb := p.Turbulence(x, -x*0.37, x*1.1, 5)
+		assert.True(t, turb >= 0 && turb <= 1)
+	}
+}
@@ -0,0 +1,122 @@
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// ParseMTL reads a Wavefront MTL library and maps each material onto a PhongMat.
+// Statements without a Phong equivalent are ignored, and so is Ka: exporters such as Blender
+// write an ambient color of 1 1 1, which would light every face evenly, so the default ambient is kept.
+func ParseMTL(r io.Reader) (map[string]*material.PhongMat, error) {
+	materials := make(map[string]*material.PhongMat)
+
+	var current *material.PhongMat
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if fields[0] == "newmtl" {
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("mtl line %d: newmtl without a name", lineNum)
+			}
+
+			current = material.DefaultPhong.Copy()
+			materials[strings.Join(fields[1:], " ")] = current
+
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		var err error
+
+		switch fields[0] {
+		case "Kd":
+			current.Color, err = parseColor(fields[1:])
+		case "Ks":
+			var ks *color.Color
+			ks, err = parseColor(fields[1:])
+			if err == nil {
+				current.Specular = maxComponent(ks)
+			}
+		case "Ns":
+			current.Shininess, err = parseFloat(fields[1:])
+		case "Ni":
+			current.IOR, err = parseFloat(fields[1:])
+		case "d":
+			var d float64
+			d, err = parseFloat(fields[1:])
+			current.Transparency = 1 - d
+		case "Tr":
+			current.Transparency, err = parseFloat(fields[1:])
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("mtl line %d: %w", lineNum, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return materials, nil
+}
+
+func parseColor(fields []string) (*color.Color, error) {
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("expected 3 color components, got %d", len(fields))
+	}
+
+	rgb, err := parseFloats(fields[:3])
+	if err != nil {
+		return nil, err
+	}
+
+	return color.NewColor(rgb[0], rgb[1], rgb[2]), nil
+}
+
+func parseFloat(fields []string) (float64, error) {
+	if len(fields) < 1 {
+		return 0, fmt.Errorf("expected a value")
+	}
+
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+func parseFloats(fields []string) ([]float64, error) {
+	result := make([]float64, len(fields))
+
+	for i, field := range fields {
+		f, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = f
+	}
+
+	return result, nil
+}
+
+func maxComponent(c *color.Color) float64 {
+	return util.Max(util.Max(c.R, c.G), c.B)
+}
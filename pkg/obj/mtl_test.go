@@ -0,0 +1,67 @@
+package obj
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/material"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMTL(t *testing.T) {
+	mats, err := ParseMTL(strings.NewReader(`
+Kd 1 1 1
+newmtl shiny
+Ka 0.2 0.3 0.1
+Kd 0.5 0.25 1
+Ks 0.8 0.9 0.7
+Ns 120
+Ni 1.33
+Tr 0.5
+illum 2
+
+newmtl plain
+`))
+
+	assert.NoError(t, err)
+	assert.Len(t, mats, 2)
+
+	shiny := mats["shiny"]
+	assert.Equal(t, material.DefaultPhong.Ambient, shiny.Ambient)
+	assert.Equal(t, color.NewColor(0.5, 0.25, 1), shiny.Color)
+	assert.InDelta(t, 0.9, shiny.Specular, 1e-9)
+	assert.Equal(t, 120.0, shiny.Shininess)
+	assert.Equal(t, 1.33, shiny.IOR)
+	assert.Equal(t, 0.5, shiny.Transparency)
+
+	assert.Equal(t, material.DefaultPhong, mats["plain"])
+}
+
+func TestParseMTL_BlenderAmbient(t *testing.T) {
+	mats, err := ParseMTL(strings.NewReader(`
+newmtl Material
+Ns 250.000000
+Ka 1.000000 1.000000 1.000000
+Kd 0.800000 0.800000 0.800000
+Ks 0.500000 0.500000 0.500000
+Ke 0.000000 0.000000 0.000000
+Ni 1.450000
+d 1.000000
+illum 2
+`))
+
+	assert.NoError(t, err)
+
+	// a white ambient color doesn't make the mesh fully lit
+	mat := mats["Material"]
+	assert.Equal(t, material.DefaultPhong.Ambient, mat.Ambient)
+	assert.Equal(t, color.NewColor(0.8, 0.8, 0.8), mat.Color)
+}
+
+func TestParseMTL_Error(t *testing.T) {
+	_, err := ParseMTL(strings.NewReader("newmtl bad\nKd 1 oops 1\n"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,321 @@
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Henelik/tricaster/pkg/geometry"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// Parser builds triangles from the statements of a Wavefront OBJ file
+type Parser struct {
+	// Vertices, Normals and TexCoords hold the vertex data in file order
+	Vertices  []*tuple.Tuple
+	Normals   []*tuple.Tuple
+	TexCoords [][2]float64
+	// DefaultGroup holds the faces which appear before any g or o statement
+	DefaultGroup *geometry.BasicGroup
+	// Groups holds the named groups, in the order they first appear
+	Groups     map[string]*geometry.BasicGroup
+	groupOrder []string
+	// Materials holds every material loaded through mtllib
+	Materials map[string]*material.PhongMat
+	// Ignored counts the lines which were not understood
+	Ignored int
+
+	// dir is the directory mtllib paths are resolved against
+	dir   string
+	group *geometry.BasicGroup
+	mat   material.Material
+}
+
+// NewParser creates a parser which gives defaultMat to faces without a usemtl statement.
+// If defaultMat is nil, the primitives' own default is used.
+func NewParser(dir string, defaultMat material.Material) *Parser {
+	p := &Parser{
+		DefaultGroup: geometry.NewBasicGroup(nil, nil),
+		Groups:       make(map[string]*geometry.BasicGroup),
+		Materials:    make(map[string]*material.PhongMat),
+		dir:          dir,
+		mat:          defaultMat,
+	}
+
+	p.group = p.DefaultGroup
+
+	return p
+}
+
+// ParseFile reads an OBJ file and returns its faces as a group
+func ParseFile(path string, defaultMat material.Material) (*geometry.BasicGroup, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	p := NewParser(filepath.Dir(path), defaultMat)
+
+	err = p.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return p.ToGroup(), nil
+}
+
+// Parse reads OBJ statements from r
+func (p *Parser) Parse(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		err := p.parseStatement(fields)
+		if err != nil {
+			return fmt.Errorf("obj line %d: %w", lineNum, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (p *Parser) parseStatement(fields []string) error {
+	switch fields[0] {
+	case "v":
+		if len(fields) < 4 {
+			return fmt.Errorf("vertex needs 3 coordinates")
+		}
+
+		xyz, err := parseFloats(fields[1:4])
+		if err != nil {
+			return err
+		}
+
+		p.Vertices = append(p.Vertices, tuple.NewPoint(xyz[0], xyz[1], xyz[2]))
+	case "vn":
+		if len(fields) < 4 {
+			return fmt.Errorf("normal needs 3 coordinates")
+		}
+
+		xyz, err := parseFloats(fields[1:4])
+		if err != nil {
+			return err
+		}
+
+		p.Normals = append(p.Normals, tuple.NewVector(xyz[0], xyz[1], xyz[2]).Norm())
+	case "vt":
+		if len(fields) < 2 {
+			return fmt.Errorf("texture coordinate needs at least 1 value")
+		}
+
+		if len(fields) > 3 {
+			fields = fields[:3]
+		}
+
+		uv, err := parseFloats(fields[1:])
+		if err != nil {
+			return err
+		}
+
+		var texCoord [2]float64
+		copy(texCoord[:], uv)
+
+		p.TexCoords = append(p.TexCoords, texCoord)
+	case "f":
+		return p.parseFace(fields[1:])
+	case "g", "o":
+		name := strings.Join(fields[1:], " ")
+		if name == "" {
+			p.group = p.DefaultGroup
+			return nil
+		}
+
+		group, ok := p.Groups[name]
+		if !ok {
+			group = geometry.NewBasicGroup(nil, nil)
+			p.Groups[name] = group
+			p.groupOrder = append(p.groupOrder, name)
+		}
+
+		p.group = group
+	case "usemtl":
+		name := strings.Join(fields[1:], " ")
+
+		mat, ok := p.Materials[name]
+		if !ok {
+			return fmt.Errorf("unknown material %q", name)
+		}
+
+		p.mat = mat
+	case "mtllib":
+		for _, name := range fields[1:] {
+			err := p.loadMTL(name)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		p.Ignored++
+	}
+
+	return nil
+}
+
+func (p *Parser) loadMTL(name string) error {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.dir, path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	materials, err := ParseMTL(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for name, mat := range materials {
+		p.Materials[name] = mat
+	}
+
+	return nil
+}
+
+// faceVertex holds the indices of a single face corner, with -1 for absent values
+type faceVertex struct {
+	v  int
+	vt int
+	vn int
+}
+
+// parseFace triangulates a polygon as a fan around its first vertex
+func (p *Parser) parseFace(fields []string) error {
+	if len(fields) < 3 {
+		return fmt.Errorf("face needs at least 3 vertices")
+	}
+
+	verts := make([]faceVertex, len(fields))
+
+	for i, field := range fields {
+		fv, err := p.parseFaceVertex(field)
+		if err != nil {
+			return err
+		}
+
+		verts[i] = fv
+	}
+
+	for i := 1; i < len(verts)-1; i++ {
+		a, b, c := verts[0], verts[i], verts[i+1]
+
+		p1, p2, p3 := p.Vertices[a.v], p.Vertices[b.v], p.Vertices[c.v]
+
+		// skip degenerate triangles, which have no defined normal
+		if p2.Sub(p1).CrossProd(p3.Sub(p1)).Mag() == 0 {
+			p.Ignored++
+			continue
+		}
+
+		hasTexCoords := a.vt >= 0 && b.vt >= 0 && c.vt >= 0
+
+		if a.vn >= 0 && b.vn >= 0 && c.vn >= 0 {
+			tri := geometry.NewSmoothTriangle(
+				p1, p2, p3,
+				p.Normals[a.vn], p.Normals[b.vn], p.Normals[c.vn],
+				nil, p.mat)
+			if hasTexCoords {
+				tri.SetTexCoords(p.TexCoords[a.vt], p.TexCoords[b.vt], p.TexCoords[c.vt])
+			}
+			p.group.AddChild(tri)
+		} else {
+			tri := geometry.NewTriangle(p1, p2, p3, nil, p.mat)
+			if hasTexCoords {
+				tri.SetTexCoords(p.TexCoords[a.vt], p.TexCoords[b.vt], p.TexCoords[c.vt])
+			}
+			p.group.AddChild(tri)
+		}
+	}
+
+	return nil
+}
+
+// parseFaceVertex reads a face corner in the form v, v/vt, v//vn or v/vt/vn
+func (p *Parser) parseFaceVertex(field string) (faceVertex, error) {
+	fv := faceVertex{-1, -1, -1}
+
+	parts := strings.Split(field, "/")
+	if len(parts) > 3 {
+		return fv, fmt.Errorf("malformed face vertex %q", field)
+	}
+
+	var err error
+
+	fv.v, err = resolveIndex(parts[0], len(p.Vertices))
+	if err != nil {
+		return fv, err
+	}
+
+	if len(parts) > 1 && parts[1] != "" {
+		fv.vt, err = resolveIndex(parts[1], len(p.TexCoords))
+		if err != nil {
+			return fv, err
+		}
+	}
+
+	if len(parts) > 2 && parts[2] != "" {
+		fv.vn, err = resolveIndex(parts[2], len(p.Normals))
+		if err != nil {
+			return fv, err
+		}
+	}
+
+	return fv, nil
+}
+
+// resolveIndex converts a 1-based or negative relative OBJ index into a slice index
+func resolveIndex(s string, count int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return -1, err
+	}
+
+	if i < 0 {
+		i += count
+	} else {
+		i--
+	}
+
+	if i < 0 || i >= count {
+		return -1, fmt.Errorf("index %s out of range", s)
+	}
+
+	return i, nil
+}
+
+// ToGroup returns a group holding the default group's faces and every named group
+func (p *Parser) ToGroup() *geometry.BasicGroup {
+	result := geometry.NewBasicGroup(nil, nil, p.DefaultGroup.Children...)
+
+	for _, name := range p.groupOrder {
+		result.AddChild(p.Groups[name])
+	}
+
+	return result
+}
@@ -0,0 +1,209 @@
+package obj
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/geometry"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_IgnoresUnrecognizedLines(t *testing.T) {
+	p := NewParser("", nil)
+
+	err := p.Parse(strings.NewReader(`There was a young lady named Bright
+who traveled much faster than light.
+She set out one day
+in a relative way,
+and came back the previous night.`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, p.Ignored)
+}
+
+func TestParser_Vertices(t *testing.T) {
+	p := NewParser("", nil)
+
+	err := p.Parse(strings.NewReader(`
+v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0
+v 1 1 0
+vn 0 0 2
+vt 0.5 0.25
+vt 0.75`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*tuple.Tuple{
+		tuple.NewPoint(-1, 1, 0),
+		tuple.NewPoint(-1, 0.5, 0),
+		tuple.NewPoint(1, 0, 0),
+		tuple.NewPoint(1, 1, 0),
+	}, p.Vertices)
+	assert.Equal(t, []*tuple.Tuple{tuple.NewVector(0, 0, 1)}, p.Normals)
+	assert.Equal(t, [][2]float64{{0.5, 0.25}, {0.75, 0}}, p.TexCoords)
+}
+
+func TestParser_TriangulatesPolygons(t *testing.T) {
+	p := NewParser("", nil)
+
+	err := p.Parse(strings.NewReader(`
+v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3 4 5`))
+
+	assert.NoError(t, err)
+	assert.Len(t, p.DefaultGroup.Children, 3)
+
+	t1 := p.DefaultGroup.Children[0].(*geometry.Triangle)
+	t3 := p.DefaultGroup.Children[2].(*geometry.Triangle)
+
+	assert.Equal(t, p.Vertices[0], t1.P1)
+	assert.Equal(t, p.Vertices[1], t1.P2)
+	assert.Equal(t, p.Vertices[2], t1.P3)
+	assert.Equal(t, p.Vertices[0], t3.P1)
+	assert.Equal(t, p.Vertices[3], t3.P2)
+	assert.Equal(t, p.Vertices[4], t3.P3)
+}
+
+func TestParser_FacesWithNormals(t *testing.T) {
+	p := NewParser("", nil)
+
+	err := p.Parse(strings.NewReader(`
+v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+vt 0 0
+
+f 1//3 2//1 3//2
+f 1/1/3 2/1/1 3/1/2
+f 1/1 2/1 3/1`))
+
+	assert.NoError(t, err)
+	assert.Len(t, p.DefaultGroup.Children, 3)
+
+	for _, child := range p.DefaultGroup.Children[:2] {
+		tri := child.(*geometry.SmoothTriangle)
+		assert.Equal(t, p.Normals[2], tri.N1)
+		assert.Equal(t, p.Normals[0], tri.N2)
+		assert.Equal(t, p.Normals[1], tri.N3)
+	}
+
+	assert.IsType(t, &geometry.Triangle{}, p.DefaultGroup.Children[2])
+}
+
+func TestParser_TexCoords(t *testing.T) {
+	p := NewParser("", nil)
+
+	err := p.Parse(strings.NewReader(`
+v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vt 0.5 1
+vt 0 0
+vt 1 0
+
+f 1/1 2/2 3/3
+f 1 2 3`))
+
+	assert.NoError(t, err)
+	assert.Len(t, p.DefaultGroup.Children, 2)
+
+	tri := p.DefaultGroup.Children[0].(*geometry.Triangle)
+	u, v := tri.UVAt(tuple.NewPoint(0, 0.5, 0))
+	assert.InDelta(t, 0.5, u, 1e-9)
+	assert.InDelta(t, 0.5, v, 1e-9)
+
+	// without texture coordinates the hit's barycentric coordinates are used
+	untextured := p.DefaultGroup.Children[1].(*geometry.Triangle)
+	assert.NotPanics(t, func() { untextured.UVAt(tuple.NewPoint(0, 0.5, 0)) })
+}
+
+func TestParser_Groups(t *testing.T) {
+	p := NewParser("", nil)
+
+	err := p.Parse(strings.NewReader(`
+v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4
+g FirstGroup
+f 1 2 4`))
+
+	assert.NoError(t, err)
+	assert.Len(t, p.DefaultGroup.Children, 0)
+	assert.Len(t, p.Groups["FirstGroup"].Children, 2)
+	assert.Len(t, p.Groups["SecondGroup"].Children, 1)
+
+	g := p.ToGroup()
+	assert.Equal(t, []geometry.Intersecter{p.Groups["FirstGroup"], p.Groups["SecondGroup"]}, g.Children)
+}
+
+func TestParser_Errors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "vertex index out of range",
+			input: "v 0 0 0\nv 1 0 0\nf 1 2 3",
+		},
+		{
+			name:  "face with too few vertices",
+			input: "v 0 0 0\nv 1 0 0\nf 1 2",
+		},
+		{
+			name:  "unknown material",
+			input: "usemtl missing",
+		},
+		{
+			name:  "malformed vertex",
+			input: "v 0 zero 0",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Error(t, NewParser("", nil).Parse(strings.NewReader(tc.input)))
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	g, err := ParseFile("testdata/quads.obj", nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, g.Children, 2)
+
+	bottom := g.Children[0].(*geometry.BasicGroup).Children
+	top := g.Children[1].(*geometry.BasicGroup).Children
+
+	assert.Len(t, bottom, 2)
+	assert.Len(t, top, 2)
+
+	red := bottom[0].(*geometry.Triangle).Mat.(*material.PhongMat)
+	assert.Equal(t, color.Red, red.Color)
+	assert.Equal(t, 1.5, red.IOR)
+	assert.Equal(t, 0.75, red.Transparency)
+
+	blue := top[1].(*geometry.Triangle).Mat.(*material.PhongMat)
+	assert.Equal(t, color.Blue, blue.Color)
+}
@@ -35,6 +35,16 @@ func (p *CheckerPattern2D) GetMatrix() *matrix.Matrix {
 }
 
 func (p *CheckerPattern2D) Process(pos *tuple.Tuple) *color.Color {
+	return p.patternAt(pos).Process(pos)
+}
+
+// ProcessSurface passes the surface coordinates on to the sub-pattern at a point
+func (p *CheckerPattern2D) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	return processSurface(p.patternAt(pos), pos, u, v)
+}
+
+// patternAt returns the sub-pattern at a point
+func (p *CheckerPattern2D) patternAt(pos *tuple.Tuple) Pattern {
 	tpos := p.im.MultTuple(pos)
-	return p.Patterns[util.AbsInt(int(tpos.X)+int(tpos.Y))%2].Process(pos)
+	return p.Patterns[util.AbsInt(int(tpos.X)+int(tpos.Y))%2]
 }
@@ -39,6 +39,16 @@ func (p *GradientPattern) GetMatrix() *matrix.Matrix {
 }
 
 func (p *GradientPattern) Process(pos *tuple.Tuple) *color.Color {
+	return p.Pattern1.Process(pos).Lerp(p.Pattern2.Process(pos), p.blendAt(pos))
+}
+
+// ProcessSurface passes the surface coordinates on to both sub-patterns
+func (p *GradientPattern) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	return processSurface(p.Pattern1, pos, u, v).Lerp(processSurface(p.Pattern2, pos, u, v), p.blendAt(pos))
+}
+
+// blendAt returns how far a point is from the first sub-pattern to the second
+func (p *GradientPattern) blendAt(pos *tuple.Tuple) float64 {
 	tpos := p.im.MultTuple(pos)
-	return p.Pattern1.Process(pos).Lerp(p.Pattern2.Process(pos), tpos.X-math.Floor(tpos.X))
+	return tpos.X - math.Floor(tpos.X)
 }
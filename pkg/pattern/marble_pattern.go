@@ -0,0 +1,68 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// MarblePattern blends between two patterns in veins along the X axis, one unit apart,
+// which turbulence twists by up to Amount units
+type MarblePattern struct {
+	m        *matrix.Matrix
+	im       *matrix.Matrix
+	Pattern1 Pattern
+	Pattern2 Pattern
+	Noise    *noise.Perlin
+	Scale    float64
+	Amount   float64
+	Octaves  int
+}
+
+func NewMarblePattern(m *matrix.Matrix, c1, c2 Pattern, n *noise.Perlin, scale, amount float64, octaves int) *MarblePattern {
+	result := &MarblePattern{
+		m:        matrix.Identity,
+		im:       matrix.Identity,
+		Pattern1: c1,
+		Pattern2: c2,
+		Noise:    n,
+		Scale:    scale,
+		Amount:   amount,
+		Octaves:  octaves,
+	}
+	if m != nil {
+		result.m = m
+		result.im = m.Inverse()
+	}
+	return result
+}
+
+func (p *MarblePattern) SetMatrix(m *matrix.Matrix) {
+	p.m = m
+	p.im = m.Inverse()
+}
+
+func (p *MarblePattern) GetMatrix() *matrix.Matrix {
+	return p.m
+}
+
+func (p *MarblePattern) Process(pos *tuple.Tuple) *color.Color {
+	return p.Pattern1.Process(pos).Lerp(p.Pattern2.Process(pos), p.blendAt(pos))
+}
+
+// ProcessSurface passes the surface coordinates on to both sub-patterns
+func (p *MarblePattern) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	return processSurface(p.Pattern1, pos, u, v).Lerp(processSurface(p.Pattern2, pos, u, v), p.blendAt(pos))
+}
+
+// blendAt returns how far a point is from the first sub-pattern to the second
+func (p *MarblePattern) blendAt(pos *tuple.Tuple) float64 {
+	tpos := p.im.MultTuple(pos)
+
+	turbulence := p.Noise.Turbulence(tpos.X/p.Scale, tpos.Y/p.Scale, tpos.Z/p.Scale, p.Octaves)
+
+	return 0.5 + 0.5*math.Sin((tpos.X+p.Amount*turbulence)*math.Pi)
+}
@@ -0,0 +1,51 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarblePattern_Process(t *testing.T) {
+	p := NewMarblePattern(
+		nil,
+		NewSolidPattern(color.White),
+		NewSolidPattern(color.Black),
+		noise.NewPerlin(0),
+		1, 0, 4)
+	testCases := []struct {
+		name string
+		pos  *tuple.Tuple
+		want *color.Color
+	}{
+		{
+			name: "vein center",
+			pos:  tuple.NewPoint(0.5, 0, 0),
+			want: color.Black,
+		},
+		{
+			name: "between veins",
+			pos:  tuple.NewPoint(-0.5, 3, 2),
+			want: color.White,
+		},
+		{
+			name: "vein edge",
+			pos:  tuple.NewPoint(0, 0, 0),
+			want: color.NewColor(0.5, 0.5, 0.5),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, tc.want.Equal(p.Process(tc.pos)))
+		})
+	}
+
+	t.Run("Turbulence moves the veins", func(t *testing.T) {
+		p.Amount = 2
+		assert.False(t, color.Black.Equal(p.Process(tuple.NewPoint(0.5, 0.3, 0.2))))
+	})
+}
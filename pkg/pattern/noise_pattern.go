@@ -0,0 +1,61 @@
+package pattern
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// NoisePattern blends between two patterns with fractal noise, which has features about Scale units across
+type NoisePattern struct {
+	m        *matrix.Matrix
+	im       *matrix.Matrix
+	Pattern1 Pattern
+	Pattern2 Pattern
+	Noise    *noise.Perlin
+	Scale    float64
+	Octaves  int
+}
+
+func NewNoisePattern(m *matrix.Matrix, c1, c2 Pattern, n *noise.Perlin, scale float64, octaves int) *NoisePattern {
+	result := &NoisePattern{
+		m:        matrix.Identity,
+		im:       matrix.Identity,
+		Pattern1: c1,
+		Pattern2: c2,
+		Noise:    n,
+		Scale:    scale,
+		Octaves:  octaves,
+	}
+	if m != nil {
+		result.m = m
+		result.im = m.Inverse()
+	}
+	return result
+}
+
+func (p *NoisePattern) SetMatrix(m *matrix.Matrix) {
+	p.m = m
+	p.im = m.Inverse()
+}
+
+func (p *NoisePattern) GetMatrix() *matrix.Matrix {
+	return p.m
+}
+
+func (p *NoisePattern) Process(pos *tuple.Tuple) *color.Color {
+	return p.Pattern1.Process(pos).Lerp(p.Pattern2.Process(pos), p.blendAt(pos))
+}
+
+// ProcessSurface passes the surface coordinates on to both sub-patterns
+func (p *NoisePattern) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	return processSurface(p.Pattern1, pos, u, v).Lerp(processSurface(p.Pattern2, pos, u, v), p.blendAt(pos))
+}
+
+// blendAt returns how far a point is from the first sub-pattern to the second
+func (p *NoisePattern) blendAt(pos *tuple.Tuple) float64 {
+	tpos := p.im.MultTuple(pos)
+
+	return 0.5 + 0.5*fbmAt(p.Noise, tpos, p.Scale, p.Octaves, 0)
+}
@@ -0,0 +1,33 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoisePattern_Process(t *testing.T) {
+	p := NewNoisePattern(
+		nil,
+		NewSolidPattern(color.Black),
+		NewSolidPattern(color.White),
+		noise.NewPerlin(0),
+		1, 4)
+
+	t.Run("Noise is halfway on the lattice", func(t *testing.T) {
+		assert.True(t, color.NewColor(0.5, 0.5, 0.5).Equal(p.Process(tuple.NewPoint(2, -1, 3))))
+	})
+
+	t.Run("Noise varies between the patterns", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			c := p.Process(tuple.NewPoint(float64(i)*0.31, float64(i)*0.17, 0.5))
+			assert.True(t, c.R >= 0 && c.R <= 1)
+		}
+
+		assert.NotEqual(t, p.Process(tuple.NewPoint(0.3, 0.3, 0.3)), p.Process(tuple.NewPoint(0.6, 0.3, 0.3)))
+	})
+}
@@ -0,0 +1,74 @@
+package pattern
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// PerturbPattern jitters the points its sub-pattern is looked up at, so straight edges become wavy.
+// Amount is the farthest a point can move, and Scale is the size of the noise features.
+type PerturbPattern struct {
+	m       *matrix.Matrix
+	im      *matrix.Matrix
+	Pattern Pattern
+	Noise   *noise.Perlin
+	Scale   float64
+	Amount  float64
+	Octaves int
+}
+
+func NewPerturbPattern(m *matrix.Matrix, p Pattern, n *noise.Perlin, scale, amount float64, octaves int) *PerturbPattern {
+	result := &PerturbPattern{
+		m:       matrix.Identity,
+		im:      matrix.Identity,
+		Pattern: p,
+		Noise:   n,
+		Scale:   scale,
+		Amount:  amount,
+		Octaves: octaves,
+	}
+	if m != nil {
+		result.m = m
+		result.im = m.Inverse()
+	}
+	return result
+}
+
+func (p *PerturbPattern) SetMatrix(m *matrix.Matrix) {
+	p.m = m
+	p.im = m.Inverse()
+}
+
+func (p *PerturbPattern) GetMatrix() *matrix.Matrix {
+	return p.m
+}
+
+func (p *PerturbPattern) Process(pos *tuple.Tuple) *color.Color {
+	return p.Pattern.Process(p.perturb(pos))
+}
+
+// ProcessSurface passes the surface coordinates on to the sub-pattern unchanged, since only points are jittered
+func (p *PerturbPattern) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	return processSurface(p.Pattern, p.perturb(pos), u, v)
+}
+
+// perturb returns the point the sub-pattern is looked up at instead of pos
+func (p *PerturbPattern) perturb(pos *tuple.Tuple) *tuple.Tuple {
+	tpos := p.im.MultTuple(pos)
+
+	// each axis reads a distant part of the noise, so they move independently
+	offset := tuple.NewVector(
+		fbmAt(p.Noise, tpos, p.Scale, p.Octaves, 0),
+		fbmAt(p.Noise, tpos, p.Scale, p.Octaves, 31.7),
+		fbmAt(p.Noise, tpos, p.Scale, p.Octaves, 67.3))
+
+	return pos.Add(offset.Mult(p.Amount))
+}
+
+// fbmAt returns fractal noise at a point in pattern space, with features about scale units across.
+// The shift moves the lookup point, to read unrelated noise from the same generator.
+func fbmAt(n *noise.Perlin, p *tuple.Tuple, scale float64, octaves int, shift float64) float64 {
+	return n.FBM(p.X/scale+shift, p.Y/scale+shift, p.Z/scale+shift, octaves)
+}
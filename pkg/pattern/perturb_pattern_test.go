@@ -0,0 +1,41 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerturbPattern_Process(t *testing.T) {
+	stripes := NewStripePattern(
+		nil,
+		NewSolidPattern(color.White),
+		NewSolidPattern(color.Black))
+
+	t.Run("No perturbation matches the sub-pattern", func(t *testing.T) {
+		p := NewPerturbPattern(nil, stripes, noise.NewPerlin(0), 1, 0, 4)
+
+		for i := 0; i < 100; i++ {
+			pos := tuple.NewPoint(float64(i)*0.13, float64(i)*0.07, 0)
+			assert.Equal(t, stripes.Process(pos), p.Process(pos))
+		}
+	})
+
+	t.Run("Perturbation moves the stripe edges", func(t *testing.T) {
+		p := NewPerturbPattern(nil, stripes, noise.NewPerlin(0), 1, 0.5, 4)
+
+		changed := 0
+		for i := 0; i < 100; i++ {
+			pos := tuple.NewPoint(float64(i)*0.13, float64(i)*0.07, 0)
+			if stripes.Process(pos) != p.Process(pos) {
+				changed++
+			}
+		}
+
+		assert.Greater(t, changed, 0)
+	})
+}
@@ -38,6 +38,16 @@ func (p *SphereRingPattern) GetMatrix() *matrix.Matrix {
 }
 
 func (p *SphereRingPattern) Process(pos *tuple.Tuple) *color.Color {
+	return p.patternAt(pos).Process(pos)
+}
+
+// ProcessSurface passes the surface coordinates on to the sub-pattern at a point
+func (p *SphereRingPattern) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	return processSurface(p.patternAt(pos), pos, u, v)
+}
+
+// patternAt returns the sub-pattern at a point
+func (p *SphereRingPattern) patternAt(pos *tuple.Tuple) Pattern {
 	tpos := p.im.MultTuple(pos)
-	return p.Patterns[util.AbsInt(int(math.Sqrt(tpos.X*tpos.X+tpos.Y*tpos.Y+tpos.Z*tpos.Z)))%len(p.Patterns)].Process(pos)
+	return p.Patterns[util.AbsInt(int(math.Sqrt(tpos.X*tpos.X+tpos.Y*tpos.Y+tpos.Z*tpos.Z)))%len(p.Patterns)]
 }
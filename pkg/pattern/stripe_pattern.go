@@ -37,7 +37,17 @@ func (p *StripePattern) GetMatrix() *matrix.Matrix {
 }
 
 func (p *StripePattern) Process(pos *tuple.Tuple) *color.Color {
+	return p.patternAt(pos).Process(pos)
+}
+
+// ProcessSurface passes the surface coordinates on to the sub-pattern at a point
+func (p *StripePattern) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	return processSurface(p.patternAt(pos), pos, u, v)
+}
+
+// patternAt returns the sub-pattern at a point
+func (p *StripePattern) patternAt(pos *tuple.Tuple) Pattern {
 	tpos := p.im.MultTuple(pos)
 
-	return p.Patterns[util.AbsInt(int(tpos.X)%len(p.Patterns))].Process(pos)
+	return p.Patterns[util.AbsInt(int(tpos.X)%len(p.Patterns))]
 }
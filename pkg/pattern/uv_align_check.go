@@ -0,0 +1,39 @@
+package pattern
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+)
+
+// UVAlignCheck marks each corner of a texture with a different color, to show how a mapping is oriented
+type UVAlignCheck struct {
+	Main        *color.Color
+	TopLeft     *color.Color
+	TopRight    *color.Color
+	BottomLeft  *color.Color
+	BottomRight *color.Color
+}
+
+func NewUVAlignCheck(main, topLeft, topRight, bottomLeft, bottomRight *color.Color) *UVAlignCheck {
+	return &UVAlignCheck{
+		Main:        main,
+		TopLeft:     topLeft,
+		TopRight:    topRight,
+		BottomLeft:  bottomLeft,
+		BottomRight: bottomRight,
+	}
+}
+
+func (c *UVAlignCheck) UVColorAt(u, v float64) *color.Color {
+	switch {
+	case v > 0.8 && u < 0.2:
+		return c.TopLeft
+	case v > 0.8 && u > 0.8:
+		return c.TopRight
+	case v < 0.2 && u < 0.2:
+		return c.BottomLeft
+	case v < 0.2 && u > 0.8:
+		return c.BottomRight
+	default:
+		return c.Main
+	}
+}
@@ -0,0 +1,36 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUVAlignCheck_UVColorAt(t *testing.T) {
+	main := color.White
+	ul := color.NewColor(1, 0, 0)
+	ur := color.NewColor(1, 1, 0)
+	bl := color.NewColor(0, 1, 0)
+	br := color.NewColor(0, 1, 1)
+
+	c := NewUVAlignCheck(main, ul, ur, bl, br)
+	testCases := []struct {
+		name string
+		u    float64
+		v    float64
+		want *color.Color
+	}{
+		{name: "middle", u: 0.5, v: 0.5, want: main},
+		{name: "top left", u: 0.1, v: 0.9, want: ul},
+		{name: "top right", u: 0.9, v: 0.9, want: ur},
+		{name: "bottom left", u: 0.1, v: 0.1, want: bl},
+		{name: "bottom right", u: 0.9, v: 0.1, want: br},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, c.UVColorAt(tc.u, tc.v))
+		})
+	}
+}
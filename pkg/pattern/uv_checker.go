@@ -0,0 +1,31 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+)
+
+// UVChecker is a 2D checkerboard with Width by Height squares
+type UVChecker struct {
+	Width  float64
+	Height float64
+	A      *color.Color
+	B      *color.Color
+}
+
+func NewUVChecker(width, height float64, a, b *color.Color) *UVChecker {
+	return &UVChecker{
+		Width:  width,
+		Height: height,
+		A:      a,
+		B:      b,
+	}
+}
+
+func (c *UVChecker) UVColorAt(u, v float64) *color.Color {
+	if int(math.Floor(u*c.Width)+math.Floor(v*c.Height))%2 == 0 {
+		return c.A
+	}
+	return c.B
+}
@@ -0,0 +1,30 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUVChecker_UVColorAt(t *testing.T) {
+	c := NewUVChecker(2, 2, color.Black, color.White)
+	testCases := []struct {
+		name string
+		u    float64
+		v    float64
+		want *color.Color
+	}{
+		{name: "0, 0", u: 0, v: 0, want: color.Black},
+		{name: "0.5, 0", u: 0.5, v: 0, want: color.White},
+		{name: "0, 0.5", u: 0, v: 0.5, want: color.White},
+		{name: "0.5, 0.5", u: 0.5, v: 0.5, want: color.Black},
+		{name: "1, 1", u: 1, v: 1, want: color.Black},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, c.UVColorAt(tc.u, tc.v))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/canvas"
+	"github.com/Henelik/tricaster/pkg/color"
+)
+
+// ImageFilter decides how an image is sampled between the centers of its pixels
+type ImageFilter int
+
+const (
+	// FilterBilinear blends the four nearest pixels
+	FilterBilinear ImageFilter = iota
+	// FilterNearest uses the closest pixel, for a blocky look
+	FilterNearest
+)
+
+// ImageWrap decides what an image shows outside of the 0 to 1 range
+type ImageWrap int
+
+const (
+	// WrapRepeat tiles the image
+	WrapRepeat ImageWrap = iota
+	// WrapClamp stretches the edge pixels outward
+	WrapClamp
+)
+
+// UVImage is a 2D texture from an image, with v=0 at the bottom of the image
+type UVImage struct {
+	Canvas *canvas.Canvas
+	Filter ImageFilter
+	Wrap   ImageWrap
+}
+
+func NewUVImage(c *canvas.Canvas, filter ImageFilter, wrap ImageWrap) *UVImage {
+	return &UVImage{
+		Canvas: c,
+		Filter: filter,
+		Wrap:   wrap,
+	}
+}
+
+func (img *UVImage) UVColorAt(u, v float64) *color.Color {
+	x := u * float64(img.Canvas.W)
+	y := (1 - v) * float64(img.Canvas.H)
+
+	if img.Filter == FilterNearest {
+		return img.pixel(int(math.Floor(x)), int(math.Floor(y)))
+	}
+
+	// pixel centers are halfway between the edges
+	x -= 0.5
+	y -= 0.5
+
+	x0 := int(math.Floor(x))
+	y0 := int(math.Floor(y))
+
+	fx := x - float64(x0)
+	fy := y - float64(y0)
+
+	top := img.pixel(x0, y0).Lerp(img.pixel(x0+1, y0), fx)
+	bottom := img.pixel(x0, y0+1).Lerp(img.pixel(x0+1, y0+1), fx)
+
+	return top.Lerp(bottom, fy)
+}
+
+// pixel returns the pixel at x and y, which are brought into the image with the wrap mode
+func (img *UVImage) pixel(x, y int) *color.Color {
+	return img.Canvas.Get(img.wrap(x, img.Canvas.W), img.wrap(y, img.Canvas.H))
+}
+
+func (img *UVImage) wrap(i, size int) int {
+	if img.Wrap == WrapClamp {
+		if i < 0 {
+			return 0
+		} else if i >= size {
+			return size - 1
+		}
+		return i
+	}
+
+	i %= size
+	if i < 0 {
+		i += size
+	}
+	return i
+}
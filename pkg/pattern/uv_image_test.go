@@ -0,0 +1,89 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/canvas"
+	"github.com/Henelik/tricaster/pkg/color"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUVImage_UVColorAt(t *testing.T) {
+	// a 2x2 image, with red and green on the top row and blue and white on the bottom
+	c := canvas.NewCanvas(2, 2)
+	c.Set(0, 0, color.Red)
+	c.Set(1, 0, color.Green)
+	c.Set(0, 1, color.Blue)
+	c.Set(1, 1, color.White)
+
+	testCases := []struct {
+		name string
+		img  *UVImage
+		u    float64
+		v    float64
+		want *color.Color
+	}{
+		{
+			name: "nearest, top left",
+			img:  NewUVImage(c, FilterNearest, WrapRepeat),
+			u:    0.1,
+			v:    0.9,
+			want: color.Red,
+		},
+		{
+			name: "nearest, bottom right",
+			img:  NewUVImage(c, FilterNearest, WrapRepeat),
+			u:    0.9,
+			v:    0.1,
+			want: color.White,
+		},
+		{
+			name: "nearest, repeated",
+			img:  NewUVImage(c, FilterNearest, WrapRepeat),
+			u:    1.9,
+			v:    -0.1,
+			want: color.Green,
+		},
+		{
+			name: "nearest, clamped",
+			img:  NewUVImage(c, FilterNearest, WrapClamp),
+			u:    1.9,
+			v:    -0.1,
+			want: color.White,
+		},
+		{
+			name: "bilinear, pixel center",
+			img:  NewUVImage(c, FilterBilinear, WrapClamp),
+			u:    0.25,
+			v:    0.75,
+			want: color.Red,
+		},
+		{
+			name: "bilinear, between pixels",
+			img:  NewUVImage(c, FilterBilinear, WrapClamp),
+			u:    0.5,
+			v:    0.75,
+			want: color.NewColor(0.5, 0.5, 0),
+		},
+		{
+			name: "bilinear, clamped edge",
+			img:  NewUVImage(c, FilterBilinear, WrapClamp),
+			u:    0,
+			v:    0.25,
+			want: color.Blue,
+		},
+		{
+			name: "bilinear, repeated edge",
+			img:  NewUVImage(c, FilterBilinear, WrapRepeat),
+			u:    0,
+			v:    0.25,
+			want: color.NewColor(0.5, 0.5, 1),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, tc.want.Equal(tc.img.UVColorAt(tc.u, tc.v)))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+// UVMapping converts a point in pattern space into surface coordinates from 0 to 1,
+// with v increasing upward
+type UVMapping func(p *tuple.Tuple) (float64, float64)
+
+// SphericalMap wraps the surface of a unit sphere around the up axis, with v running from pole to pole
+func SphericalMap(p *tuple.Tuple) (float64, float64) {
+	r := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z)
+	if r == 0 {
+		return 0.5, 0.5
+	}
+
+	return azimuth(p), 1 - math.Acos(util.Clamp(p.Z/r, -1, 1))/math.Pi
+}
+
+// PlanarMap repeats every unit square of the ground plane
+func PlanarMap(p *tuple.Tuple) (float64, float64) {
+	return frac(p.X), frac(p.Y)
+}
+
+// CylindricalMap wraps around the up axis, repeating every unit of height
+func CylindricalMap(p *tuple.Tuple) (float64, float64) {
+	return azimuth(p), frac(p.Z)
+}
+
+// CubicMap maps each face of a cube from -1 to 1 onto the whole texture.
+// Each side face is upright when seen from outside, and the top and bottom faces have +Y upward.
+func CubicMap(p *tuple.Tuple) (float64, float64) {
+	switch CubeFace(p) {
+	case CubeRight:
+		return (1 - p.Y) / 2, (p.Z + 1) / 2
+	case CubeLeft:
+		return (p.Y + 1) / 2, (p.Z + 1) / 2
+	case CubeBack:
+		return (p.X + 1) / 2, (p.Z + 1) / 2
+	case CubeFront:
+		return (1 - p.X) / 2, (p.Z + 1) / 2
+	case CubeTop:
+		return (1 - p.X) / 2, (p.Y + 1) / 2
+	default:
+		return (p.X + 1) / 2, (p.Y + 1) / 2
+	}
+}
+
+// CubeFaceName identifies one of the faces of a cube
+type CubeFaceName int
+
+const (
+	// CubeRight faces +X
+	CubeRight CubeFaceName = iota
+	// CubeLeft faces -X
+	CubeLeft
+	// CubeBack faces +Y
+	CubeBack
+	// CubeFront faces -Y, toward the default forward direction
+	CubeFront
+	// CubeTop faces +Z
+	CubeTop
+	// CubeBottom faces -Z
+	CubeBottom
+)
+
+// CubeFace returns the face of a cube which a point is closest to
+func CubeFace(p *tuple.Tuple) CubeFaceName {
+	x, y, z := math.Abs(p.X), math.Abs(p.Y), math.Abs(p.Z)
+
+	switch {
+	case x >= y && x >= z:
+		if p.X > 0 {
+			return CubeRight
+		}
+		return CubeLeft
+	case y >= z:
+		if p.Y > 0 {
+			return CubeBack
+		}
+		return CubeFront
+	default:
+		if p.Z > 0 {
+			return CubeTop
+		}
+		return CubeBottom
+	}
+}
+
+// azimuth returns the angle of a point around the up axis, from 0 to 1, starting from -X.
+// It runs in the direction which keeps textures from being mirrored when seen from outside.
+func azimuth(p *tuple.Tuple) float64 {
+	return 0.5 - math.Atan2(p.Y, p.X)/(2*math.Pi)
+}
+
+// frac returns the fractional part of n, which is always positive
+func frac(n float64) float64 {
+	return n - math.Floor(n)
+}
@@ -0,0 +1,151 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUVMappings(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping UVMapping
+		pos     *tuple.Tuple
+		wantU   float64
+		wantV   float64
+	}{
+		{
+			name:    "spherical, -X on the equator",
+			mapping: SphericalMap,
+			pos:     tuple.NewPoint(-1, 0, 0),
+			wantU:   0,
+			wantV:   0.5,
+		},
+		{
+			name:    "spherical, +X on the equator",
+			mapping: SphericalMap,
+			pos:     tuple.NewPoint(1, 0, 0),
+			wantU:   0.5,
+			wantV:   0.5,
+		},
+		{
+			name:    "spherical, -Y on the equator",
+			mapping: SphericalMap,
+			pos:     tuple.NewPoint(0, -1, 0),
+			wantU:   0.75,
+			wantV:   0.5,
+		},
+		{
+			name:    "spherical, north pole",
+			mapping: SphericalMap,
+			pos:     tuple.NewPoint(0, 0, 1),
+			wantU:   0.5,
+			wantV:   1,
+		},
+		{
+			name:    "spherical, off the unit sphere",
+			mapping: SphericalMap,
+			pos:     tuple.NewPoint(0, -2, -2),
+			wantU:   0.75,
+			wantV:   0.25,
+		},
+		{
+			name:    "planar",
+			mapping: PlanarMap,
+			pos:     tuple.NewPoint(0.25, 0.5, 7),
+			wantU:   0.25,
+			wantV:   0.5,
+		},
+		{
+			name:    "planar, negative",
+			mapping: PlanarMap,
+			pos:     tuple.NewPoint(-0.25, -1.75, 0),
+			wantU:   0.75,
+			wantV:   0.25,
+		},
+		{
+			name:    "cylindrical",
+			mapping: CylindricalMap,
+			pos:     tuple.NewPoint(0, -1, 1.25),
+			wantU:   0.75,
+			wantV:   0.25,
+		},
+		{
+			name:    "cubic, front",
+			mapping: CubicMap,
+			pos:     tuple.NewPoint(-0.5, -1, 0.5),
+			wantU:   0.75,
+			wantV:   0.75,
+		},
+		{
+			name:    "cubic, back",
+			mapping: CubicMap,
+			pos:     tuple.NewPoint(-0.5, 1, 0.5),
+			wantU:   0.25,
+			wantV:   0.75,
+		},
+		{
+			name:    "cubic, right",
+			mapping: CubicMap,
+			pos:     tuple.NewPoint(1, 0.5, -0.5),
+			wantU:   0.25,
+			wantV:   0.25,
+		},
+		{
+			name:    "cubic, left",
+			mapping: CubicMap,
+			pos:     tuple.NewPoint(-1, 0.5, -0.5),
+			wantU:   0.75,
+			wantV:   0.25,
+		},
+		{
+			name:    "cubic, top",
+			mapping: CubicMap,
+			pos:     tuple.NewPoint(0.5, -0.5, 1),
+			wantU:   0.25,
+			wantV:   0.25,
+		},
+		{
+			name:    "cubic, bottom",
+			mapping: CubicMap,
+			pos:     tuple.NewPoint(0.5, 0.5, -1),
+			wantU:   0.75,
+			wantV:   0.75,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, v := tc.mapping(tc.pos)
+			assert.InDelta(t, tc.wantU, u, 1e-9)
+			assert.InDelta(t, tc.wantV, v, 1e-9)
+		})
+	}
+}
+
+func TestCubeFace(t *testing.T) {
+	testCases := []struct {
+		name string
+		pos  *tuple.Tuple
+		want CubeFaceName
+	}{
+		{name: "right", pos: tuple.NewPoint(1, 0.5, -0.9), want: CubeRight},
+		{name: "left", pos: tuple.NewPoint(-1, -0.5, 0.9), want: CubeLeft},
+		{name: "back", pos: tuple.NewPoint(-0.3, 1, 0.2), want: CubeBack},
+		{name: "front", pos: tuple.NewPoint(0.3, -1, -0.2), want: CubeFront},
+		{name: "top", pos: tuple.NewPoint(0.7, 0.1, 1), want: CubeTop},
+		{name: "bottom", pos: tuple.NewPoint(-0.7, 0.1, -1), want: CubeBottom},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, CubeFace(tc.pos))
+		})
+	}
+}
+
+func TestFrac(t *testing.T) {
+	assert.Equal(t, 0.25, frac(3.25))
+	assert.Equal(t, 0.75, frac(-3.25))
+	assert.Equal(t, 0.0, frac(2))
+}
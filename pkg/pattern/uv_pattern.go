@@ -0,0 +1,75 @@
+package pattern
+
+import (
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// UVTexture is a 2D pattern, which UVPattern wraps onto objects
+type UVTexture interface {
+	// UVColorAt returns the color at surface coordinates from 0 to 1
+	UVColorAt(u, v float64) *color.Color
+}
+
+// SurfacePattern is implemented by patterns which can use the surface coordinates of the object they're on
+type SurfacePattern interface {
+	ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color
+}
+
+// processSurface looks up a sub-pattern, passing on the surface coordinates if it can use them
+func processSurface(p Pattern, pos *tuple.Tuple, u, v float64) *color.Color {
+	if s, ok := p.(SurfacePattern); ok {
+		return s.ProcessSurface(pos, u, v)
+	}
+	return p.Process(pos)
+}
+
+// UVPattern wraps a 2D texture onto objects.
+// The texture is placed with a UV mapping of the pattern space, or with no mapping it follows the
+// surface coordinates of the object, such as the texture coordinates of a mesh.
+type UVPattern struct {
+	m       *matrix.Matrix
+	im      *matrix.Matrix
+	Mapping UVMapping
+	Texture UVTexture
+}
+
+func NewUVPattern(m *matrix.Matrix, mapping UVMapping, texture UVTexture) *UVPattern {
+	result := &UVPattern{
+		m:       matrix.Identity,
+		im:      matrix.Identity,
+		Mapping: mapping,
+		Texture: texture,
+	}
+	if m != nil {
+		result.SetMatrix(m)
+	}
+	return result
+}
+
+func (p *UVPattern) SetMatrix(m *matrix.Matrix) {
+	p.m = m
+	p.im = m.Inverse()
+}
+
+func (p *UVPattern) GetMatrix() *matrix.Matrix {
+	return p.m
+}
+
+// Process uses the pattern's mapping, or a planar mapping if it follows the object's surface
+func (p *UVPattern) Process(pos *tuple.Tuple) *color.Color {
+	mapping := p.Mapping
+	if mapping == nil {
+		mapping = PlanarMap
+	}
+	return p.Texture.UVColorAt(mapping(p.im.MultTuple(pos)))
+}
+
+// ProcessSurface uses the surface coordinates of the object if the pattern has no mapping
+func (p *UVPattern) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	if p.Mapping != nil {
+		return p.Process(pos)
+	}
+	return p.Texture.UVColorAt(u, v)
+}
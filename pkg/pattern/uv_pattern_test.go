@@ -0,0 +1,95 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUVPattern_Process(t *testing.T) {
+	checker := NewUVChecker(2, 2, color.Black, color.White)
+	testCases := []struct {
+		name string
+		p    *UVPattern
+		pos  *tuple.Tuple
+		want *color.Color
+	}{
+		{
+			name: "spherical mapping",
+			p:    NewUVPattern(nil, SphericalMap, checker),
+			pos:  tuple.NewPoint(0, -1, 0.1),
+			want: color.Black,
+		},
+		{
+			name: "spherical mapping, other hemisphere",
+			p:    NewUVPattern(nil, SphericalMap, checker),
+			pos:  tuple.NewPoint(0, -1, -0.1),
+			want: color.White,
+		},
+		{
+			name: "transformed planar mapping",
+			p:    NewUVPattern(matrix.Scaling(2, 2, 2), PlanarMap, checker),
+			pos:  tuple.NewPoint(1.5, 0.5, 0),
+			want: color.White,
+		},
+		{
+			name: "no mapping falls back to planar",
+			p:    NewUVPattern(nil, nil, checker),
+			pos:  tuple.NewPoint(0.75, 0.25, 0),
+			want: color.White,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.p.Process(tc.pos))
+		})
+	}
+}
+
+func TestUVPattern_ProcessSurface(t *testing.T) {
+	checker := NewUVChecker(2, 2, color.Black, color.White)
+
+	t.Run("no mapping uses the surface coordinates", func(t *testing.T) {
+		p := NewUVPattern(nil, nil, checker)
+		assert.Equal(t, color.White, p.ProcessSurface(tuple.Origin, 0.75, 0.25))
+	})
+
+	t.Run("a mapping ignores the surface coordinates", func(t *testing.T) {
+		p := NewUVPattern(nil, PlanarMap, checker)
+		assert.Equal(t, color.Black, p.ProcessSurface(tuple.Origin, 0.75, 0.25))
+	})
+}
+
+func TestSurfacePattern_Nested(t *testing.T) {
+	n := noise.NewPerlin(1)
+	// white at the surface coordinates used below, but black at the origin without them
+	surface := NewUVPattern(nil, nil, NewUVChecker(2, 2, color.Black, color.White))
+
+	testCases := []struct {
+		name string
+		p    Pattern
+	}{
+		{name: "checker 2d", p: NewCheckerPattern2D(nil, surface, surface)},
+		{name: "checker 3d", p: NewCheckerPattern3D(nil, surface, surface)},
+		{name: "cylinder ring", p: NewCylinderRingPattern(nil, surface, surface)},
+		{name: "sphere ring", p: NewSphereRingPattern(nil, surface, surface)},
+		{name: "stripe", p: NewStripePattern(nil, surface, surface)},
+		{name: "gradient", p: NewGradientPattern(nil, surface, surface)},
+		{name: "noise", p: NewNoisePattern(nil, surface, surface, n, 1, 2)},
+		{name: "marble", p: NewMarblePattern(nil, surface, surface, n, 1, 1, 2)},
+		{name: "wood", p: NewWoodPattern(nil, surface, surface, n, 1, 0.1, 2)},
+		{name: "perturb", p: NewPerturbPattern(nil, surface, n, 1, 0.1, 2)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, ok := tc.p.(SurfacePattern)
+			assert.True(t, ok)
+			assert.Equal(t, color.White, s.ProcessSurface(tuple.Origin, 0.75, 0.25))
+		})
+	}
+}
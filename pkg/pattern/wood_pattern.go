@@ -0,0 +1,68 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+)
+
+// WoodPattern draws growth rings around the Z axis, one unit apart, which fade from the first
+// pattern to the second and are warped by noise of up to Amount units
+type WoodPattern struct {
+	m        *matrix.Matrix
+	im       *matrix.Matrix
+	Pattern1 Pattern
+	Pattern2 Pattern
+	Noise    *noise.Perlin
+	Scale    float64
+	Amount   float64
+	Octaves  int
+}
+
+func NewWoodPattern(m *matrix.Matrix, c1, c2 Pattern, n *noise.Perlin, scale, amount float64, octaves int) *WoodPattern {
+	result := &WoodPattern{
+		m:        matrix.Identity,
+		im:       matrix.Identity,
+		Pattern1: c1,
+		Pattern2: c2,
+		Noise:    n,
+		Scale:    scale,
+		Amount:   amount,
+		Octaves:  octaves,
+	}
+	if m != nil {
+		result.m = m
+		result.im = m.Inverse()
+	}
+	return result
+}
+
+func (p *WoodPattern) SetMatrix(m *matrix.Matrix) {
+	p.m = m
+	p.im = m.Inverse()
+}
+
+func (p *WoodPattern) GetMatrix() *matrix.Matrix {
+	return p.m
+}
+
+func (p *WoodPattern) Process(pos *tuple.Tuple) *color.Color {
+	return p.Pattern1.Process(pos).Lerp(p.Pattern2.Process(pos), p.blendAt(pos))
+}
+
+// ProcessSurface passes the surface coordinates on to both sub-patterns
+func (p *WoodPattern) ProcessSurface(pos *tuple.Tuple, u, v float64) *color.Color {
+	return processSurface(p.Pattern1, pos, u, v).Lerp(processSurface(p.Pattern2, pos, u, v), p.blendAt(pos))
+}
+
+// blendAt returns how far a point is from the first sub-pattern to the second
+func (p *WoodPattern) blendAt(pos *tuple.Tuple) float64 {
+	tpos := p.im.MultTuple(pos)
+
+	r := math.Hypot(tpos.X, tpos.Y) + p.Amount*fbmAt(p.Noise, tpos, p.Scale, p.Octaves, 0)
+
+	return r - math.Floor(r)
+}
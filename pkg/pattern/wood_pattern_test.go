@@ -0,0 +1,46 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWoodPattern_Process(t *testing.T) {
+	p := NewWoodPattern(
+		nil,
+		NewSolidPattern(color.White),
+		NewSolidPattern(color.Black),
+		noise.NewPerlin(0),
+		1, 0, 4)
+	testCases := []struct {
+		name string
+		pos  *tuple.Tuple
+		want *color.Color
+	}{
+		{
+			name: "ring start",
+			pos:  tuple.NewPoint(1, 0, 5),
+			want: color.White,
+		},
+		{
+			name: "middle of a ring",
+			pos:  tuple.NewPoint(0, 1.5, 0),
+			want: color.NewColor(0.5, 0.5, 0.5),
+		},
+		{
+			name: "rings are circular",
+			pos:  tuple.NewPoint(-0.6, 0.8, -3),
+			want: color.White,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, tc.want.Equal(p.Process(tc.pos)))
+		})
+	}
+}
@@ -3,16 +3,20 @@ package ray
 import (
 	"math"
 
+	"github.com/Henelik/tricaster/pkg/color"
 	"github.com/Henelik/tricaster/pkg/tuple"
 	"github.com/Henelik/tricaster/pkg/util"
 )
 
-var NilIntersect = &Intersection{math.Inf(1), nil}
+var NilIntersect = &Intersection{T: math.Inf(1)}
 
 // Intersection stores the t value of a ray intersection and a pointer to the intersected primitive
 type Intersection struct {
 	T float64
 	P Primitive
+	// U and V are the barycentric coordinates of a hit on a triangle, which are found along with T
+	U float64
+	V float64
 }
 
 type Hit struct {
@@ -22,12 +26,23 @@ type Hit struct {
 	NormalV  *tuple.Tuple
 	ReflectV *tuple.Tuple
 	Inside   bool
-	InShadow bool
-	OverP    *tuple.Tuple
-	UnderP   *tuple.Tuple
-	N1       float64
-	N2       float64
-	Inters   []Intersection
+	// Shadow is the fraction of the current light which is blocked, from 0 (fully lit) to 1
+	Shadow float64
+	// Tint colors the current light after it passes through transparent objects, or is nil for no tint.
+	// Its brightest channel is 1, since the light's brightness is already reduced by Shadow.
+	Tint   *color.Color
+	OverP  *tuple.Tuple
+	UnderP *tuple.Tuple
+	N1     float64
+	N2     float64
+	// From and To are the containers the ray travels through before and after crossing the surface,
+	// or nil outside of every object
+	From IORHaver
+	To   IORHaver
+	// U and V are the surface coordinates of the hit, for primitives which provide them
+	U      float64
+	V      float64
+	Inters []Intersection
 }
 
 func NewHit(r *Ray, inters []Intersection, index int) *Hit {
@@ -37,7 +52,15 @@ func NewHit(r *Ray, inters []Intersection, index int) *Hit {
 		EyeV:  r.Direction.Neg(),
 	}
 
-	h.NormalV = inters[index].P.NormalAt(h.Pos)
+	if mapper, ok := inters[index].P.(IntersectionMapper); ok {
+		h.NormalV = mapper.NormalAtIntersection(inters[index])
+		h.U, h.V = mapper.UVAtIntersection(inters[index])
+	} else {
+		h.NormalV = inters[index].P.NormalAt(h.Pos)
+		if mapper, ok := inters[index].P.(UVMapper); ok {
+			h.U, h.V = mapper.UVAt(h.Pos)
+		}
+	}
 	h.Inside = h.NormalV.DotProd(h.EyeV) < 0
 	if h.Inside {
 		h.NormalV = h.NormalV.Neg()
@@ -112,7 +135,7 @@ func SortI(inters []Intersection) []Intersection {
 	r := SortI(inters[mid:])
 	i, j := 0, 0
 	for i < len(l) && j < len(r) {
-		if l[i].T < r[j].T {
+		if l[i].T <= r[j].T {
 			result = append(result, l[i])
 			i++
 		} else {
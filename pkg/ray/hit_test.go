@@ -23,7 +23,7 @@ func (prim *TestPrimitive) NormalAt(pos *tuple.Tuple) *tuple.Tuple {
 	return tuple.NewVector(0, 0, -1)
 }
 
-func (prim *TestPrimitive) Shade(light *light.PointLight, h *Hit) *color.Color {
+func (prim *TestPrimitive) Shade(light light.Light, h *Hit) *color.Color {
 	return nil
 }
 
@@ -98,48 +98,62 @@ func TestComputeRefractIOR(t *testing.T) {
 		tuple.NewVector(0, 0, 1))
 
 	xs := []Intersection{
-		{2, a},
-		{2.75, b},
-		{3.25, c},
-		{4.75, b},
-		{5.25, c},
-		{6, a},
+		{T: 2, P: a},
+		{T: 2.75, P: b},
+		{T: 3.25, P: c},
+		{T: 4.75, P: b},
+		{T: 5.25, P: c},
+		{T: 6, P: a},
 	}
 
 	testCases := []struct {
-		index  int
-		wantN1 float64
-		wantN2 float64
+		index    int
+		wantN1   float64
+		wantN2   float64
+		wantFrom IORHaver
+		wantTo   IORHaver
 	}{
 		{
-			index:  0,
-			wantN1: 1.0,
-			wantN2: 1.5,
+			index:    0,
+			wantN1:   1.0,
+			wantN2:   1.5,
+			wantFrom: nil,
+			wantTo:   a,
 		},
 		{
-			index:  1,
-			wantN1: 1.5,
-			wantN2: 2.0,
+			index:    1,
+			wantN1:   1.5,
+			wantN2:   2.0,
+			wantFrom: a,
+			wantTo:   b,
 		},
 		{
-			index:  2,
-			wantN1: 2.0,
-			wantN2: 2.5,
+			index:    2,
+			wantN1:   2.0,
+			wantN2:   2.5,
+			wantFrom: b,
+			wantTo:   c,
 		},
 		{
-			index:  3,
-			wantN1: 2.5,
-			wantN2: 2.5,
+			index:    3,
+			wantN1:   2.5,
+			wantN2:   2.5,
+			wantFrom: c,
+			wantTo:   c,
 		},
 		{
-			index:  4,
-			wantN1: 2.5,
-			wantN2: 1.5,
+			index:    4,
+			wantN1:   2.5,
+			wantN2:   1.5,
+			wantFrom: c,
+			wantTo:   a,
 		},
 		{
-			index:  5,
-			wantN1: 1.5,
-			wantN2: 1.0,
+			index:    5,
+			wantN1:   1.5,
+			wantN2:   1.0,
+			wantFrom: a,
+			wantTo:   nil,
 		},
 	}
 	for _, tc := range testCases {
@@ -148,6 +162,8 @@ func TestComputeRefractIOR(t *testing.T) {
 			h := NewHit(r, xs, tc.index)
 			assert.Equal(t, tc.wantN1, h.N1)
 			assert.Equal(t, tc.wantN2, h.N2)
+			assert.Equal(t, tc.wantFrom, h.From)
+			assert.Equal(t, tc.wantTo, h.To)
 		})
 	}
 }
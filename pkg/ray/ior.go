@@ -8,6 +8,21 @@ type IORHaver interface {
 	GetIOR() float64
 }
 
+// Solid is implemented by surfaces which are part of a larger solid, such as a CSG,
+// so refraction treats the whole solid as a single container
+type Solid interface {
+	Solid() IORHaver
+}
+
+// container returns the object a ray is inside of after crossing the primitive's surface
+func container(p Primitive) IORHaver {
+	if s, ok := p.(Solid); ok {
+		return s.Solid()
+	}
+
+	return p.(IORHaver)
+}
+
 func ComputeIORs(h *Hit) {
 	containers := make([]IORHaver, 0, len(h.Inters))
 	var removed bool
@@ -16,21 +31,24 @@ func ComputeIORs(h *Hit) {
 			if len(containers) == 0 {
 				h.N1 = 1
 			} else {
-				h.N1 = containers[len(containers)-1].(IORHaver).GetIOR()
+				h.From = containers[len(containers)-1]
+				h.N1 = h.From.GetIOR()
 			}
 		}
 
 		// if this object is in the containers, remove it.  Otherwise, append it.
-		containers, removed = removeIORHaverFromArr(inter.P.(IORHaver), containers)
+		c := container(inter.P)
+		containers, removed = removeIORHaverFromArr(c, containers)
 		if !removed {
-			containers = append(containers, inter.P.(IORHaver))
+			containers = append(containers, c)
 		}
 
 		if h.Inters[h.Index].T == inter.T && h.Inters[h.Index].P == inter.P {
 			if len(containers) == 0 {
 				h.N2 = 1
 			} else {
-				h.N2 = containers[len(containers)-1].(IORHaver).GetIOR()
+				h.To = containers[len(containers)-1]
+				h.N2 = h.To.GetIOR()
 			}
 			return
 		}
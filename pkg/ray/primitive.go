@@ -12,5 +12,17 @@ type Primitive interface {
 	Intersects(r *Ray) []Intersection
 	// NormalAt returns the normal vector at a given scene point
 	NormalAt(pos *tuple.Tuple) *tuple.Tuple
-	Shade(light *light.PointLight, h *Hit) *color.Color
+	Shade(light light.Light, h *Hit) *color.Color
+}
+
+// IntersectionMapper is implemented by primitives which find where on their surface a ray hits them as they intersect it,
+// so the normal and surface coordinates of the hit don't need to be solved for again from its position
+type IntersectionMapper interface {
+	NormalAtIntersection(i Intersection) *tuple.Tuple
+	UVAtIntersection(i Intersection) (float64, float64)
+}
+
+// UVMapper is implemented by primitives which can report surface coordinates at a scene point
+type UVMapper interface {
+	UVAt(pos *tuple.Tuple) (float64, float64)
 }
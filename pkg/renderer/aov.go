@@ -0,0 +1,207 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/canvas"
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/geometry"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/ray"
+)
+
+// AOV is an auxiliary pass of a render, which records something about the first hit of each pixel
+// instead of its color. AOVs are taken from a single ray through the center of the pixel.
+type AOV int
+
+const (
+	// AOVDepth is the distance along the camera ray to the first hit, or 0 where nothing is hit
+	AOVDepth AOV = iota
+	// AOVNormal is the world space normal of the first hit, with x, y and z in the red, green and blue channels
+	AOVNormal
+	// AOVAlbedo is the base color of the material at the first hit, before any lighting
+	AOVAlbedo
+	// AOVObjectID gives each object of the scene its own color
+	AOVObjectID
+	// AOVMaterialID gives each material of the scene its own color
+	AOVMaterialID
+)
+
+var aovNames = [...]string{"depth", "normal", "albedo", "object_id", "material_id"}
+
+func (a AOV) String() string {
+	return aovNames[a]
+}
+
+// aovByName returns the AOV with a name
+func aovByName(name string) (AOV, bool) {
+	for a, aovName := range aovNames {
+		if name == aovName {
+			return AOV(a), true
+		}
+	}
+
+	return 0, false
+}
+
+// IsData returns true if the pass holds values rather than colors, so it mustn't be tone mapped
+func (a AOV) IsData() bool {
+	return a != AOVAlbedo
+}
+
+// forDisplay fits a pass into the 0 to 1 range of display formats, which would otherwise clip its values.
+// Depths are scaled so the farthest hit is white, and normals are moved from -1 to 1 into 0 to 1.
+func (a AOV) forDisplay(c *canvas.Canvas) *canvas.Canvas {
+	result := canvas.NewCanvas(c.W, c.H)
+	copy(result.Pix, c.Pix)
+
+	switch a {
+	case AOVDepth:
+		var maxDepth float64
+		for _, p := range c.Pix {
+			maxDepth = math.Max(maxDepth, p.R)
+		}
+
+		if maxDepth == 0 {
+			return result
+		}
+
+		for i := range result.Pix {
+			result.Pix[i] = *result.Pix[i].MultF(1 / maxDepth)
+		}
+	case AOVNormal:
+		for i := range result.Pix {
+			result.Pix[i] = *result.Pix[i].MultF(0.5).Add(color.Grey(0.5))
+		}
+	}
+
+	return result
+}
+
+// sceneIDs numbers the objects and materials of a world, starting from 1
+type sceneIDs struct {
+	// objects maps every primitive, or the outermost CSG it belongs to, to its top level object
+	objects   map[interface{}]int
+	materials map[material.Material]int
+}
+
+func newSceneIDs(w *World) *sceneIDs {
+	ids := &sceneIDs{
+		objects:   make(map[interface{}]int),
+		materials: make(map[material.Material]int),
+	}
+
+	for i, p := range w.Geometry {
+		ids.add(p, i+1)
+	}
+
+	return ids
+}
+
+// add numbers an object and everything inside it
+func (ids *sceneIDs) add(item geometry.Intersecter, id int) {
+	switch item := item.(type) {
+	case *geometry.BasicGroup:
+		for _, child := range item.Children {
+			ids.add(child, id)
+		}
+	case *geometry.CSG:
+		// hits on a CSG are identified by the CSG, but its materials still need numbers
+		ids.objects[item] = id
+		ids.addMaterials(item.Left)
+		ids.addMaterials(item.Right)
+	case Primitive:
+		ids.objects[item] = id
+		ids.addMaterials(item)
+	}
+}
+
+func (ids *sceneIDs) addMaterials(item geometry.Intersecter) {
+	switch item := item.(type) {
+	case *geometry.BasicGroup:
+		for _, child := range item.Children {
+			ids.addMaterials(child)
+		}
+	case *geometry.CSG:
+		ids.addMaterials(item.Left)
+		ids.addMaterials(item.Right)
+	case Primitive:
+		mat := item.GetMaterial()
+		if _, ok := ids.materials[mat]; !ok && mat != nil {
+			ids.materials[mat] = len(ids.materials) + 1
+		}
+	}
+}
+
+// objectID returns the number of the object which an intersected primitive belongs to
+func (ids *sceneIDs) objectID(p ray.Primitive) int {
+	if s, ok := p.(interface{ Solid() ray.IORHaver }); ok {
+		return ids.objects[s.Solid()]
+	}
+
+	return ids.objects[p]
+}
+
+// aovsAt returns the value of each pass where a ray first meets an object
+func (w *World) aovsAt(r *ray.Ray, aovs []AOV, ids *sceneIDs) []*color.Color {
+	result := make([]*color.Color, len(aovs))
+
+	h, ok := w.HitAt(r)
+	if !ok {
+		for i := range result {
+			result[i] = color.Black
+		}
+
+		return result
+	}
+
+	hitP := h.Inters[h.Index].P
+	mat := hitP.(Primitive).GetMaterial()
+
+	for i, a := range aovs {
+		switch a {
+		case AOVDepth:
+			result[i] = color.Grey(h.Inters[h.Index].T)
+		case AOVNormal:
+			result[i] = color.NewColor(h.NormalV.X, h.NormalV.Y, h.NormalV.Z)
+		case AOVAlbedo:
+			if c, ok := mat.(material.Colorer); ok {
+				result[i] = c.ColorAtHit(h)
+			} else {
+				result[i] = color.Black
+			}
+		case AOVObjectID:
+			result[i] = idColor(ids.objectID(hitP))
+		case AOVMaterialID:
+			result[i] = idColor(ids.materials[mat])
+		}
+	}
+
+	return result
+}
+
+// idColor returns a bright color for a positive ID, with the hues of neighboring IDs far apart, or black for 0
+func idColor(id int) *color.Color {
+	if id <= 0 {
+		return color.Black
+	}
+
+	// stepping by the golden ratio spreads the hues evenly, however many there are
+	hue := math.Mod(float64(id)*0.618033988749895, 1) * 6
+	x := 1 - math.Abs(math.Mod(hue, 2)-1)
+
+	switch int(hue) {
+	case 0:
+		return color.NewColor(1, x, 0)
+	case 1:
+		return color.NewColor(x, 1, 0)
+	case 2:
+		return color.NewColor(0, 1, x)
+	case 3:
+		return color.NewColor(0, x, 1)
+	case 4:
+		return color.NewColor(x, 0, 1)
+	default:
+		return color.NewColor(1, 0, x)
+	}
+}
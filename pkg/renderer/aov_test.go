@@ -0,0 +1,162 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/canvas"
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/geometry"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func aovWorld() *World {
+	matA := material.DefaultPhong.Copy()
+	matA.Color = color.Red
+	matB := material.DefaultPhong.Copy()
+	matB.Color = color.Green
+	matC := material.DefaultPhong.Copy()
+
+	return &World{
+		Config: &WorldConfig{},
+		Geometry: []Primitive{
+			geometry.NewBasicGroup(nil, nil,
+				geometry.NewSphere(nil, matA),
+				geometry.NewSphere(matrix.Translation(3, 0, 0), matA)),
+			geometry.NewCSG(geometry.CSGUnion,
+				geometry.NewCube(matrix.Translation(-3, 0, 0), matB),
+				geometry.NewSphere(matrix.Translation(-3, 0, 0).Mult(matrix.ScalingU(0.5)), matC),
+				nil),
+			geometry.NewPlane(matrix.Translation(0, 0, -2), matB),
+		},
+	}
+}
+
+func TestWorld_aovsAt(t *testing.T) {
+	w := aovWorld()
+	ids := newSceneIDs(w)
+	aovs := []AOV{AOVDepth, AOVNormal, AOVAlbedo, AOVObjectID, AOVMaterialID}
+
+	testCases := []struct {
+		name string
+		r    *ray.Ray
+		want []*color.Color
+	}{
+		{
+			name: "A sphere in a group",
+			r:    ray.NewRay(tuple.NewPoint(3, -5, 0), tuple.NewVector(0, 1, 0)),
+			want: []*color.Color{color.Grey(4), color.NewColor(0, -1, 0), color.Red, idColor(1), idColor(1)},
+		},
+		{
+			name: "A primitive of a CSG belongs to the CSG",
+			r:    ray.NewRay(tuple.NewPoint(-3, -5, 0), tuple.NewVector(0, 1, 0)),
+			want: []*color.Color{color.Grey(4), color.NewColor(0, -1, 0), color.Green, idColor(2), idColor(2)},
+		},
+		{
+			name: "Objects can share a material",
+			r:    ray.NewRay(tuple.NewPoint(6, 0, 5), tuple.NewVector(0, 0, -1)),
+			want: []*color.Color{color.Grey(7), color.NewColor(0, 0, 1), color.Green, idColor(3), idColor(2)},
+		},
+		{
+			name: "Every pass is black where nothing is hit",
+			r:    ray.NewRay(tuple.NewPoint(6, 0, 5), tuple.NewVector(0, 0, 1)),
+			want: []*color.Color{color.Black, color.Black, color.Black, color.Black, color.Black},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := w.aovsAt(tc.r, aovs, ids)
+
+			assert.Len(t, got, len(aovs))
+			for i := range aovs {
+				assert.True(t, tc.want[i].Equal(got[i]), "%s: want %v, got %v", aovs[i], tc.want[i], got[i])
+			}
+		})
+	}
+
+	// the sphere inside the CSG still has its material numbered
+	assert.Len(t, ids.materials, 3)
+}
+
+func TestIDColor(t *testing.T) {
+	assert.Equal(t, color.Black, idColor(0))
+
+	seen := make([]*color.Color, 0, 20)
+	for id := 1; id <= 20; id++ {
+		c := idColor(id)
+
+		// the colors are bright and saturated
+		assert.Equal(t, 1.0, maxComponent(c))
+		assert.Zero(t, c.R*c.G*c.B)
+
+		for _, s := range seen {
+			assert.False(t, s.Equal(c), "id %d repeats a color", id)
+		}
+		seen = append(seen, c)
+	}
+}
+
+func TestAOV(t *testing.T) {
+	for _, name := range aovNames {
+		a, ok := aovByName(name)
+		assert.True(t, ok)
+		assert.Equal(t, name, a.String())
+	}
+
+	_, ok := aovByName("beauty")
+	assert.False(t, ok)
+
+	assert.False(t, AOVAlbedo.IsData())
+	assert.True(t, AOVDepth.IsData())
+	assert.Equal(t, "renders/scene.normal.png", aovPath("renders/scene.png", AOVNormal))
+}
+
+func TestAOV_forDisplay(t *testing.T) {
+	depth := canvas.NewCanvas(2, 1)
+	depth.Set(0, 0, color.Grey(2))
+	depth.Set(1, 0, color.Grey(8))
+
+	got := AOVDepth.forDisplay(depth)
+	assert.True(t, color.Grey(0.25).Equal(got.Get(0, 0)))
+	assert.True(t, color.White.Equal(got.Get(1, 0)))
+	// the pass itself is left alone
+	assert.True(t, color.Grey(8).Equal(depth.Get(1, 0)))
+
+	normal := canvas.NewCanvas(1, 1)
+	normal.Set(0, 0, color.NewColor(-1, 0, 1))
+
+	assert.True(t, color.NewColor(0, 0.5, 1).Equal(AOVNormal.forDisplay(normal).Get(0, 0)))
+
+	// nothing hit
+	assert.True(t, color.Black.Equal(AOVDepth.forDisplay(canvas.NewCanvas(1, 1)).Get(0, 0)))
+}
+
+func TestCamera_GoRenderAOVs(t *testing.T) {
+	c := NewCamera(&CameraConfig{
+		Height:            12,
+		Width:             8,
+		SubdivisionNumber: 2,
+		FOV:               0.5,
+		Transform: &ViewTransformConfig{
+			From: PointConfig{3, -5, 0},
+			To:   PointConfig{3, 0, 0},
+			Up:   VectorConfig{0, 0, 1},
+		},
+	})
+
+	canv, passes := c.GoRenderAOVs(aovWorld(), []AOV{AOVDepth, AOVObjectID})
+
+	assert.Len(t, passes, 2)
+	for _, p := range passes {
+		assert.Equal(t, canv.W, p.W)
+		assert.Equal(t, canv.H, p.H)
+	}
+
+	// the camera looks straight at the grouped sphere
+	assert.InDelta(t, 4, passes[0].Get(6, 4).R, 0.05)
+	assert.True(t, idColor(1).Equal(passes[1].Get(6, 4)))
+}
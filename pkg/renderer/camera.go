@@ -1,25 +1,51 @@
 package renderer
 
 import (
+	"context"
 	"math"
+	"runtime"
 	"sync"
+	"time"
 
 	"github.com/Henelik/tricaster/pkg/canvas"
 	"github.com/Henelik/tricaster/pkg/color"
 	"github.com/Henelik/tricaster/pkg/matrix"
 	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/sampling"
 	"github.com/Henelik/tricaster/pkg/tuple"
 )
 
 var DefaultFOV = math.Pi / 2
 
+// Projection decides which direction the camera looks through each point of the canvas
+type Projection int
+
+const (
+	// ProjectionPerspective sees the scene through a flat canvas in front of the camera, like a real lens
+	ProjectionPerspective Projection = iota
+	// ProjectionOrthographic sends parallel rays, so objects keep their size however far away they are
+	ProjectionOrthographic
+	// ProjectionFisheye is an equidistant fisheye, where the distance from the center of the image is proportional to the angle from the view direction
+	ProjectionFisheye
+	// ProjectionEquirectangular stretches every direction around the camera over the image, with longitude across and latitude down
+	ProjectionEquirectangular
+)
+
 type Camera struct {
 	config     *CameraConfig
 	m          *matrix.Matrix
 	im         *matrix.Matrix
+	projection Projection
 	halfWidth  float64
 	halfHeight float64
 	pixelSize  float64
+	// pixelHeight is the same as pixelSize, except for panoramas which stretch to fill the image
+	pixelHeight float64
+	sampler     sampling.Sequence
+	filter      sampling.Filter
+	// filterSampler spreads the samples of a pixel over its filter
+	filterSampler *sampling.FilterSampler
+	tileOrder     TileOrder
 }
 
 // NewCamera creates a new camera.
@@ -38,22 +64,100 @@ func NewCamera(config *CameraConfig) *Camera {
 		c.m = matrix.Identity
 	}
 
+	// focus on the point the camera looks at, unless told otherwise
+	if config.FocalDistance <= 0 {
+		config.FocalDistance = 1
+		if config.Transform != nil {
+			config.FocalDistance = config.Transform.Distance()
+		}
+	}
+
+	if config.ApertureBlades < 3 {
+		config.ApertureBlades = 0
+	}
+
+	if config.LensSamples < 1 {
+		config.LensSamples = 1
+	}
+
 	c.im = c.m.Inverse()
 
-	switch config.AALevel {
-	case 2:
-		config.AALevel = 2
-	case 4:
-		config.AALevel = 4
-	case 8:
-		config.AALevel = 8
-	case 16:
-		config.AALevel = 16
-	default:
+	if config.AALevel < 1 {
 		config.AALevel = 1
 	}
 
+	if config.Samples < 1 {
+		config.Samples = config.AALevel * config.AALevel
+	}
+
+	if config.MaxSamples < 1 {
+		config.MaxSamples = 4 * config.Samples
+	}
+
+	switch config.Sampler {
+	case "", "stratified":
+		c.sampler = sampling.Stratified{}
+	case "grid":
+		c.sampler = sampling.Grid{}
+	case "halton":
+		c.sampler = sampling.Halton{}
+	case "sobol":
+		c.sampler = sampling.Sobol{}
+	default:
+		panic("unrecognized sampler: " + config.Sampler)
+	}
+
+	switch config.Filter {
+	case "", "box":
+		c.filter = sampling.NewBoxFilter(filterRadius(config, 0.5))
+	case "tent":
+		c.filter = sampling.NewTentFilter(filterRadius(config, 1))
+	case "gaussian":
+		c.filter = sampling.NewGaussianFilter(filterRadius(config, 1.5), 2)
+	case "mitchell":
+		c.filter = sampling.NewMitchellFilter(filterRadius(config, 2), 1.0/3, 1.0/3)
+	default:
+		panic("unrecognized filter: " + config.Filter)
+	}
+
+	c.filterSampler = sampling.NewFilterSampler(c.filter)
+
+	if config.NumWorkers < 1 {
+		config.NumWorkers = runtime.NumCPU()
+	}
+
+	if config.TileSize < 1 {
+		config.TileSize = DefaultTileSize
+	}
+
+	switch config.TileOrder {
+	case "", "spiral":
+		c.tileOrder = TileOrderSpiral
+	case "hilbert":
+		c.tileOrder = TileOrderHilbert
+	default:
+		panic("unrecognized tile order: " + config.TileOrder)
+	}
+
+	switch config.Projection {
+	case "", "perspective":
+		c.projection = ProjectionPerspective
+	case "orthographic":
+		c.projection = ProjectionOrthographic
+	case "fisheye":
+		c.projection = ProjectionFisheye
+	case "equirectangular":
+		c.projection = ProjectionEquirectangular
+	default:
+		panic("unrecognized projection: " + config.Projection)
+	}
+
+	// the canvas is sized in units of distance for orthographic cameras, or angle for fisheye ones
 	halfView := math.Tan(config.FOV / 2)
+	if c.projection == ProjectionFisheye {
+		halfView = config.FOV / 2
+	}
+
 	aspect := float64(config.Height) / float64(config.Width)
 	if aspect >= 1 {
 		c.halfWidth = halfView
@@ -62,11 +166,36 @@ func NewCamera(config *CameraConfig) *Camera {
 		c.halfWidth = halfView * aspect
 		c.halfHeight = halfView
 	}
+
+	switch c.projection {
+	case ProjectionOrthographic:
+		// by default, keep the framing of a perspective camera at the focal distance
+		if config.ViewWidth <= 0 {
+			config.ViewWidth = c.halfWidth * 2 * config.FocalDistance
+		}
+
+		c.halfWidth = config.ViewWidth / 2
+		c.halfHeight = c.halfWidth / aspect
+	case ProjectionEquirectangular:
+		c.halfWidth = math.Pi
+		c.halfHeight = math.Pi / 2
+	}
+
 	c.pixelSize = (c.halfWidth * 2) / float64(config.Height)
+	c.pixelHeight = (c.halfHeight * 2) / float64(config.Width)
 
 	return c
 }
 
+// filterRadius returns the configured filter radius, after setting it to the filter's own default if it's missing
+func filterRadius(config *CameraConfig, defaultRadius float64) float64 {
+	if config.FilterRadius <= 0 {
+		config.FilterRadius = defaultRadius
+	}
+
+	return config.FilterRadius
+}
+
 func (c *Camera) GetMatrix() *matrix.Matrix {
 	return c.m
 }
@@ -86,57 +215,224 @@ func (c *Camera) SetTransform(im *matrix.Matrix) {
 }
 
 func (c *Camera) RayForPixel(x, y int) *ray.Ray {
+	worldX, worldY := c.pixelCenter(x, y)
+	lensU, lensV := sampling.RandomPoint(0, lensSeed(c.pixelSeed(x, y)))
+
+	return c.rayThrough(worldX, worldY, lensU, lensV)
+}
+
+// pixelCenter returns the untransformed coordinates of the center of a pixel in world space
+func (c *Camera) pixelCenter(x, y int) (float64, float64) {
 	// the offset from the edge of the canvas to the pixel's center
 	xOffset := (float64(x) + 0.5) * c.pixelSize
-	yOffset := (float64(y) + 0.5) * c.pixelSize
+	yOffset := (float64(y) + 0.5) * c.pixelHeight
 
-	// the untransformed coordinates of the pixel in world space.
 	// (remember that the camera looks toward -z, so +x is to the *right*.)
-	worldX := c.halfWidth - xOffset
-	worldY := c.halfHeight - yOffset
+	return c.halfWidth - xOffset, c.halfHeight - yOffset
+}
+
+// pixelSeed returns the seed of a pixel's samples.
+// Every pixel has its own seed, so the same sample of neighboring pixels isn't in the same place.
+func (c *Camera) pixelSeed(x, y int) uint32 {
+	return uint32(y*c.config.Height + x)
+}
+
+// pathSeed returns the seed of the paths traced for a pixel, which is unrelated to its other seeds
+func pathSeed(seed uint32) uint32 {
+	return seed ^ 0x9e3779b9
+}
+
+// lensSeed returns the seed of the points on the lens which a pixel is seen through,
+// which is different from the pixel's seed so they're unrelated to the points on the canvas
+func lensSeed(seed uint32) uint32 {
+	return ^seed
+}
+
+// rayThrough returns a ray through an untransformed point on the canvas.
+// A pinhole camera's rays all start at its origin, while a camera with an aperture starts each ray
+// at the point on its lens given by lensU and lensV, aimed so that the focal plane stays sharp.
+func (c *Camera) rayThrough(worldX, worldY, lensU, lensV float64) *ray.Ray {
+	switch c.projection {
+	case ProjectionOrthographic:
+		// every ray looks straight ahead, from its own point on the canvas
+		origin := c.im.MultTuple(tuple.NewPoint(worldX, worldY, 0))
+		return ray.NewRay(origin, c.im.MultTuple(tuple.NewVector(0, 0, -1)).Norm())
+	case ProjectionFisheye:
+		// the distance from the center is the angle away from straight ahead
+		theta := math.Hypot(worldX, worldY)
+		if theta == 0 {
+			return c.rayToward(tuple.NewVector(0, 0, -1))
+		}
+
+		scale := math.Sin(theta) / theta
+		return c.rayToward(tuple.NewVector(worldX*scale, worldY*scale, -math.Cos(theta)))
+	case ProjectionEquirectangular:
+		// the canvas coordinates are the longitude and latitude
+		return c.rayToward(tuple.NewVector(
+			math.Sin(worldX)*math.Cos(worldY),
+			math.Sin(worldY),
+			-math.Cos(worldX)*math.Cos(worldY)))
+	}
 
 	// using the camera matrix, transform the canvas point and the origin,
 	// and then compute the ray's direction vector.
 	// (remember that the canvas is at y=-1)
-	pixel := c.im.MultTuple(tuple.NewPoint(worldX, worldY, -1))
-	origin := c.im.MultTuple(tuple.Origin)
-	direction := pixel.Sub(origin).Norm()
+	if c.config.Aperture <= 0 {
+		pixel := c.im.MultTuple(tuple.NewPoint(worldX, worldY, -1))
+		origin := c.im.MultTuple(tuple.Origin)
+
+		return ray.NewRay(origin, pixel.Sub(origin).Norm())
+	}
+
+	// the canvas is one unit away, so scaling its point reaches the focal plane
+	focus := c.im.MultTuple(tuple.NewPoint(
+		worldX*c.config.FocalDistance,
+		worldY*c.config.FocalDistance,
+		-c.config.FocalDistance))
+
+	lensX, lensY := c.lensPoint(lensU, lensV)
+	origin := c.im.MultTuple(tuple.NewPoint(lensX*c.config.Aperture, lensY*c.config.Aperture, 0))
+
+	return ray.NewRay(origin, focus.Sub(origin).Norm())
+}
+
+// rayToward returns a ray from the camera's origin, in a direction given in camera space
+func (c *Camera) rayToward(direction *tuple.Tuple) *ray.Ray {
+	return ray.NewRay(c.im.MultTuple(tuple.Origin), c.im.MultTuple(direction).Norm())
+}
+
+// lensPoint maps a point in the unit square to a point on a lens of radius 1,
+// which is a disk, or a regular polygon if the aperture has blades.
+// Points spread evenly over the square are spread evenly over the lens.
+func (c *Camera) lensPoint(u, v float64) (float64, float64) {
+	blades := c.config.ApertureBlades
+
+	if blades == 0 {
+		r := math.Sqrt(u)
+		theta := 2 * math.Pi * v
+
+		return r * math.Cos(theta), r * math.Sin(theta)
+	}
+
+	// u picks one of the triangles between the center and each edge, and what's left of it
+	// picks a point inside the triangle along with v.
+	// The first corner points up, like the aperture of a real lens.
+	edge := int(u * float64(blades))
+	if edge == blades {
+		edge--
+	}
+	u = u*float64(blades) - float64(edge)
 
-	return ray.NewRay(origin, direction)
+	a0 := math.Pi/2 + 2*math.Pi*float64(edge)/float64(blades)
+	a1 := a0 + 2*math.Pi/float64(blades)
+
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+
+	return u*math.Cos(a0) + v*math.Cos(a1), u*math.Sin(a0) + v*math.Sin(a1)
+}
+
+// cameraSample is a ray through a pixel, the offset of its point on the canvas from the pixel's center,
+// its weight in the pixel's color, and the random numbers of the paths traced from it
+type cameraSample struct {
+	r      *ray.Ray
+	dx, dy float64
+	weight float64
+	rnd    *sampling.Random
 }
 
+// AARaysForPixel returns the rays of the first anti-aliasing samples of a pixel
 func (c *Camera) AARaysForPixel(x, y int) []*ray.Ray {
-	if c.config.AALevel == 0 {
-		return []*ray.Ray{c.RayForPixel(x, y)}
+	samples := c.samplesForPixel(x, y, 0, c.config.Samples)
+
+	rs := make([]*ray.Ray, len(samples))
+	for i, s := range samples {
+		rs[i] = s.r
 	}
 
-	rs := make([]*ray.Ray, 0, c.config.AALevel*c.config.AALevel)
+	return rs
+}
 
-	// the offset from the edge of the canvas to the pixel's center
-	xOffset := (float64(x) + 0.5) * c.pixelSize
-	yOffset := (float64(y) + 0.5) * c.pixelSize
-
-	// the distance between sampled sub-pixel points on the canvas
-	aaOffset := c.pixelSize / float64(c.config.AALevel)
-	origin := c.im.MultTuple(tuple.Origin)
-
-	for aax := 0; aax < c.config.AALevel; aax++ {
-		for aay := 0; aay < c.config.AALevel; aay++ {
-			// the untransformed coordinates of the pixel in world space.
-			// (remember that the camera looks toward -z, so +x is to the *right*.)
-			worldX := c.halfWidth - xOffset + float64(aax)*aaOffset
-			worldY := c.halfHeight - yOffset + float64(aay)*aaOffset
-
-			// using the camera matrix, transform the canvas point and the origin,
-			// and then compute the ray's direction vector.
-			// (remember that the canvas is at y=-1)
-			pixel := c.im.MultTuple(tuple.NewPoint(worldX, worldY, -1))
-			direction := pixel.Sub(origin).Norm()
-			rs = append(rs, ray.NewRay(origin, direction))
+// samplesForPixel returns the rays of count anti-aliasing samples of a pixel, starting from the start-th sample.
+// The samples spread over the filter's radius, so wide filters overlap the neighboring pixels.
+func (c *Camera) samplesForPixel(x, y, start, count int) []cameraSample {
+	samples := make([]cameraSample, 0, count*c.config.LensSamples)
+
+	seed := c.pixelSeed(x, y)
+
+	for i := start; i < start+count; i++ {
+		// a lone sample looks through the center of the pixel
+		u, v := 0.5, 0.5
+		if c.config.Samples > 1 || i > 0 {
+			u, v = c.sampler.Point(i, c.config.Samples, seed)
+		}
+
+		dx, dy, weight := c.filterSampler.Sample(u, v)
+
+		// the untransformed coordinates of the sample in world space.
+		// (remember that the camera looks toward -z, so +x is to the *right*.)
+		worldX := c.halfWidth - (float64(x)+0.5+dx)*c.pixelSize
+		worldY := c.halfHeight - (float64(y)+0.5+dy)*c.pixelHeight
+
+		// each point on the canvas is seen through several points on the lens
+		for j := 0; j < c.config.LensSamples; j++ {
+			k := i*c.config.LensSamples + j
+			lensU, lensV := sampling.RandomPoint(k, lensSeed(seed))
+			r := c.rayThrough(worldX, worldY, lensU, lensV)
+
+			samples = append(samples, cameraSample{
+				r: r, dx: dx, dy: dy, weight: weight,
+				rnd: sampling.NewRandom(pathSeed(seed), k),
+			})
 		}
 	}
 
-	return rs
+	return samples
+}
+
+// pixelColor traces the samples of a pixel and blends them with the reconstruction filter,
+// and returns the number of rays it traced.
+// With adaptive sampling, more samples are taken while their brightness still varies too much.
+func (c *Camera) pixelColor(w *World, x, y int) (*color.Color, int) {
+	sum := color.Black
+	weights := 0.0
+
+	var brightness sampling.Estimate
+	cols := make([]*color.Color, 0, c.config.Samples*c.config.LensSamples)
+
+	for taken, batch := 0, c.config.Samples; batch > 0; {
+		for _, s := range c.samplesForPixel(x, y, taken, batch) {
+			col := w.Trace(s.r, s.rnd)
+
+			sum = sum.Add(col.MultF(s.weight))
+			weights += s.weight
+			brightness.Add(luminance(col))
+			cols = append(cols, col)
+		}
+		taken += batch
+
+		if c.config.AdaptiveThreshold <= 0 || brightness.StandardError() <= c.config.AdaptiveThreshold {
+			break
+		}
+
+		batch = c.config.Samples
+		if taken+batch > c.config.MaxSamples {
+			batch = c.config.MaxSamples - taken
+		}
+	}
+
+	// with few samples, the negative lobes of a filter can cancel out the rest
+	if weights <= 0 {
+		return color.Avg(cols), len(cols)
+	}
+
+	return sum.MultF(1 / weights), len(cols)
+}
+
+// luminance returns the brightness of a color as the eye sees it
+func luminance(c *color.Color) float64 {
+	return 0.2126*c.R + 0.7152*c.G + 0.0722*c.B
 }
 
 // Render is the original single-thread render function
@@ -145,7 +441,7 @@ func (c *Camera) Render(w *World) *canvas.Canvas {
 	for x := 0; x < c.config.Height; x++ {
 		for y := 0; y < c.config.Width; y++ {
 			r := c.RayForPixel(x, y)
-			col := w.ColorAt(r, w.Config.MaxBounce)
+			col := w.Trace(r, sampling.NewRandom(pathSeed(c.pixelSeed(x, y)), 0))
 			canv.Set(x, y, col)
 		}
 	}
@@ -153,38 +449,112 @@ func (c *Camera) Render(w *World) *canvas.Canvas {
 	return canv
 }
 
-// GoRender divides the image into an n*n grid and renders each cell in a goroutine
+// GoRender divides the image into tiles, and renders them with a pool of goroutines.
+// Each worker takes the next tile as soon as it's done with the last one,
+// so a slow part of the image doesn't hold up the rest.
 func (c *Camera) GoRender(w *World) *canvas.Canvas {
+	canv, _ := c.GoRenderAOVs(w, nil)
+	return canv
+}
+
+// GoRenderAOVs is like GoRender, but also renders a canvas for each of the AOVs
+func (c *Camera) GoRenderAOVs(w *World, aovs []AOV) (*canvas.Canvas, []*canvas.Canvas) {
+	canv, passes, _ := c.GoRenderContext(context.Background(), w, aovs, nil)
+	return canv, passes
+}
+
+// GoRenderContext is like GoRenderAOVs, but stops early if the context is cancelled,
+// and calls onProgress, unless it's nil, whenever a tile is finished.
+// If the render is stopped, the canvases are returned with the tiles which were finished,
+// along with the context's error.
+func (c *Camera) GoRenderContext(ctx context.Context, w *World, aovs []AOV, onProgress func(Progress)) (*canvas.Canvas, []*canvas.Canvas, error) {
+	start := time.Now()
+
 	canv := canvas.NewCanvas(c.config.Height, c.config.Width)
 
-	// set up a wait group for the number of subdivisions
+	passes := make([]*canvas.Canvas, len(aovs))
+	for i := range passes {
+		passes[i] = canvas.NewCanvas(c.config.Height, c.config.Width)
+	}
+
+	var ids *sceneIDs
+	if len(aovs) > 0 {
+		ids = newSceneIDs(w)
+	}
+
+	// queue every tile up front, so the workers stop once the queue is empty
+	ts := tiles(c.config.Height, c.config.Width, c.config.TileSize, c.tileOrder)
+	queue := make(chan tile, len(ts))
+	for _, t := range ts {
+		queue <- t
+	}
+	close(queue)
+
+	// the workers report each finished tile, with the number of rays it took
+	type tileResult struct {
+		t    tile
+		rays int
+	}
+	results := make(chan tileResult)
+
 	var wg sync.WaitGroup
-	wg.Add(c.config.SubdivisionNumber * c.config.SubdivisionNumber)
-
-	subH := c.config.Height / c.config.SubdivisionNumber
-	subV := c.config.Width / c.config.SubdivisionNumber
-
-	worker := func(xs, ys int) {
-		defer wg.Done()
-		for x := xs; x < subH+xs; x++ {
-			for y := ys; y < subV+ys; y++ {
-				rs := c.AARaysForPixel(x, y)
-				cols := make([]*color.Color, len(rs))
-				for i, r := range rs {
-					cols[i] = w.ColorAt(r, w.Config.MaxBounce)
+	wg.Add(c.config.NumWorkers)
+
+	for i := 0; i < c.config.NumWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				rays, ok := c.renderTile(ctx, w, t, canv, aovs, passes, ids)
+				if !ok {
+					return
 				}
-				canv.Set(x, y, color.Avg(cols))
+
+				results <- tileResult{t: t, rays: rays}
 			}
-		}
+		}()
 	}
 
-	for sh := 0; sh < c.config.SubdivisionNumber; sh++ {
-		for sv := 0; sv < c.config.SubdivisionNumber; sv++ {
-			go worker(sh*subH, sv*subV)
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// progress is only reported from here, so the callback doesn't need to be safe for concurrent use
+	progress := Progress{Tiles: len(ts), Pixels: c.config.Height * c.config.Width}
+	for r := range results {
+		progress.finishTile(r.t, r.rays, start)
+
+		if onProgress != nil {
+			onProgress(progress)
 		}
 	}
 
-	wg.Wait()
+	return canv, passes, ctx.Err()
+}
 
-	return canv
+// renderTile renders the pixels of a tile, and its AOVs if there are any.
+// It returns the number of camera rays it traced, and false if the context was cancelled before it finished.
+func (c *Camera) renderTile(ctx context.Context, w *World, t tile, canv *canvas.Canvas, aovs []AOV, passes []*canvas.Canvas, ids *sceneIDs) (int, bool) {
+	rays := 0
+
+	for x := t.x; x < t.x+t.w; x++ {
+		// a tile can take a while, so stop partway through if need be
+		if ctx.Err() != nil {
+			return rays, false
+		}
+
+		for y := t.y; y < t.y+t.h; y++ {
+			col, n := c.pixelColor(w, x, y)
+			canv.Set(x, y, col)
+			rays += n
+
+			if len(aovs) > 0 {
+				for i, col := range w.aovsAt(c.RayForPixel(x, y), aovs, ids) {
+					passes[i].Set(x, y, col)
+				}
+			}
+		}
+	}
+
+	return rays, true
 }
@@ -1,15 +1,19 @@
 package renderer
 
 import (
+	"context"
 	"math"
 	"testing"
 
 	"github.com/Henelik/tricaster/pkg/canvas"
 	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/environment"
 	"github.com/Henelik/tricaster/pkg/geometry"
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/sampling"
 	"github.com/Henelik/tricaster/pkg/tuple"
 
 	"github.com/stretchr/testify/assert"
@@ -76,6 +80,204 @@ func TestRayForPixel(t *testing.T) {
 	assert.True(t, tuple.NewVector(0, -math.Sqrt2/2, -math.Sqrt2/2).Equal(r2.Direction))
 }
 
+func TestThinLens(t *testing.T) {
+	newCamera := func(blades int) *Camera {
+		return NewCamera(&CameraConfig{
+			Height:         51,
+			Width:          21,
+			AALevel:        2,
+			FOV:            math.Pi / 3,
+			Aperture:       0.5,
+			ApertureBlades: blades,
+			LensSamples:    8,
+			Transform: &ViewTransformConfig{
+				From: PointConfig{0, -4, 3},
+				To:   PointConfig{0, 0, 0},
+				Up:   VectorConfig{0, 0, 1},
+			},
+		})
+	}
+
+	c := newCamera(0)
+
+	// the focal distance defaults to the point the camera looks at
+	assert.Equal(t, 5.0, c.config.FocalDistance)
+	assert.Len(t, c.AARaysForPixel(10, 10), 2*2*8)
+	// the rays are the same every time
+	assert.Equal(t, c.AARaysForPixel(10, 10), c.AARaysForPixel(10, 10))
+
+	pinhole := NewCamera(&CameraConfig{
+		Height:    51,
+		Width:     21,
+		FOV:       math.Pi / 3,
+		Transform: c.config.Transform,
+	})
+
+	for _, pixel := range [][2]int{{25, 10}, {0, 0}, {50, 20}} {
+		want := pinhole.RayForPixel(pixel[0], pixel[1])
+		// the point where the pinhole ray crosses the focal plane
+		focus := want.Position(c.config.FocalDistance / -c.m.MultTuple(want.Direction).Z)
+
+		worldX, worldY := c.pixelCenter(pixel[0], pixel[1])
+
+		for i := 0; i < 20; i++ {
+			lensU, lensV := sampling.RandomPoint(i, 1)
+			r := c.rayThrough(worldX, worldY, lensU, lensV)
+
+			// rays start on the lens, and meet at the focal plane
+			lens := c.m.MultTuple(r.Origin)
+			assert.InDelta(t, 0, lens.Z, 1e-9)
+			assert.LessOrEqual(t, math.Hypot(lens.X, lens.Y), 0.5+1e-9)
+			assert.InDelta(t, 0, focus.Sub(r.Origin).CrossProd(r.Direction).Mag(), 1e-9)
+		}
+	}
+
+	// a focal distance of 0 means the default
+	c.config.FocalDistance = 0
+	assert.Equal(t, 5.0, NewCamera(c.config).config.FocalDistance)
+}
+
+func TestCamera_lensPoint(t *testing.T) {
+	testCases := []struct {
+		name   string
+		blades int
+		// apothem is the distance from the center to the middle of each edge
+		apothem float64
+	}{
+		{
+			name:    "A round aperture",
+			blades:  0,
+			apothem: 1,
+		},
+		{
+			name:    "A triangular aperture",
+			blades:  3,
+			apothem: 0.5,
+		},
+		{
+			name:    "A hexagonal aperture",
+			blades:  6,
+			apothem: math.Cos(math.Pi / 6),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewCamera(&CameraConfig{Height: 1, Width: 1, ApertureBlades: tc.blades})
+
+			var sumX, sumY float64
+			for i := 0; i < 2000; i++ {
+				x, y := c.lensPoint(sampling.RandomPoint(i, 1))
+				sumX += x
+				sumY += y
+
+				if tc.blades == 0 {
+					assert.LessOrEqual(t, math.Hypot(x, y), 1.0)
+					continue
+				}
+
+				// the point is inside every edge of the polygon
+				for edge := 0; edge < tc.blades; edge++ {
+					mid := math.Pi/2 + math.Pi*float64(2*edge+1)/float64(tc.blades)
+					assert.LessOrEqual(t, x*math.Cos(mid)+y*math.Sin(mid), tc.apothem+1e-9)
+				}
+			}
+
+			// the points are spread evenly around the center
+			assert.InDelta(t, 0, sumX/2000, 0.05)
+			assert.InDelta(t, 0, sumY/2000, 0.05)
+		})
+	}
+
+	// fewer than 3 blades is a round aperture
+	assert.Equal(t, 0, NewCamera(&CameraConfig{Height: 1, Width: 1, ApertureBlades: 2}).config.ApertureBlades)
+}
+
+func TestProjections(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *CameraConfig
+		x, y   int
+		want   *ray.Ray
+	}{
+		{
+			name:   "An orthographic ray through the center of the canvas",
+			config: &CameraConfig{Height: 201, Width: 101, Projection: "orthographic", ViewWidth: 4},
+			x:      100,
+			y:      50,
+			want:   ray.NewRay(tuple.Origin, tuple.Down),
+		},
+		{
+			name:   "Orthographic rays are parallel, and start across the view width",
+			config: &CameraConfig{Height: 201, Width: 101, Projection: "orthographic", ViewWidth: 4},
+			x:      0,
+			y:      50,
+			want:   ray.NewRay(tuple.NewPoint(2-4.0/201/2, 0, 0), tuple.Down),
+		},
+		{
+			name:   "A fisheye ray through the center of the canvas",
+			config: &CameraConfig{Height: 201, Width: 101, Projection: "fisheye", FOV: math.Pi},
+			x:      100,
+			y:      50,
+			want:   ray.NewRay(tuple.Origin, tuple.Down),
+		},
+		{
+			name:   "The edge of a 180° fisheye looks to the side",
+			config: &CameraConfig{Height: 2000, Width: 1, Projection: "fisheye", FOV: math.Pi},
+			x:      0,
+			y:      0,
+			want:   ray.NewRay(tuple.Origin, tuple.NewVector(1, 0, 0)),
+		},
+		{
+			name:   "An equirectangular panorama looks ahead from its center",
+			config: &CameraConfig{Height: 2000, Width: 1000, Projection: "equirectangular"},
+			x:      1000,
+			y:      500,
+			want:   ray.NewRay(tuple.Origin, tuple.Down),
+		},
+		{
+			name:   "The edges of a panorama look behind",
+			config: &CameraConfig{Height: 2000, Width: 1000, Projection: "equirectangular"},
+			x:      0,
+			y:      500,
+			want:   ray.NewRay(tuple.Origin, tuple.Up),
+		},
+		{
+			name:   "The top of a panorama looks up, whatever the shape of the image",
+			config: &CameraConfig{Height: 2000, Width: 2000, Projection: "equirectangular"},
+			x:      1000,
+			y:      0,
+			want:   ray.NewRay(tuple.Origin, tuple.NewVector(0, 1, 0)),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewCamera(tc.config).RayForPixel(tc.x, tc.y)
+
+			assert.True(t, tc.want.Origin.Equal(r.Origin), "origin %v", r.Origin)
+			// panoramas are sampled at the centers of pixels, which are slightly off the exact directions
+			assert.InDelta(t, 0, tc.want.Direction.Sub(r.Direction).Mag(), 0.01, "direction %v", r.Direction)
+		})
+	}
+
+	// an orthographic camera keeps the framing of a perspective camera at the focal distance
+	c := NewCamera(&CameraConfig{
+		Height:     200,
+		Width:      100,
+		FOV:        math.Pi / 2,
+		Projection: "orthographic",
+		Transform: &ViewTransformConfig{
+			From: PointConfig{0, -5, 0},
+			To:   PointConfig{0, 0, 0},
+			Up:   VectorConfig{0, 0, 1},
+		},
+	})
+	assert.InDelta(t, 10, c.config.ViewWidth, 1e-9)
+
+	assert.PanicsWithValue(t, "unrecognized projection: cylindrical", func() {
+		NewCamera(&CameraConfig{Height: 1, Width: 1, Projection: "cylindrical"})
+	})
+}
+
 func BenchmarkRender(b *testing.B) {
 	var canv *canvas.Canvas
 	floorMat := material.DefaultPhong.CopyWithColor(color.NewColor(1, 0.9, 0.9))
@@ -121,9 +323,11 @@ func BenchmarkRender(b *testing.B) {
 			left,
 			right,
 		},
-		Light: &light.PointLight{
-			Pos:   tuple.NewPoint(0, -10, 10),
-			Color: color.White,
+		Lights: []light.Light{
+			&light.PointLight{
+				Pos:   tuple.NewPoint(0, -10, 10),
+				Color: color.White,
+			},
 		},
 		Config: &WorldConfig{
 			Shadows: true,
@@ -150,3 +354,218 @@ func BenchmarkRender(b *testing.B) {
 	}
 	assert.NotNil(b, canv)
 }
+
+func TestCamera_samplesForPixel(t *testing.T) {
+	// an orthographic camera with pixels 1 unit wide, so the rays start at the sampled points
+	newCamera := func(samples int, sampler, filter string) *Camera {
+		return NewCamera(&CameraConfig{
+			Height:     4,
+			Width:      4,
+			Projection: "orthographic",
+			ViewWidth:  4,
+			Samples:    samples,
+			Sampler:    sampler,
+			Filter:     filter,
+		})
+	}
+
+	offsets := func(c *Camera) [][2]float64 {
+		result := make([][2]float64, 0)
+		for _, s := range c.samplesForPixel(1, 1, 0, c.config.Samples) {
+			// the center of the pixel is at (0.5, 0.5), and +x is to the left of the image
+			assert.InDelta(t, 0.5-s.dx, s.r.Origin.X, 1e-9)
+			assert.InDelta(t, 0.5-s.dy, s.r.Origin.Y, 1e-9)
+			result = append(result, [2]float64{s.dx, s.dy})
+		}
+
+		return result
+	}
+
+	assertOffsets := func(want, got [][2]float64) {
+		assert.Len(t, got, len(want))
+		for i := range want {
+			assert.InDelta(t, want[i][0], got[i][0], 1e-9)
+			assert.InDelta(t, want[i][1], got[i][1], 1e-9)
+		}
+	}
+
+	// a lone sample is in the center of the pixel
+	assertOffsets([][2]float64{{0, 0}}, offsets(newCamera(1, "stratified", "")))
+
+	// a box filter keeps the samples inside the pixel
+	assertOffsets([][2]float64{{-0.25, -0.25}, {0.25, -0.25}, {-0.25, 0.25}, {0.25, 0.25}}, offsets(newCamera(4, "grid", "box")))
+
+	// wider filters spread the samples over the neighboring pixels, but crowd them toward the center
+	mitchell := offsets(newCamera(4, "grid", "mitchell"))
+	assert.Greater(t, mitchell[0][0], -1.0)
+	assert.Less(t, mitchell[0][0], -0.25)
+	assertOffsets([][2]float64{
+		{mitchell[0][0], mitchell[0][0]},
+		{-mitchell[0][0], mitchell[0][0]},
+		{mitchell[0][0], -mitchell[0][0]},
+		{-mitchell[0][0], -mitchell[0][0]},
+	}, mitchell)
+
+	for _, sampler := range []string{"stratified", "halton", "sobol"} {
+		for _, o := range offsets(newCamera(16, sampler, "tent")) {
+			assert.LessOrEqual(t, math.Abs(o[0]), 1.0)
+			assert.LessOrEqual(t, math.Abs(o[1]), 1.0)
+		}
+	}
+}
+
+func TestCamera_pixelColor(t *testing.T) {
+	// the edge of a white cube runs through the middle of pixel 0, while pixel 3 only sees the black background
+	w := &World{
+		Config: &WorldConfig{},
+		Geometry: []Primitive{
+			geometry.NewCube(
+				matrix.Translation(101.5, 0, -10).Mult(matrix.ScalingU(100)),
+				&material.ShadelessMat{Color: color.White}),
+		},
+	}
+
+	newCamera := func(filter string, threshold float64) *Camera {
+		return NewCamera(&CameraConfig{
+			Height:            4,
+			Width:             1,
+			Projection:        "orthographic",
+			ViewWidth:         4,
+			Samples:           16,
+			Sampler:           "grid",
+			Filter:            filter,
+			AdaptiveThreshold: threshold,
+			MaxSamples:        64,
+		})
+	}
+
+	for _, filter := range []string{"box", "tent", "gaussian", "mitchell"} {
+		t.Run(filter, func(t *testing.T) {
+			c := newCamera(filter, 0)
+
+			col, rays := c.pixelColor(w, 0, 0)
+			assert.Equal(t, 16, rays)
+			// the filters and the grid are symmetric, so the edge is half covered
+			assert.InDelta(t, 0.5, col.R, 1e-9)
+
+			// the weights are normalized, so a flat color stays the same
+			col, _ = c.pixelColor(w, 3, 0)
+			assert.True(t, color.Black.Equal(col))
+		})
+	}
+
+	// adaptive sampling only takes more samples where they disagree
+	c := newCamera("box", 0.01)
+
+	_, rays := c.pixelColor(w, 0, 0)
+	assert.Equal(t, 64, rays)
+
+	_, rays = c.pixelColor(w, 3, 0)
+	assert.Equal(t, 16, rays)
+
+	// the random choices of the path tracer come from the pixel, so it renders the same every time
+	glass := &material.PhongMat{Reflectivity: 1, Transparency: 1, IOR: 1.5, Color: color.White}
+	paths := &World{
+		Config: &WorldConfig{Integrator: "path", Samples: 4},
+		Geometry: []Primitive{
+			geometry.NewPlane(matrix.Translation(0, 0, -10), &material.ShadelessMat{Color: color.White}),
+			geometry.NewSphere(matrix.Translation(0, 0, -8).Mult(matrix.ScalingU(2)), glass),
+		},
+	}
+	c = newCamera("box", 0)
+
+	first, _ := c.pixelColor(paths, 1, 0)
+	second, _ := c.pixelColor(paths, 1, 0)
+	assert.Equal(t, first, second)
+}
+
+func TestCamera_GoRender(t *testing.T) {
+	w := &World{
+		Config:     &WorldConfig{},
+		Background: environment.NewSolid(color.White),
+	}
+
+	for _, order := range []string{"spiral", "hilbert"} {
+		t.Run(order, func(t *testing.T) {
+			// an image which doesn't divide evenly into tiles, or between the workers
+			c := NewCamera(&CameraConfig{
+				Height:     37,
+				Width:      23,
+				NumWorkers: 3,
+				TileSize:   8,
+				TileOrder:  order,
+			})
+
+			canv := c.GoRender(w)
+			assert.Equal(t, 37, canv.W)
+			assert.Equal(t, 23, canv.H)
+
+			for x := 0; x < canv.W; x++ {
+				for y := 0; y < canv.H; y++ {
+					assert.True(t, color.White.Equal(canv.Get(x, y)), "pixel %d, %d wasn't rendered", x, y)
+				}
+			}
+		})
+	}
+
+	c := NewCamera(&CameraConfig{Height: 1, Width: 1})
+	assert.Positive(t, c.config.NumWorkers)
+	assert.Equal(t, DefaultTileSize, c.config.TileSize)
+	assert.Equal(t, TileOrderSpiral, c.tileOrder)
+
+	assert.PanicsWithValue(t, "unrecognized tile order: zigzag", func() {
+		NewCamera(&CameraConfig{Height: 1, Width: 1, TileOrder: "zigzag"})
+	})
+}
+
+func TestCamera_GoRenderContext(t *testing.T) {
+	w := &World{
+		Config:     &WorldConfig{},
+		Background: environment.NewSolid(color.White),
+	}
+
+	newCamera := func() *Camera {
+		return NewCamera(&CameraConfig{
+			Height:     40,
+			Width:      30,
+			Samples:    2,
+			NumWorkers: 1,
+			TileSize:   10,
+		})
+	}
+
+	// every tile is reported, in order of completion
+	var reports []Progress
+	_, _, err := newCamera().GoRenderContext(context.Background(), w, nil, func(p Progress) {
+		reports = append(reports, p)
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, reports, 12)
+	for i, p := range reports {
+		assert.Equal(t, 12, p.Tiles)
+		assert.Equal(t, i+1, p.TilesDone)
+		assert.Equal(t, int64((i+1)*10*10*2), p.Rays)
+	}
+	assert.Equal(t, 1.0, reports[11].Fraction())
+
+	// cancelling the render stops it after the tiles which were started, and keeps what was rendered
+	ctx, cancel := context.WithCancel(context.Background())
+	done := 0
+	canv, _, err := newCamera().GoRenderContext(ctx, w, nil, func(p Progress) {
+		done = p.TilesDone
+		cancel()
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, done, 12)
+
+	rendered := 0
+	for _, px := range canv.Pix {
+		if px.R == 1 {
+			rendered++
+		}
+	}
+	assert.GreaterOrEqual(t, rendered, 10*10)
+	assert.Less(t, rendered, 40*30)
+}
@@ -1,13 +1,19 @@
 package renderer
 
 import (
+	"path/filepath"
 	"strconv"
+	"strings"
 
+	"github.com/Henelik/tricaster/pkg/canvas"
 	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/environment"
 	"github.com/Henelik/tricaster/pkg/geometry"
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/obj"
 	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/tuple"
 )
@@ -17,47 +23,335 @@ type Configuration struct {
 	World   WorldConfig    `yaml:"world"`
 	Camera  CameraConfig   `yaml:"camera"`
 	Objects []ObjectConfig `yaml:"objects"`
+	Output  OutputConfig   `yaml:"output"`
 }
 
 // world
 
 type WorldConfig struct {
-	Shadows   bool        `yaml:"shadows"`
-	MaxBounce int         `yaml:"max_bounce"`
-	Light     LightConfig `yaml:"light"`
+	Shadows bool `yaml:"shadows"`
+	// ShadowStrength is the fraction of blocked light which is removed from shadows, and defaults to 1
+	ShadowStrength *float64 `yaml:"shadow_strength"`
+	MaxBounce      int      `yaml:"max_bounce"`
+	// Light is a single light, kept for older scene files
+	Light  *LightConfig  `yaml:"light"`
+	Lights []LightConfig `yaml:"lights"`
+	// Integrator is either whitted (the default) or path
+	Integrator string `yaml:"integrator"`
+	// Samples is the number of paths traced for each camera ray by the path integrator
+	Samples int `yaml:"samples"`
+	// Background is seen behind the scene, and is black if not set
+	Background *BackgroundConfig `yaml:"background"`
+	// Ambient is the color of the light which reaches every surface evenly,
+	// and defaults to the sum of the point, spot and directional lights' colors
+	Ambient *ColorConfig `yaml:"ambient"`
 }
 
 func (w *WorldConfig) ToWorld() *World {
-	return &World{
-		Light:  w.Light.ToLight(),
+	switch w.Integrator {
+	case "", "whitted", "path":
+	default:
+		panic("unrecognized integrator: " + w.Integrator)
+	}
+
+	lights := make([]light.Light, 0, len(w.Lights)+1)
+
+	if w.Light != nil {
+		lights = append(lights, w.Light.ToLight())
+	}
+
+	for _, l := range w.Lights {
+		lights = append(lights, l.ToLight())
+	}
+
+	world := &World{
+		Lights: lights,
 		Config: w,
 	}
+
+	if w.Background != nil {
+		world.Background = w.Background.ToEnvironment()
+		world.BackgroundLighting = w.Background.Lighting
+	}
+
+	if w.Ambient != nil {
+		world.Ambient = w.Ambient.ToColor()
+	}
+
+	return world
+}
+
+// background
+
+type BackgroundConfig struct {
+	// Type is color, gradient or image
+	Type  string
+	Color ColorConfig
+	// Bottom and Top are the colors straight down and straight up in a gradient
+	Bottom ColorConfig
+	Top    ColorConfig
+	// File is an equirectangular png, jpeg or radiance hdr image
+	File string
+	// Strength scales the brightness of an image, and defaults to 1
+	Strength float64
+	// Rotation turns an image around the up axis, in radians
+	Rotation float64
+	// Lighting lets the background light the scene in path traced renders
+	Lighting bool
+}
+
+func (b *BackgroundConfig) ToEnvironment() environment.Environment {
+	switch b.Type {
+	case "", "color":
+		return environment.NewSolid(b.Color.ToColor())
+	case "gradient":
+		return environment.NewGradient(b.Bottom.ToColor(), b.Top.ToColor())
+	case "image":
+		c, err := canvas.LoadImage(b.File)
+		if err != nil {
+			panic("can't load background image: " + err.Error())
+		}
+
+		strength := b.Strength
+		if strength == 0 {
+			strength = 1
+		}
+
+		return environment.NewImage(c, strength, b.Rotation)
+	default:
+		panic("unrecognized background type: " + b.Type)
+	}
+}
+
+// shadowStrength returns the configured shadow strength, which defaults to 1
+func (w *WorldConfig) shadowStrength() float64 {
+	if w.ShadowStrength == nil {
+		return 1
+	}
+
+	return *w.ShadowStrength
 }
 
 // light
 
 type LightConfig struct {
-	Color    ColorConfig
-	Position PointConfig
+	Type      string
+	Color     ColorConfig
+	Position  PointConfig
+	Direction VectorConfig
+	// Angle is the half-angle of a spot light's cone, in radians
+	Angle float64
+	// Falloff is the width of a spot light's soft edge, in radians
+	Falloff float64
+	// U and V are the edges of a rect light
+	U VectorConfig
+	V VectorConfig
+	// Radius is the size of a sphere light
+	Radius float64
+	// Steps is the number of samples along each side of an area light,
+	// so each shadow test uses steps*steps rays
+	Steps int
+}
+
+func (l *LightConfig) ToLight() light.Light {
+	switch l.Type {
+	case "", "point":
+		return &light.PointLight{
+			Pos:   l.Position.ToPoint(),
+			Color: l.Color.ToColor(),
+		}
+	case "directional":
+		return light.NewDirectionalLight(l.Direction.ToVector(), l.Color.ToColor())
+	case "spot":
+		return light.NewSpotLight(
+			l.Position.ToPoint(),
+			l.Direction.ToVector(),
+			l.Angle,
+			l.Falloff,
+			l.Color.ToColor())
+	case "rect":
+		return light.NewRectLight(
+			l.Position.ToPoint(),
+			l.U.ToVector(),
+			l.V.ToVector(),
+			l.Steps,
+			l.Color.ToColor())
+	case "sphere":
+		return light.NewSphereLight(l.Position.ToPoint(), l.Radius, l.Steps, l.Color.ToColor())
+	default:
+		panic("unrecognized light type: " + l.Type)
+	}
+}
+
+// output
+
+type OutputConfig struct {
+	// File is the path of the render, and its extension decides the image format.
+	// It defaults to the scene name with the extension of Format.
+	File string
+	// Format is png, jpg, ppm, tiff, pfm, hdr or exr, and defaults to png
+	Format string
+	// ToneMap is linear, reinhard or aces, and applies to every format except pfm, hdr and exr
+	ToneMap string `yaml:"tone_map"`
+	// Exposure brightens tone mapped images by this many stops
+	Exposure float64
+	// BitDepth is 8 or 16 for png, ppm and tiff images
+	BitDepth int `yaml:"bit_depth"`
+	// Quality of jpg images, from 1 to 100
+	Quality int
+	// Plain writes ppm images as text instead of bytes
+	Plain bool
+	// AOVs are the extra passes to render: depth, normal, albedo, object_id and material_id.
+	// Each is saved next to the render, with its name added before the extension.
+	// Formats other than pfm, hdr and exr scale depths so the farthest hit is white, and map normals into 0 to 1.
+	AOVs []string `yaml:"aovs"`
+	// Layers saves the AOVs as layers of the render's exr file instead
+	Layers bool
 }
 
-func (l *LightConfig) ToLight() *light.PointLight {
-	return &light.PointLight{
-		Pos:   l.Position.ToPoint(),
-		Color: l.Color.ToColor(),
+// Extension returns the file extension of the output format
+func (o *OutputConfig) Extension() string {
+	if o.Format == "" {
+		return ".png"
 	}
+
+	ext := "." + strings.ToLower(o.Format)
+	if !isImageFormat(ext) {
+		panic("unrecognized output format: " + o.Format)
+	}
+
+	return ext
+}
+
+// Path returns the file the render of the named scene is saved to
+func (o *OutputConfig) Path(name string) string {
+	path := o.File
+	if path == "" {
+		path = name + o.Extension()
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if !isImageFormat(ext) {
+		panic("unrecognized output format: " + path)
+	}
+
+	if o.Layers && ext != ".exr" {
+		panic("aov layers need an exr file: " + path)
+	}
+
+	return path
+}
+
+func (o *OutputConfig) ToSaveOptions() canvas.SaveOptions {
+	if o.BitDepth != 0 && o.BitDepth != 8 && o.BitDepth != 16 {
+		panic("unsupported bit depth: " + strconv.Itoa(o.BitDepth))
+	}
+
+	if o.Quality < 0 || o.Quality > 100 {
+		panic("jpg quality out of range: " + strconv.Itoa(o.Quality))
+	}
+
+	return canvas.SaveOptions{
+		ToneMapping: o.ToToneMapping(),
+		BitDepth:    o.BitDepth,
+		Quality:     o.Quality,
+		Plain:       o.Plain,
+	}
+}
+
+func (o *OutputConfig) ToAOVs() []AOV {
+	aovs := make([]AOV, 0, len(o.AOVs))
+
+	for _, name := range o.AOVs {
+		a, ok := aovByName(name)
+		if !ok {
+			panic("unrecognized aov: " + name)
+		}
+
+		aovs = append(aovs, a)
+	}
+
+	return aovs
+}
+
+func (o *OutputConfig) ToToneMapping() canvas.ToneMapping {
+	t := canvas.ToneMapping{Exposure: o.Exposure}
+
+	switch o.ToneMap {
+	case "", "linear":
+		t.Operator = canvas.ToneLinear
+	case "reinhard":
+		t.Operator = canvas.ToneReinhard
+	case "aces":
+		t.Operator = canvas.ToneACES
+	default:
+		panic("unrecognized tone map: " + o.ToneMap)
+	}
+
+	return t
+}
+
+func isImageFormat(ext string) bool {
+	for _, format := range canvas.Formats {
+		if ext == format {
+			return true
+		}
+	}
+
+	return false
 }
 
 // camera
 
 type CameraConfig struct {
-	Height            int
-	Width             int
-	AALevel           int `yaml:"aa_level"`
-	NumWorkers        int `yaml:"num_workers"`
+	Height  int
+	Width   int
+	AALevel int `yaml:"aa_level"`
+	// NumWorkers is the number of tiles rendered at once, and defaults to the number of CPUs
+	NumWorkers int `yaml:"num_workers"`
+	// SubdivisionNumber is no longer used, since the image is divided into tiles of TileSize instead.
+	//
+	// Deprecated: set TileSize.
 	SubdivisionNumber int `yaml:"subdivision_number"`
-	FOV               float64
-	Transform         *ViewTransformConfig
+	// TileSize is the width and height of the tiles the workers render, in pixels, and defaults to 32
+	TileSize int `yaml:"tile_size"`
+	// TileOrder is spiral or hilbert, and defaults to spiral
+	TileOrder string `yaml:"tile_order"`
+	FOV       float64
+	Transform *ViewTransformConfig
+	// Projection is perspective, orthographic, fisheye or equirectangular, and defaults to perspective.
+	// A fisheye's FOV is the angle across the longer side of the image, which may be more than 180°,
+	// while an equirectangular panorama always sees every direction.
+	Projection string
+	// ViewWidth is the width of an orthographic view in world units,
+	// and defaults to the width a perspective camera would see at the focal distance
+	ViewWidth float64 `yaml:"view_width"`
+	// Aperture is the radius of the lens of a perspective camera. Objects away from the focal distance are blurred,
+	// unless it's 0 for a pinhole camera which keeps everything sharp.
+	Aperture float64
+	// FocalDistance is how far from the camera objects are sharpest,
+	// and defaults to the distance to the point the camera looks at
+	FocalDistance float64 `yaml:"focal_distance"`
+	// ApertureBlades shapes the lens as a regular polygon with this many sides, for polygonal bokeh,
+	// or as a disk if it's 0
+	ApertureBlades int `yaml:"aperture_blades"`
+	// LensSamples is the number of rays traced through different points of the lens, for each anti-aliasing sample
+	LensSamples int `yaml:"lens_samples"`
+	// Samples is the number of anti-aliasing samples taken in each pixel, and defaults to the square of AALevel
+	Samples int
+	// Sampler is stratified, grid, halton or sobol, and decides where in the pixel the samples are taken.
+	// It defaults to stratified.
+	Sampler string
+	// Filter is box, tent, gaussian or mitchell, and decides how the samples are weighted. It defaults to box.
+	Filter string
+	// FilterRadius is how far from the center of a pixel samples are taken, in pixels.
+	// It defaults to 0.5 for box, 1 for tent, 1.5 for gaussian and 2 for mitchell filters.
+	FilterRadius float64 `yaml:"filter_radius"`
+	// AdaptiveThreshold turns on adaptive sampling: pixels keep taking more samples
+	// while the standard error of their brightness is above it
+	AdaptiveThreshold float64 `yaml:"adaptive_threshold"`
+	// MaxSamples limits the samples taken in a pixel by adaptive sampling, and defaults to 4 times Samples
+	MaxSamples int `yaml:"max_samples"`
 }
 
 func (c *CameraConfig) ToCamera() *Camera {
@@ -70,6 +364,11 @@ type ViewTransformConfig struct {
 	Up   VectorConfig
 }
 
+// Distance returns the distance from the camera to the point it looks at
+func (v *ViewTransformConfig) Distance() float64 {
+	return tuple.NewPoint(v.To[0], v.To[1], v.To[2]).Sub(tuple.NewPoint(v.From[0], v.From[1], v.From[2])).Mag()
+}
+
 func (v *ViewTransformConfig) ToMatrix() *matrix.Matrix {
 	return matrix.ViewTransform(
 		tuple.NewPoint(v.From[0], v.From[1], v.From[2]),
@@ -87,25 +386,144 @@ type ObjectConfig struct {
 	Minimum   float64
 	Maximum   float64
 	Capped    bool
+	Vertices  []PointConfig
+	Normals   []VectorConfig
+	File      string
+	// Operation, Left and Right describe a CSG
+	Operation string
+	Left      *ObjectConfig
+	Right     *ObjectConfig
+	// Children are the objects in a group, which inherit its material if they don't have their own
+	Children []ObjectConfig
+	// CastsShadow can be set to false to stop the object blocking light, and applies to all of a group
+	CastsShadow *bool `yaml:"casts_shadow"`
 }
 
 func (o *ObjectConfig) ToPrimitive() Primitive {
+	return o.toPrimitive(nil)
+}
+
+// toPrimitive builds the object, which takes the inherited material of its group if it has none of its own
+func (o *ObjectConfig) toPrimitive(inherited material.Material) Primitive {
+	p := o.newPrimitive(inherited)
+
+	if o.CastsShadow != nil {
+		p.SetCastsShadow(*o.CastsShadow)
+	}
+
+	return p
+}
+
+func (o *ObjectConfig) newPrimitive(inherited material.Material) Primitive {
 	switch o.Type {
 	case "sphere":
-		return geometry.NewSphere(o.Transform.ToMatrix(), o.Material.ToMaterial())
+		return geometry.NewSphere(o.Transform.ToMatrix(), o.toMaterial(inherited))
 	case "cube":
-		return geometry.NewCube(o.Transform.ToMatrix(), o.Material.ToMaterial())
+		return geometry.NewCube(o.Transform.ToMatrix(), o.toMaterial(inherited))
 	case "plane":
-		return geometry.NewPlane(o.Transform.ToMatrix(), o.Material.ToMaterial())
+		return geometry.NewPlane(o.Transform.ToMatrix(), o.toMaterial(inherited))
 	case "cylinder":
-		return geometry.NewCylinder(o.Minimum, o.Maximum, o.Capped, o.Transform.ToMatrix(), o.Material.ToMaterial())
+		return geometry.NewCylinder(o.Minimum, o.Maximum, o.Capped, o.Transform.ToMatrix(), o.toMaterial(inherited))
 	case "cone":
-		return geometry.NewCone(o.Minimum, o.Maximum, o.Capped, o.Transform.ToMatrix(), o.Material.ToMaterial())
+		return geometry.NewCone(o.Minimum, o.Maximum, o.Capped, o.Transform.ToMatrix(), o.toMaterial(inherited))
+	case "triangle":
+		if len(o.Vertices) != 3 {
+			panic("triangle needs exactly 3 vertices, got " + strconv.Itoa(len(o.Vertices)))
+		}
+
+		return geometry.NewTriangle(
+			o.Vertices[0].ToPoint(),
+			o.Vertices[1].ToPoint(),
+			o.Vertices[2].ToPoint(),
+			o.Transform.ToOptionalMatrix(),
+			o.toMaterial(inherited))
+	case "smooth_triangle":
+		if len(o.Vertices) != 3 {
+			panic("smooth_triangle needs exactly 3 vertices, got " + strconv.Itoa(len(o.Vertices)))
+		}
+
+		if len(o.Normals) != 3 {
+			panic("smooth_triangle needs exactly 3 normals, got " + strconv.Itoa(len(o.Normals)))
+		}
+
+		return geometry.NewSmoothTriangle(
+			o.Vertices[0].ToPoint(),
+			o.Vertices[1].ToPoint(),
+			o.Vertices[2].ToPoint(),
+			o.Normals[0].ToVector().Norm(),
+			o.Normals[1].ToVector().Norm(),
+			o.Normals[2].ToVector().Norm(),
+			o.Transform.ToOptionalMatrix(),
+			o.toMaterial(inherited))
+	case "obj":
+		// the material is optional, and only applies to faces without a usemtl statement
+		group, err := obj.ParseFile(o.File, o.optionalMaterial(inherited))
+		if err != nil {
+			panic("can't load obj file: " + err.Error())
+		}
+
+		if m := o.Transform.ToOptionalMatrix(); m != nil {
+			group.SetMatrix(m)
+		}
+
+		return group
+	case "csg":
+		if o.Left == nil || o.Right == nil {
+			panic("csg needs both a left and a right object")
+		}
+
+		mat := o.optionalMaterial(inherited)
+
+		return geometry.NewCSG(
+			o.ToCSGOperation(),
+			o.Left.toPrimitive(mat),
+			o.Right.toPrimitive(mat),
+			o.Transform.ToOptionalMatrix())
+	case "group":
+		mat := o.optionalMaterial(inherited)
+
+		group := geometry.NewBasicGroup(o.Transform.ToOptionalMatrix(), nil)
+		for i := range o.Children {
+			group.AddChild(o.Children[i].toPrimitive(mat))
+		}
+
+		return group
 	default:
 		panic("unknown object type: " + o.Type)
 	}
 }
 
+// toMaterial returns the object's material, or the inherited one if it doesn't have its own
+func (o *ObjectConfig) toMaterial(inherited material.Material) material.Material {
+	if o.Material.Type == "" && inherited != nil {
+		return inherited
+	}
+
+	return o.Material.ToMaterial()
+}
+
+// optionalMaterial returns the material of a container, which may be nil
+func (o *ObjectConfig) optionalMaterial(inherited material.Material) material.Material {
+	if o.Material.Type == "" {
+		return inherited
+	}
+
+	return o.Material.ToMaterial()
+}
+
+func (o *ObjectConfig) ToCSGOperation() geometry.CSGOperation {
+	switch o.Operation {
+	case "union":
+		return geometry.CSGUnion
+	case "intersection":
+		return geometry.CSGIntersection
+	case "difference":
+		return geometry.CSGDifference
+	default:
+		panic("unrecognized csg operation: " + o.Operation)
+	}
+}
+
 // material
 
 type MaterialConfig struct {
@@ -119,6 +537,21 @@ type MaterialConfig struct {
 	IOR          float64
 	Color        ColorConfig
 	Pattern      *PatternConfig
+	// Emission is the light given off by a phong surface
+	Emission ColorConfig
+	// Strength scales the light given off by an emissive, phong or pbr material, and defaults to 1
+	Strength float64
+	// Metallic, Roughness, SpecularTint, Clearcoat and ClearcoatRoughness are used by pbr materials
+	Metallic           float64
+	Roughness          float64
+	SpecularTint       float64 `yaml:"specular_tint"`
+	Clearcoat          float64
+	ClearcoatRoughness float64 `yaml:"clearcoat_roughness"`
+	// Absorption is the color of white light after traveling AbsorptionDistance through a transparent phong material
+	Absorption         ColorConfig
+	AbsorptionDistance float64 `yaml:"absorption_distance"`
+	// Bump perturbs the normals of a phong or pbr material
+	Bump *BumpConfig
 }
 
 func (m *MaterialConfig) ToMaterial() material.Material {
@@ -140,12 +573,98 @@ func (m *MaterialConfig) ToMaterial() material.Material {
 			mat.Pattern = m.Pattern.ToPattern()
 		}
 
+		if m.Emission != [3]float64{0, 0, 0} {
+			mat.Emission = m.Emission.ToColor().MultF(m.strength())
+		}
+
+		if m.Absorption != [3]float64{0, 0, 0} {
+			mat.Absorption = m.Absorption.ToColor()
+			mat.AbsorptionDistance = m.AbsorptionDistance
+		}
+
+		if m.Bump != nil {
+			mat.Bump = m.Bump.ToBump()
+		}
+
+		return mat
+	case "pbr":
+		mat := &material.PBRMat{
+			Ambient:            m.Ambient,
+			Color:              m.Color.ToColor(),
+			Metallic:           m.Metallic,
+			Roughness:          m.Roughness,
+			SpecularTint:       m.SpecularTint,
+			Clearcoat:          m.Clearcoat,
+			ClearcoatRoughness: m.ClearcoatRoughness,
+			IOR:                m.IOR,
+		}
+
+		if mat.IOR == 0 {
+			mat.IOR = material.DefaultPBRIOR
+		}
+
+		if m.Pattern != nil {
+			mat.Pattern = m.Pattern.ToPattern()
+		}
+
+		if m.Emission != [3]float64{0, 0, 0} {
+			mat.Emission = m.Emission.ToColor().MultF(m.strength())
+		}
+
+		if m.Bump != nil {
+			mat.Bump = m.Bump.ToBump()
+		}
+
+		return mat
+	case "shadeless":
+		mat := &material.ShadelessMat{
+			Color: m.Color.ToColor(),
+		}
+
+		if m.Pattern != nil {
+			mat.Pattern = m.Pattern.ToPattern()
+		}
+
+		return mat
+	case "emissive":
+		mat := material.NewEmissiveMat(m.Color.ToColor(), m.strength())
+
+		if m.Pattern != nil {
+			mat.Pattern = m.Pattern.ToPattern()
+		}
+
 		return mat
 	default:
 		panic("unrecognized material type: " + m.Type)
 	}
 }
 
+// bump
+
+type BumpConfig struct {
+	// Type is height, which raises the bright parts of the pattern, or normal, which reads a normal map
+	Type string
+	// Strength scales the height or the lean of the normals, and defaults to 1
+	Strength float64
+	Pattern  PatternConfig
+}
+
+func (b *BumpConfig) ToBump() material.Bump {
+	strength := b.Strength
+	if strength == 0 {
+		strength = 1
+	}
+
+	switch b.Type {
+	case "", "height":
+		return material.NewHeightBump(b.Pattern.ToPattern(), strength)
+	case "normal":
+		return material.NewNormalMap(b.Pattern.ToPattern(), strength)
+	default:
+		panic("unrecognized bump type: " + b.Type)
+	}
+}
+
 // transform
 
 type TransformConfig struct {
@@ -168,6 +687,16 @@ func (t *TransformConfig) ToMatrix() *matrix.Matrix {
 	)
 }
 
+// ToOptionalMatrix is like ToMatrix, but returns nil for an empty transform
+// so objects which are positioned by other means can omit it.
+func (t *TransformConfig) ToOptionalMatrix() *matrix.Matrix {
+	if *t == (TransformConfig{}) {
+		return nil
+	}
+
+	return t.ToMatrix()
+}
+
 // tuples
 
 type PointConfig [3]float64
@@ -184,6 +713,15 @@ func (v *VectorConfig) ToVector() *tuple.Tuple {
 
 // color
 
+// strength returns the configured emission strength, which defaults to 1
+func (m *MaterialConfig) strength() float64 {
+	if m.Strength == 0 {
+		return 1
+	}
+
+	return m.Strength
+}
+
 type ColorConfig [3]float64
 
 func (config *ColorConfig) ToColor() *color.Color {
@@ -197,6 +735,22 @@ type PatternConfig struct {
 	Color       ColorConfig
 	Transform   TransformConfig
 	SubPatterns []PatternConfig `yaml:"sub_patterns"`
+	// Mapping wraps 2D patterns onto objects, and defaults to the surface coordinates of the object
+	Mapping string
+	Width   float64
+	Height  float64
+	Colors  []ColorConfig
+	// File is the PNG or JPEG image of an image pattern, which is sampled with Filter (bilinear or nearest)
+	// and repeated or clamped outside of the image depending on Wrap (repeat or clamp)
+	File   string
+	Filter string
+	Wrap   string
+	// Scale is the size of the features of noise patterns, and defaults to 1.
+	// Amount is how far the noise moves perturbed points, marble veins or wood rings.
+	Scale   float64
+	Amount  float64
+	Octaves int
+	Seed    int64
 }
 
 func (p *PatternConfig) ToPattern() pattern.Pattern {
@@ -291,7 +845,162 @@ func (p *PatternConfig) ToPattern() pattern.Pattern {
 			p.Transform.ToMatrix(),
 			subPatterns...)
 
+	case "uv_checker":
+		numColors := len(p.Colors)
+
+		if numColors < 2 {
+			panic("not enough colors for uv_checker: " + strconv.Itoa(numColors))
+		}
+
+		return pattern.NewUVPattern(
+			p.Transform.ToOptionalMatrix(),
+			p.uvMapping(),
+			pattern.NewUVChecker(p.Width, p.Height, p.Colors[0].ToColor(), p.Colors[1].ToColor()))
+
+	case "uv_align_check":
+		numColors := len(p.Colors)
+
+		if numColors < 5 {
+			panic("not enough colors for uv_align_check: " + strconv.Itoa(numColors))
+		}
+
+		return pattern.NewUVPattern(
+			p.Transform.ToOptionalMatrix(),
+			p.uvMapping(),
+			pattern.NewUVAlignCheck(
+				p.Colors[0].ToColor(),
+				p.Colors[1].ToColor(),
+				p.Colors[2].ToColor(),
+				p.Colors[3].ToColor(),
+				p.Colors[4].ToColor()))
+
+	case "noise":
+		numSub := len(p.SubPatterns)
+
+		if numSub < 2 {
+			panic("not enough sub-patterns for noise: " + strconv.Itoa(numSub))
+		}
+
+		return pattern.NewNoisePattern(
+			p.Transform.ToOptionalMatrix(),
+			p.SubPatterns[0].ToPattern(),
+			p.SubPatterns[1].ToPattern(),
+			noise.NewPerlin(p.Seed),
+			p.noiseScale(),
+			p.octaves())
+
+	case "perturb":
+		numSub := len(p.SubPatterns)
+
+		if numSub < 1 {
+			panic("not enough sub-patterns for perturb: " + strconv.Itoa(numSub))
+		}
+
+		return pattern.NewPerturbPattern(
+			p.Transform.ToOptionalMatrix(),
+			p.SubPatterns[0].ToPattern(),
+			noise.NewPerlin(p.Seed),
+			p.noiseScale(),
+			p.Amount,
+			p.octaves())
+
+	case "marble":
+		numSub := len(p.SubPatterns)
+
+		if numSub < 2 {
+			panic("not enough sub-patterns for marble: " + strconv.Itoa(numSub))
+		}
+
+		return pattern.NewMarblePattern(
+			p.Transform.ToOptionalMatrix(),
+			p.SubPatterns[0].ToPattern(),
+			p.SubPatterns[1].ToPattern(),
+			noise.NewPerlin(p.Seed),
+			p.noiseScale(),
+			p.Amount,
+			p.octaves())
+
+	case "wood":
+		numSub := len(p.SubPatterns)
+
+		if numSub < 2 {
+			panic("not enough sub-patterns for wood: " + strconv.Itoa(numSub))
+		}
+
+		return pattern.NewWoodPattern(
+			p.Transform.ToOptionalMatrix(),
+			p.SubPatterns[0].ToPattern(),
+			p.SubPatterns[1].ToPattern(),
+			noise.NewPerlin(p.Seed),
+			p.noiseScale(),
+			p.Amount,
+			p.octaves())
+
+	case "image":
+		c, err := canvas.LoadImage(p.File)
+		if err != nil {
+			panic("can't load pattern image: " + err.Error())
+		}
+
+		return pattern.NewUVPattern(
+			p.Transform.ToOptionalMatrix(),
+			p.uvMapping(),
+			pattern.NewUVImage(c, p.imageFilter(), p.imageWrap()))
+
 	default:
 		panic("unrecognized pattern type: " + p.Type)
 	}
 }
+
+func (p *PatternConfig) noiseScale() float64 {
+	if p.Scale == 0 {
+		return 1
+	}
+	return p.Scale
+}
+
+func (p *PatternConfig) octaves() int {
+	if p.Octaves == 0 {
+		return 4
+	}
+	return p.Octaves
+}
+
+func (p *PatternConfig) imageFilter() pattern.ImageFilter {
+	switch p.Filter {
+	case "", "bilinear":
+		return pattern.FilterBilinear
+	case "nearest":
+		return pattern.FilterNearest
+	default:
+		panic("unrecognized image filter: " + p.Filter)
+	}
+}
+
+func (p *PatternConfig) imageWrap() pattern.ImageWrap {
+	switch p.Wrap {
+	case "", "repeat":
+		return pattern.WrapRepeat
+	case "clamp":
+		return pattern.WrapClamp
+	default:
+		panic("unrecognized image wrap: " + p.Wrap)
+	}
+}
+
+func (p *PatternConfig) uvMapping() pattern.UVMapping {
+	switch p.Mapping {
+	case "", "uv":
+		return nil
+	case "spherical":
+		return pattern.SphericalMap
+	case "planar":
+		return pattern.PlanarMap
+	case "cylindrical":
+		return pattern.CylindricalMap
+	case "cubic":
+		return pattern.CubicMap
+	default:
+		panic("unrecognized uv mapping: " + p.Mapping)
+	}
+}
@@ -0,0 +1,530 @@
+package renderer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/canvas"
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/environment"
+	"github.com/Henelik/tricaster/pkg/geometry"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/noise"
+	"github.com/Henelik/tricaster/pkg/pattern"
+	"github.com/Henelik/tricaster/pkg/sampling"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const groupConfig = `
+type: group
+material:
+  type: phong
+  color: [1, 0, 0]
+transform:
+  position: [0, 0, 5]
+  scale: [2, 2, 2]
+children:
+  - type: sphere
+    transform:
+      position: [0, 0, 0]
+      scale: [1, 1, 1]
+  - type: cube
+    material:
+      type: phong
+      color: [0, 0, 1]
+    transform:
+      position: [5, 0, 0]
+      scale: [1, 1, 1]
+`
+
+func TestObjectConfig_Group(t *testing.T) {
+	config := new(ObjectConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(groupConfig), config))
+
+	group, ok := config.ToPrimitive().(*geometry.BasicGroup)
+	assert.True(t, ok)
+	assert.Len(t, group.Children, 2)
+
+	sphere := group.Children[0].(*geometry.Sphere)
+	cube := group.Children[1].(*geometry.Cube)
+
+	// the sphere inherits the group's material, and the cube keeps its own
+	assert.Equal(t, color.NewColor(1, 0, 0), sphere.GetMaterial().(*material.PhongMat).Color)
+	assert.Equal(t, color.NewColor(0, 0, 1), cube.GetMaterial().(*material.PhongMat).Color)
+
+	// the group's transform applies to its children
+	assert.True(t, tuple.NewVector(0, 0, 1).Equal(sphere.NormalAt(tuple.NewPoint(0, 0, 7))))
+	assert.True(t, tuple.NewVector(1, 0, 0).Equal(cube.NormalAt(tuple.NewPoint(12, 0, 5))))
+}
+
+const triangleConfig = `
+type: triangle
+material:
+  type: phong
+  color: [1, 1, 1]
+vertices:
+  - [0, 1, 0]
+  - [-1, 0, 0]
+  - [1, 0, 0]
+transform:
+  position: [0, 0, 2]
+  scale: [1, 1, 1]
+`
+
+const smoothTriangleConfig = `
+type: smooth_triangle
+material:
+  type: phong
+  color: [1, 1, 1]
+vertices:
+  - [0, 1, 0]
+  - [-1, 0, 0]
+  - [1, 0, 0]
+normals:
+  - [0, 2, 0]
+  - [-1, 0, 0]
+  - [1, 0, 0]
+`
+
+func TestObjectConfig_Triangles(t *testing.T) {
+	t.Run("triangle", func(t *testing.T) {
+		config := new(ObjectConfig)
+		assert.NoError(t, yaml.Unmarshal([]byte(triangleConfig), config))
+
+		tri, ok := config.ToPrimitive().(*geometry.Triangle)
+		assert.True(t, ok)
+		assert.Equal(t, tuple.NewPoint(-1, 0, 0), tri.P2)
+		// the transform moves the triangle
+		assert.True(t, tuple.NewPoint(-1, 0, 2).Equal(tri.Bounds().Min))
+	})
+
+	t.Run("smooth_triangle", func(t *testing.T) {
+		config := new(ObjectConfig)
+		assert.NoError(t, yaml.Unmarshal([]byte(smoothTriangleConfig), config))
+
+		tri, ok := config.ToPrimitive().(*geometry.SmoothTriangle)
+		assert.True(t, ok)
+		assert.Equal(t, tuple.NewPoint(1, 0, 0), tri.P3)
+		// the normals are normalized
+		assert.True(t, tuple.NewVector(0, 1, 0).Equal(tri.N1))
+	})
+
+	t.Run("missing normals", func(t *testing.T) {
+		config := new(ObjectConfig)
+		assert.NoError(t, yaml.Unmarshal([]byte(triangleConfig), config))
+		config.Type = "smooth_triangle"
+
+		assert.Panics(t, func() { config.ToPrimitive() })
+	})
+}
+
+func TestBackgroundConfig_ToEnvironment(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config string
+		want   environment.Environment
+	}{
+		{
+			name:   "color",
+			config: "color: [0.1, 0.2, 0.3]",
+			want:   environment.NewSolid(color.NewColor(0.1, 0.2, 0.3)),
+		},
+		{
+			name:   "gradient",
+			config: "type: gradient\nbottom: [1, 1, 1]\ntop: [0, 0, 1]",
+			want:   environment.NewGradient(color.White, color.NewColor(0, 0, 1)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := new(BackgroundConfig)
+			assert.NoError(t, yaml.Unmarshal([]byte(tc.config), config))
+
+			assert.Equal(t, tc.want, config.ToEnvironment())
+		})
+	}
+
+	assert.Panics(t, func() {
+		(&BackgroundConfig{Type: "stars"}).ToEnvironment()
+	})
+
+	assert.Panics(t, func() {
+		(&BackgroundConfig{Type: "image", File: "missing.hdr"}).ToEnvironment()
+	})
+}
+
+const pbrConfig = `
+type: pbr
+color: [1, 0.8, 0.2]
+metallic: 1
+roughness: 0.3
+specular_tint: 0.5
+clearcoat: 0.8
+clearcoat_roughness: 0.1
+`
+
+func TestMaterialConfig_PBR(t *testing.T) {
+	config := new(MaterialConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(pbrConfig), config))
+
+	assert.Equal(t, &material.PBRMat{
+		Color:              color.NewColor(1, 0.8, 0.2),
+		Metallic:           1,
+		Roughness:          0.3,
+		SpecularTint:       0.5,
+		Clearcoat:          0.8,
+		ClearcoatRoughness: 0.1,
+		IOR:                material.DefaultPBRIOR,
+	}, config.ToMaterial())
+}
+
+const absorptionConfig = `
+type: phong
+transparency: 1
+ior: 1.5
+color: [1, 1, 1]
+absorption: [0.2, 0.6, 0.9]
+absorption_distance: 3
+`
+
+func TestMaterialConfig_Absorption(t *testing.T) {
+	config := new(MaterialConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(absorptionConfig), config))
+
+	mat := config.ToMaterial().(*material.PhongMat)
+
+	assert.Equal(t, color.NewColor(0.2, 0.6, 0.9), mat.Absorption)
+	assert.Equal(t, 3.0, mat.AbsorptionDistance)
+}
+
+const shadowlessConfig = `
+type: group
+casts_shadow: false
+material:
+  type: phong
+  color: [1, 1, 1]
+children:
+  - type: sphere
+    transform:
+      position: [0, 0, 0]
+      scale: [1, 1, 1]
+  - type: csg
+    operation: union
+    left:
+      type: cube
+      transform:
+        position: [3, 0, 0]
+        scale: [1, 1, 1]
+    right:
+      type: sphere
+      transform:
+        position: [3, 0, 1]
+        scale: [1, 1, 1]
+`
+
+func TestObjectConfig_CastsShadow(t *testing.T) {
+	config := new(ObjectConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(shadowlessConfig), config))
+
+	group := config.ToPrimitive().(*geometry.BasicGroup)
+	csg := group.Children[1].(*geometry.CSG)
+
+	// the setting reaches every primitive in the group
+	assert.False(t, group.Children[0].(*geometry.Sphere).CastsShadow())
+	assert.False(t, csg.Left.(*geometry.Cube).CastsShadow())
+	assert.False(t, csg.Right.(*geometry.Sphere).CastsShadow())
+
+	config.CastsShadow = nil
+
+	assert.True(t, config.ToPrimitive().(*geometry.BasicGroup).Children[0].(*geometry.Sphere).CastsShadow())
+}
+
+func TestWorldConfig_ShadowStrength(t *testing.T) {
+	config := new(WorldConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte("shadows: true"), config))
+	assert.Equal(t, 1.0, config.shadowStrength())
+
+	assert.NoError(t, yaml.Unmarshal([]byte("shadow_strength: 0"), config))
+	assert.Equal(t, 0.0, config.shadowStrength())
+
+	assert.NoError(t, yaml.Unmarshal([]byte("shadow_strength: 0.5"), config))
+	assert.Equal(t, 0.5, config.shadowStrength())
+}
+
+func TestWorldConfig_Ambient(t *testing.T) {
+	config := new(WorldConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte("ambient: [0.1, 0.2, 0.3]"), config))
+	assert.Equal(t, color.NewColor(0.1, 0.2, 0.3), config.ToWorld().Ambient)
+
+	// without it, the ambient light comes from the lights
+	assert.Nil(t, new(WorldConfig).ToWorld().Ambient)
+}
+
+func TestPatternConfig_UV(t *testing.T) {
+	config := new(PatternConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+type: uv_checker
+mapping: spherical
+width: 16
+height: 8
+colors:
+  - [0, 0, 0]
+  - [1, 1, 1]
+`), config))
+
+	p := config.ToPattern().(*pattern.UVPattern)
+	assert.NotNil(t, p.Mapping)
+	assert.Equal(t, pattern.NewUVChecker(16, 8, color.Black, color.White), p.Texture)
+
+	config.Mapping = ""
+	assert.Nil(t, config.ToPattern().(*pattern.UVPattern).Mapping)
+
+	config.Mapping = "conical"
+	assert.PanicsWithValue(t, "unrecognized uv mapping: conical", func() { config.ToPattern() })
+
+	config.Mapping = "cubic"
+	config.Type = "uv_align_check"
+	assert.PanicsWithValue(t, "not enough colors for uv_align_check: 2", func() { config.ToPattern() })
+}
+
+func TestPatternConfig_Image(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "texture.png")
+
+	c := canvas.NewCanvas(2, 1)
+	c.Set(0, 0, color.White)
+	assert.NoError(t, c.SaveImage(file))
+
+	// image patterns can be nested in other patterns
+	config := new(PatternConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+type: checker_3d
+transform:
+  scale: [1, 1, 1]
+sub_patterns:
+  - type: image
+    file: `+file+`
+    filter: nearest
+    wrap: clamp
+  - type: solid
+    color: [0, 0, 0]
+`), config))
+
+	checker := config.ToPattern().(*pattern.CheckerPattern3D)
+	p := checker.Patterns[0].(*pattern.UVPattern)
+	img := p.Texture.(*pattern.UVImage)
+
+	assert.Equal(t, pattern.FilterNearest, img.Filter)
+	assert.Equal(t, pattern.WrapClamp, img.Wrap)
+	assert.Equal(t, color.White, p.ProcessSurface(tuple.Origin, 0.25, 0.5))
+	assert.Equal(t, color.Black, p.ProcessSurface(tuple.Origin, 0.75, 0.5))
+
+	config.SubPatterns[0].File = filepath.Join(t.TempDir(), "missing.png")
+	assert.Panics(t, func() { config.ToPattern() })
+
+	config.SubPatterns[0].File = file
+	config.SubPatterns[0].Filter = "cubic"
+	assert.PanicsWithValue(t, "unrecognized image filter: cubic", func() { config.ToPattern() })
+}
+
+func TestPatternConfig_Noise(t *testing.T) {
+	config := new(PatternConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+type: perturb
+amount: 0.3
+seed: 7
+sub_patterns:
+  - type: marble
+    scale: 2
+    amount: 5
+    octaves: 6
+    sub_patterns:
+      - type: solid
+        color: [1, 1, 1]
+      - type: wood
+        sub_patterns:
+          - type: solid
+            color: [0.5, 0.3, 0.1]
+          - type: solid
+            color: [0.3, 0.2, 0.1]
+`), config))
+
+	perturb := config.ToPattern().(*pattern.PerturbPattern)
+	assert.Equal(t, 0.3, perturb.Amount)
+	assert.Equal(t, noise.NewPerlin(7), perturb.Noise)
+
+	marble := perturb.Pattern.(*pattern.MarblePattern)
+	assert.Equal(t, 2.0, marble.Scale)
+	assert.Equal(t, 6, marble.Octaves)
+
+	// scale and octaves have defaults
+	wood := marble.Pattern2.(*pattern.WoodPattern)
+	assert.Equal(t, 1.0, wood.Scale)
+	assert.Equal(t, 4, wood.Octaves)
+
+	config.SubPatterns = nil
+	assert.PanicsWithValue(t, "not enough sub-patterns for perturb: 0", func() { config.ToPattern() })
+}
+
+func TestMaterialConfig_Bump(t *testing.T) {
+	config := new(MaterialConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+type: pbr
+color: [0.8, 0.8, 0.8]
+bump:
+  strength: 0.02
+  pattern:
+    type: noise
+    scale: 0.1
+    sub_patterns:
+      - type: solid
+        color: [0, 0, 0]
+      - type: solid
+        color: [1, 1, 1]
+`), config))
+
+	bump := config.ToMaterial().(*material.PBRMat).Bump.(*material.HeightBump)
+	assert.Equal(t, 0.02, bump.Strength)
+	assert.IsType(t, &pattern.NoisePattern{}, bump.Pattern)
+
+	config.Type = "phong"
+	config.Bump.Type = "normal"
+	config.Bump.Strength = 0
+	normalMap := config.ToMaterial().(*material.PhongMat).Bump.(*material.NormalMap)
+	assert.Equal(t, 1.0, normalMap.Strength)
+
+	config.Bump.Type = "displacement"
+	assert.PanicsWithValue(t, "unrecognized bump type: displacement", func() { config.ToMaterial() })
+}
+
+func TestOutputConfig(t *testing.T) {
+	config := new(Configuration)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+name: test
+output:
+  file: renders/test.TIFF
+  tone_map: aces
+  exposure: -1.5
+  bit_depth: 16
+`), config))
+
+	assert.Equal(t, "renders/test.TIFF", config.Output.Path(config.Name))
+	assert.Equal(t, canvas.SaveOptions{
+		ToneMapping: canvas.ToneMapping{Operator: canvas.ToneACES, Exposure: -1.5},
+		BitDepth:    16,
+	}, config.Output.ToSaveOptions())
+
+	// without a file, the format is added to the scene name
+	output := OutputConfig{Format: "exr"}
+	assert.Equal(t, "test.exr", output.Path("test"))
+
+	// png and linear are the defaults
+	output = OutputConfig{}
+	assert.Equal(t, "test.png", output.Path("test"))
+	assert.Equal(t, canvas.ToneLinear, output.ToToneMapping().Operator)
+
+	output = OutputConfig{Format: "gif", ToneMap: "filmic"}
+	assert.PanicsWithValue(t, "unrecognized output format: gif", func() { output.Path("test") })
+	assert.PanicsWithValue(t, "unrecognized tone map: filmic", func() { output.ToToneMapping() })
+
+	output = OutputConfig{File: "test.bmp", BitDepth: 12, Quality: 101}
+	assert.PanicsWithValue(t, "unrecognized output format: test.bmp", func() { output.Path("test") })
+	assert.PanicsWithValue(t, "unsupported bit depth: 12", func() { output.ToSaveOptions() })
+
+	output.BitDepth = 8
+	assert.PanicsWithValue(t, "jpg quality out of range: 101", func() { output.ToSaveOptions() })
+}
+
+func TestOutputConfig_AOVs(t *testing.T) {
+	config := new(OutputConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+file: test.exr
+aovs: [depth, normal, object_id]
+layers: true
+`), config))
+
+	assert.Equal(t, []AOV{AOVDepth, AOVNormal, AOVObjectID}, config.ToAOVs())
+	assert.Equal(t, "test.exr", config.Path("scene"))
+
+	config.File = "test.png"
+	assert.PanicsWithValue(t, "aov layers need an exr file: test.png", func() { config.Path("scene") })
+
+	config.AOVs = append(config.AOVs, "motion")
+	assert.PanicsWithValue(t, "unrecognized aov: motion", func() { config.ToAOVs() })
+}
+
+func TestCameraConfig_Lens(t *testing.T) {
+	config := new(CameraConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+height: 10
+width: 10
+aperture: 0.2
+focal_distance: 7
+aperture_blades: 5
+lens_samples: 4
+transform:
+  from: [0, -10, 0]
+  to: [0, 0, 0]
+  up: [0, 0, 1]
+`), config))
+
+	c := config.ToCamera()
+	assert.Equal(t, 0.2, c.config.Aperture)
+	assert.Equal(t, 7.0, c.config.FocalDistance)
+	assert.Equal(t, 5, c.config.ApertureBlades)
+	assert.Equal(t, 4, c.config.LensSamples)
+	assert.Equal(t, 10.0, config.Transform.Distance())
+}
+
+func TestCameraConfig_Projection(t *testing.T) {
+	config := new(CameraConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+height: 20
+width: 10
+projection: orthographic
+view_width: 8
+`), config))
+
+	c := config.ToCamera()
+	assert.Equal(t, ProjectionOrthographic, c.projection)
+	assert.Equal(t, 0.4, c.pixelSize)
+}
+
+func TestCameraConfig_Sampling(t *testing.T) {
+	config := new(CameraConfig)
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+height: 10
+width: 10
+aa_level: 3
+sampler: halton
+filter: mitchell
+adaptive_threshold: 0.02
+`), config))
+
+	c := config.ToCamera()
+	assert.Equal(t, sampling.Halton{}, c.sampler)
+	assert.Equal(t, sampling.NewMitchellFilter(2, 1.0/3, 1.0/3), c.filter)
+	// any level of anti-aliasing works, not just powers of two
+	assert.Equal(t, 9, c.config.Samples)
+	assert.Equal(t, 36, c.config.MaxSamples)
+	assert.Equal(t, 0.02, c.config.AdaptiveThreshold)
+
+	// the defaults are stratified samples, averaged over the pixel
+	c = NewCamera(&CameraConfig{Height: 1, Width: 1})
+	assert.Equal(t, sampling.Stratified{}, c.sampler)
+	assert.Equal(t, sampling.NewBoxFilter(0.5), c.filter)
+	assert.Equal(t, 1, c.config.Samples)
+
+	c = NewCamera(&CameraConfig{Height: 1, Width: 1, Filter: "gaussian", FilterRadius: 3})
+	assert.Equal(t, 3.0, c.filter.Radius())
+
+	assert.PanicsWithValue(t, "unrecognized sampler: random", func() {
+		NewCamera(&CameraConfig{Height: 1, Width: 1, Sampler: "random"})
+	})
+	assert.PanicsWithValue(t, "unrecognized filter: lanczos", func() {
+		NewCamera(&CameraConfig{Height: 1, Width: 1, Filter: "lanczos"})
+	})
+}
@@ -0,0 +1,218 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/sampling"
+	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
+)
+
+const (
+	// DefaultPathSamples is the number of paths traced per camera ray if none is configured
+	DefaultPathSamples = 16
+	// pathMinBounces is the number of bounces before russian roulette may end a path
+	pathMinBounces = 3
+	// pathMaxBounces ends any path which survives russian roulette for too long
+	pathMaxBounces = 64
+)
+
+// PathColorAt estimates the light arriving along a ray by averaging several Monte Carlo paths,
+// which take their random choices from rnd
+func (w *World) PathColorAt(r *ray.Ray, samples int, rnd *sampling.Random) *color.Color {
+	cols := make([]*color.Color, samples)
+
+	for i := range cols {
+		cols[i] = w.TracePath(r, rnd)
+	}
+
+	return color.Avg(cols)
+}
+
+// TracePath follows a single random path from a ray.
+// Lights are sampled directly at every bounce, and the path continues with a cosine-weighted
+// diffuse bounce, a mirror reflection or a refraction, chosen in proportion to the material.
+// Materials which implement material.Sampler choose their own bounces.
+// The random choices come from rnd, so the same stream always traces the same path.
+func (w *World) TracePath(r *ray.Ray, rnd *sampling.Random) *color.Color {
+	result := color.Black
+	throughput := color.White
+
+	// glowing objects which light the scene are already sampled as lights after a diffuse bounce,
+	// so their emission is only counted when they are seen directly or through a mirror
+	countLights := true
+	// the background is seen directly, or through anything but a diffuse bounce
+	seeBackground := true
+	// medium is the object the path is traveling through, which changes when it crosses a surface
+	var medium ray.IORHaver
+
+	for bounce := 0; bounce < pathMaxBounces; bounce++ {
+		h, ok := w.HitAt(r)
+
+		if absorber, isAbsorber := mediumMaterial(medium).(material.Absorber); isAbsorber {
+			distance := math.Inf(1)
+			if ok {
+				distance = h.Inters[h.Index].T * r.Direction.Mag()
+			}
+
+			throughput = throughput.MultCol(absorber.Transmittance(distance))
+		}
+
+		if !ok {
+			// the background only lights the scene if it's enabled, but can always be seen
+			if seeBackground || w.BackgroundLighting {
+				result = result.Add(throughput.MultCol(w.BackgroundAt(r)))
+			}
+
+			break
+		}
+
+		primitive := h.Inters[h.Index].P.(Primitive)
+
+		if e, ok := primitive.GetMaterial().(material.Emitter); ok && (countLights || !e.IsLight()) {
+			result = result.Add(throughput.MultCol(e.EmissionAtHit(h)))
+		}
+
+		result = result.Add(throughput.MultCol(w.directLight(primitive, h)))
+
+		var (
+			next    *ray.Ray
+			weight  *color.Color
+			diffuse bool
+		)
+
+		switch mat := primitive.GetMaterial().(type) {
+		case *material.PhongMat:
+			next, weight, diffuse = w.scatter(mat, h, rnd)
+			countLights = !diffuse
+		case material.Sampler:
+			var dir *tuple.Tuple
+
+			dir, weight, diffuse = mat.Sample(h, rnd.Float64)
+			if dir != nil {
+				next = ray.NewRay(h.OverP, dir)
+			}
+
+			countLights = !diffuse
+		}
+
+		// materials without a physical model don't scatter light
+		if next == nil {
+			break
+		}
+
+		// the normal faces the side the path arrived from, so a ray against it has crossed the surface
+		if next.Direction.DotProd(h.NormalV) < 0 {
+			medium = h.To
+		}
+
+		throughput = throughput.MultCol(weight)
+		seeBackground = !diffuse
+		r = next
+
+		// russian roulette ends dim paths early, and boosts the survivors to stay unbiased
+		if bounce >= pathMinBounces {
+			survival := util.Min(maxComponent(throughput), 0.95)
+			if rnd.Float64() >= survival {
+				break
+			}
+
+			throughput = throughput.MultF(1 / survival)
+		}
+	}
+
+	return result
+}
+
+// directLight returns the light reaching a hit straight from the scene's lights
+func (w *World) directLight(primitive Primitive, h *ray.Hit) *color.Color {
+	result := color.Black
+
+	for _, l := range w.Lights {
+		if litBySelf(l, primitive) {
+			continue
+		}
+
+		if w.Config.Shadows {
+			w.shadow(l, h)
+		}
+
+		if mat, ok := primitive.GetMaterial().(directLighter); ok {
+			// ambient light is replaced by the light gathered from indirect bounces
+			result = result.Add(mat.Direct(l, h))
+		} else {
+			result = result.Add(primitive.Shade(l, h))
+		}
+	}
+
+	return result
+}
+
+// directLighter is implemented by materials which can leave out ambient light
+type directLighter interface {
+	Direct(l light.Light, h *ray.Hit) *color.Color
+}
+
+// scatter picks the next ray of a path, the weight of the light it carries, and whether it's a diffuse bounce.
+// Returns nil if the path is absorbed.
+func (w *World) scatter(mat *material.PhongMat, h *ray.Hit, rnd *sampling.Random) (*ray.Ray, *color.Color, bool) {
+	reflect := mat.Reflectivity
+	refract := mat.Transparency
+
+	if reflect > 0 && refract > 0 {
+		reflectance := h.Schlick()
+		reflect *= reflectance
+		refract *= 1 - reflectance
+	}
+
+	diffuse := mat.Diffuse * maxComponent(mat.ColorAtHit(h))
+
+	total := reflect + refract + diffuse
+	if total <= 0 {
+		return nil, nil, false
+	}
+
+	// each lobe is chosen in proportion to its weight, so the weight of the chosen lobe is the total
+	choice := rnd.Float64() * total
+
+	switch {
+	case choice < reflect:
+		return ray.NewRay(h.OverP, h.ReflectV), color.Grey(total), false
+	case choice < reflect+refract:
+		dir, ok := refractDirection(h)
+		if !ok {
+			// total internal reflection
+			return ray.NewRay(h.OverP, h.ReflectV), color.Grey(total), false
+		}
+
+		return ray.NewRay(h.UnderP, dir), color.Grey(total), false
+	default:
+		weight := mat.ColorAtHit(h).MultF(mat.Diffuse * total / diffuse)
+
+		return ray.NewRay(h.OverP, h.NormalV.CosineSample(rnd.Float64)), weight, true
+	}
+}
+
+// refractDirection returns the direction of a ray refracted at a hit,
+// or false if it is totally internally reflected
+func refractDirection(h *ray.Hit) (*tuple.Tuple, bool) {
+	nRatio := h.N1 / h.N2
+	cosI := h.EyeV.DotProd(h.NormalV)
+	sin2T := nRatio * nRatio * (1 - cosI*cosI)
+
+	if sin2T > 1 {
+		return nil, false
+	}
+
+	cosT := math.Sqrt(1.0 - sin2T)
+
+	return h.NormalV.Mult(nRatio*cosI - cosT).Sub(h.EyeV.Mult(nRatio)), true
+}
+
+func maxComponent(c *color.Color) float64 {
+	return util.Max(util.Max(c.R, c.G), c.B)
+}
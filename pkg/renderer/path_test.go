@@ -0,0 +1,181 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/environment"
+	"github.com/Henelik/tricaster/pkg/geometry"
+	"github.com/Henelik/tricaster/pkg/light"
+	"github.com/Henelik/tricaster/pkg/material"
+	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/sampling"
+	"github.com/Henelik/tricaster/pkg/tuple"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefractDirection(t *testing.T) {
+	testCases := []struct {
+		name   string
+		n1     float64
+		n2     float64
+		cosI   float64
+		wantOk bool
+	}{
+		{
+			name:   "A ray entering a denser material refracts",
+			n1:     1,
+			n2:     1.5,
+			cosI:   0.2,
+			wantOk: true,
+		},
+		{
+			name:   "A grazing ray leaving a denser material is totally internally reflected",
+			n1:     1.5,
+			n2:     1,
+			cosI:   0.2,
+			wantOk: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &ray.Hit{
+				NormalV: tuple.Up,
+				EyeV:    tuple.NewVector(math.Sqrt(1-tc.cosI*tc.cosI), 0, tc.cosI),
+				N1:      tc.n1,
+				N2:      tc.n2,
+			}
+
+			dir, ok := refractDirection(h)
+			assert.Equal(t, tc.wantOk, ok)
+
+			if ok {
+				// snell's law holds for the refracted ray
+				sinI := math.Sqrt(1 - tc.cosI*tc.cosI)
+				sinT := math.Sqrt(1 - dir.Norm().DotProd(tuple.Down)*dir.Norm().DotProd(tuple.Down))
+				assert.InDelta(t, tc.n1*sinI, tc.n2*sinT, 1e-9)
+			}
+		})
+	}
+}
+
+func TestTracePath(t *testing.T) {
+	mat := material.DefaultPhong.Copy()
+	mat.Specular = 0
+
+	floor := geometry.NewPlane(nil, mat)
+	ceiling := geometry.NewPlane(matrix.Translation(0, 0, 2), mat)
+
+	down := ray.NewRay(tuple.NewPoint(0, 0, 1), tuple.Down)
+	rnd := sampling.NewRandom(0, 0)
+
+	t.Run("A ray which misses everything is black", func(t *testing.T) {
+		w := &World{Config: &WorldConfig{Integrator: "path"}}
+
+		assert.Equal(t, color.Black, w.TracePath(down, rnd))
+	})
+
+	t.Run("A ray which misses everything sees the background", func(t *testing.T) {
+		w := &World{
+			Config:     &WorldConfig{Integrator: "path"},
+			Background: environment.NewSolid(color.Grey(0.5)),
+		}
+
+		assert.Equal(t, color.Grey(0.5), w.TracePath(down, rnd))
+	})
+
+	t.Run("The background only lights the scene when enabled", func(t *testing.T) {
+		w := &World{
+			Geometry:   []Primitive{floor},
+			Config:     &WorldConfig{Integrator: "path"},
+			Background: environment.NewSolid(color.White),
+		}
+
+		assert.Equal(t, color.Black, w.TracePath(down, rnd))
+
+		// every diffuse bounce off the open floor escapes to the white sky, so the floor shows its color
+		w.BackgroundLighting = true
+
+		assert.True(t, mat.Color.MultF(mat.Diffuse).Equal(w.TracePath(down, rnd)))
+	})
+
+	t.Run("An open floor only receives direct light", func(t *testing.T) {
+		w := &World{
+			Geometry: []Primitive{floor},
+			Lights:   []light.Light{light.NewDirectionalLight(tuple.Down, color.White)},
+			Config:   &WorldConfig{Shadows: true, Integrator: "path"},
+		}
+
+		assert.True(t, color.Grey(0.9).Equal(w.TracePath(down, rnd)))
+	})
+
+	t.Run("Sampled materials choose their own bounces", func(t *testing.T) {
+		w := &World{
+			Geometry:           []Primitive{geometry.NewPlane(nil, material.NewPBRMat(color.White, 0, 0.5))},
+			Config:             &WorldConfig{Integrator: "path"},
+			Background:         environment.NewSolid(color.White),
+			BackgroundLighting: true,
+		}
+
+		// a white floor under a white sky reflects almost all of the sky's light
+		got := w.PathColorAt(down, 2000, rnd)
+
+		assert.InDelta(t, 0.95, got.R, 0.1)
+	})
+
+	t.Run("A mirror-like sampled material reflects a glowing quad", func(t *testing.T) {
+		glow := material.NewEmissiveMat(color.White, 1)
+
+		w := &World{
+			Geometry: []Primitive{
+				geometry.NewPlane(nil, material.NewPBRMat(color.White, 1, 0)),
+				geometry.NewCube(matrix.Translation(0, 0, 5).Mult(matrix.Scaling(4, 4, 0.01)), glow),
+			},
+			Config: &WorldConfig{Shadows: true, Integrator: "path"},
+		}
+		w.AddEmissiveLights()
+
+		// the reflection of the quad is off to the side of its center, which is all a mesh light's direct light sees
+		got := w.PathColorAt(ray.NewRay(tuple.NewPoint(-1, 0, 2), tuple.NewVector(1, 0, -2).Norm()), 16, rnd)
+
+		assert.InDelta(t, 1, got.R, 0.05)
+	})
+
+	t.Run("Light is absorbed while traveling through colored glass", func(t *testing.T) {
+		glass := &material.PhongMat{
+			Transparency:       1,
+			IOR:                1,
+			Color:              color.White,
+			Absorption:         color.NewColor(0.5, 0.5, 1),
+			AbsorptionDistance: 1,
+		}
+
+		w := &World{
+			Geometry: []Primitive{
+				geometry.NewCube(matrix.Scaling(10, 10, 1), glass),
+				geometry.NewPlane(matrix.Translation(0, 0, -5), &material.ShadelessMat{Color: color.White}),
+			},
+			Config: &WorldConfig{Integrator: "path"},
+		}
+
+		got := w.TracePath(ray.NewRay(tuple.NewPoint(0, 0, 5), tuple.Down), rnd)
+
+		assert.True(t, color.NewColor(0.25, 0.25, 1).Equal(got))
+	})
+
+	t.Run("Light bounces between a floor and a ceiling", func(t *testing.T) {
+		w := &World{
+			Geometry: []Primitive{floor, ceiling},
+			Lights:   []light.Light{&light.PointLight{Pos: tuple.NewPoint(0, 0, 1.5), Color: color.White}},
+			Config:   &WorldConfig{Shadows: true, Integrator: "path"},
+		}
+
+		direct := w.directLight(floor, ray.NewHit(down, floor.Intersects(down), 0))
+		got := w.PathColorAt(down, 64, rnd)
+
+		assert.Greater(t, got.R, direct.R)
+	})
+}
@@ -2,6 +2,7 @@ package renderer
 
 import (
 	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/geometry"
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
@@ -13,11 +14,16 @@ import (
 type Primitive interface {
 	SetMatrix(m *matrix.Matrix)
 	GetMatrix() *matrix.Matrix
+	SetParent(group geometry.GroupInterface)
 	// Intersects returns an array of intersections where the ray meets the primitive
 	Intersects(r *ray.Ray) []ray.Intersection
 	// NormalAt returns the normal vector at a given scene point
 	NormalAt(pos *tuple.Tuple) *tuple.Tuple
-	Shade(light *light.PointLight, h *ray.Hit) *color.Color
+	Shade(light light.Light, h *ray.Hit) *color.Color
 	GetMaterial() material.Material
 	GetIOR() float64
+	// Bounds returns the primitive's bounding box in world space
+	Bounds() *geometry.Bounds
+	// SetCastsShadow controls whether the primitive blocks light from reaching other objects
+	SetCastsShadow(casts bool)
 }
@@ -0,0 +1,36 @@
+package renderer
+
+import "time"
+
+// Progress describes how far along a render is, each time a tile is finished
+type Progress struct {
+	Tiles     int
+	TilesDone int
+	// Pixels and PixelsDone count the pixels of the image, and of the finished tiles
+	Pixels     int
+	PixelsDone int
+	// Rays is the number of camera rays traced for the finished tiles
+	Rays int64
+	// Elapsed is the time since the render started
+	Elapsed time.Duration
+	// ETA estimates the time left, assuming the rest of the image is as slow to render as the part which is done
+	ETA time.Duration
+}
+
+// Fraction returns how much of the image is done, from 0 to 1
+func (p Progress) Fraction() float64 {
+	if p.Pixels == 0 {
+		return 1
+	}
+
+	return float64(p.PixelsDone) / float64(p.Pixels)
+}
+
+// finishTile adds a finished tile, and updates the estimate of the time left
+func (p *Progress) finishTile(t tile, rays int, start time.Time) {
+	p.TilesDone++
+	p.PixelsDone += t.w * t.h
+	p.Rays += int64(rays)
+	p.Elapsed = time.Since(start)
+	p.ETA = time.Duration(float64(p.Elapsed) * float64(p.Pixels-p.PixelsDone) / float64(p.PixelsDone))
+}
@@ -0,0 +1,31 @@
+package renderer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	p := Progress{Tiles: 3, Pixels: 100}
+	assert.Zero(t, p.Fraction())
+
+	start := time.Now().Add(-time.Second)
+
+	p.finishTile(tile{w: 5, h: 5}, 50, start)
+	assert.Equal(t, 1, p.TilesDone)
+	assert.Equal(t, 25, p.PixelsDone)
+	assert.Equal(t, int64(50), p.Rays)
+	assert.Equal(t, 0.25, p.Fraction())
+	// a quarter of the image took a second, so the rest takes about three
+	assert.InDelta(t, 3*time.Second, p.ETA, float64(100*time.Millisecond))
+
+	p.finishTile(tile{w: 5, h: 15}, 150, start)
+	assert.Equal(t, 1.0, p.Fraction())
+	assert.Equal(t, int64(200), p.Rays)
+	assert.Zero(t, p.ETA)
+
+	// an empty image is done from the start
+	assert.Equal(t, 1.0, Progress{}.Fraction())
+}
@@ -1,9 +1,23 @@
 package renderer
 
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/Henelik/tricaster/pkg/canvas"
+)
+
 type Scene struct {
 	Name   string
 	Camera *Camera
 	World  *World
+	// File is where the render is saved, and its extension decides the image format
+	File    string
+	Options canvas.SaveOptions
+	// AOVs are rendered alongside the color, and saved next to it or as layers of its file
+	AOVs   []AOV
+	Layers bool
 }
 
 func NewScene(config *Configuration) *Scene {
@@ -15,13 +29,66 @@ func NewScene(config *Configuration) *Scene {
 		world.Geometry = append(world.Geometry, object.ToPrimitive())
 	}
 
+	world.AddEmissiveLights()
+	world.BuildBVH()
+
 	return &Scene{
-		Name:   config.Name,
-		World:  world,
-		Camera: config.Camera.ToCamera(),
+		Name:    config.Name,
+		World:   world,
+		Camera:  config.Camera.ToCamera(),
+		File:    config.Output.Path(config.Name),
+		Options: config.Output.ToSaveOptions(),
+		AOVs:    config.Output.ToAOVs(),
+		Layers:  config.Output.Layers,
+	}
+}
+
+// Render renders the scene and saves it, calling onProgress, unless it's nil, as the render goes.
+// If the context is cancelled, whatever was rendered is saved before the context's error is returned.
+func (s *Scene) Render(ctx context.Context, onProgress func(Progress)) error {
+	canv, passes, renderErr := s.Camera.GoRenderContext(ctx, s.World, s.AOVs, onProgress)
+
+	if err := s.save(canv, passes); err != nil {
+		return err
 	}
+
+	return renderErr
+}
+
+// save saves a render and its AOVs
+func (s *Scene) save(canv *canvas.Canvas, passes []*canvas.Canvas) error {
+	if s.Layers {
+		layers := []canvas.Layer{{Canvas: canv}}
+		for i, a := range s.AOVs {
+			layers = append(layers, canvas.Layer{Name: a.String(), Canvas: passes[i]})
+		}
+
+		return canvas.SaveLayers(s.File, layers)
+	}
+
+	if err := canv.Save(s.File, s.Options); err != nil {
+		return err
+	}
+
+	for i, a := range s.AOVs {
+		options := s.Options
+		options.Raw = a.IsData()
+
+		pass := passes[i]
+		if !canvas.IsFloatFormat(s.File) {
+			pass = a.forDisplay(pass)
+		}
+
+		if err := pass.Save(aovPath(s.File, a), options); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (s *Scene) Render() {
-	s.Camera.GoRender(s.World).SaveImage(s.Name + ".png")
+// aovPath adds the name of an AOV to a file name, before its extension
+func aovPath(path string, a AOV) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + a.String() + ext
 }
@@ -0,0 +1,123 @@
+package renderer
+
+// DefaultTileSize is the width and height of the tiles of a render, in pixels
+const DefaultTileSize = 32
+
+// TileOrder decides which tiles of a render are handed to the workers first
+type TileOrder int
+
+const (
+	// TileOrderSpiral starts in the middle of the image, where the subject usually is, and spirals outward
+	TileOrderSpiral TileOrder = iota
+	// TileOrderHilbert follows a Hilbert curve, so that consecutive tiles are next to each other
+	// and share more of the scene in the caches
+	TileOrderHilbert
+)
+
+// tile is a rectangle of pixels which one worker renders at a time
+type tile struct {
+	x, y int
+	w, h int
+}
+
+// tiles divides an image into tiles, in the order they should be rendered.
+// The tiles at the right and bottom edges are cut short if the image doesn't divide evenly.
+func tiles(width, height, size int, order TileOrder) []tile {
+	nx := (width + size - 1) / size
+	ny := (height + size - 1) / size
+
+	var cells [][2]int
+	if order == TileOrderHilbert {
+		cells = hilbertCells(nx, ny)
+	} else {
+		cells = spiralCells(nx, ny)
+	}
+
+	result := make([]tile, len(cells))
+	for i, cell := range cells {
+		t := tile{x: cell[0] * size, y: cell[1] * size, w: size, h: size}
+		if t.x+t.w > width {
+			t.w = width - t.x
+		}
+		if t.y+t.h > height {
+			t.h = height - t.y
+		}
+
+		result[i] = t
+	}
+
+	return result
+}
+
+// spiralCells returns every cell of an nx*ny grid, spiraling outward from the middle
+func spiralCells(nx, ny int) [][2]int {
+	cells := make([][2]int, 0, nx*ny)
+
+	x, y := (nx-1)/2, (ny-1)/2
+	add := func() {
+		if x >= 0 && x < nx && y >= 0 && y < ny {
+			cells = append(cells, [2]int{x, y})
+		}
+	}
+	add()
+
+	// walk right, down, left and up, taking one more step every second turn.
+	// Cells outside the grid are skipped until the spiral has passed all of its edges.
+	directions := [4][2]int{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+	for steps, turn := 1, 0; len(cells) < nx*ny; turn++ {
+		d := directions[turn%4]
+		for i := 0; i < steps; i++ {
+			x, y = x+d[0], y+d[1]
+			add()
+		}
+
+		if turn%2 == 1 {
+			steps++
+		}
+	}
+
+	return cells
+}
+
+// hilbertCells returns every cell of an nx*ny grid along a Hilbert curve,
+// which covers the smallest power of two square around the grid, skipping the cells outside it
+func hilbertCells(nx, ny int) [][2]int {
+	n := 1
+	for n < nx || n < ny {
+		n *= 2
+	}
+
+	cells := make([][2]int, 0, nx*ny)
+	for d := 0; d < n*n; d++ {
+		x, y := hilbertPoint(n, d)
+		if x < nx && y < ny {
+			cells = append(cells, [2]int{x, y})
+		}
+	}
+
+	return cells
+}
+
+// hilbertPoint returns the cell at distance d along the Hilbert curve of an n*n grid, where n is a power of two
+func hilbertPoint(n, d int) (int, int) {
+	x, y := 0, 0
+
+	for s := 1; s < n; s *= 2 {
+		rx := 1 & (d / 2)
+		ry := 1 & (d ^ rx)
+
+		// rotate the quadrant, so that the curves of the quadrants join up
+		if ry == 0 {
+			if rx == 1 {
+				x, y = s-1-x, s-1-y
+			}
+			x, y = y, x
+		}
+
+		x += s * rx
+		y += s * ry
+		d /= 4
+	}
+
+	return x, y
+}
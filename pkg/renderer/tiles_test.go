@@ -0,0 +1,100 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTiles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		width, height int
+		size          int
+		order         TileOrder
+		count         int
+	}{
+		{
+			name:   "An image which divides evenly into tiles",
+			width:  64,
+			height: 96,
+			size:   32,
+			order:  TileOrderSpiral,
+			count:  6,
+		},
+		{
+			name:   "The edge tiles of an uneven image are cut short",
+			width:  100,
+			height: 37,
+			size:   32,
+			order:  TileOrderSpiral,
+			count:  8,
+		},
+		{
+			name:   "A Hilbert curve over an uneven image",
+			width:  100,
+			height: 37,
+			size:   32,
+			order:  TileOrderHilbert,
+			count:  8,
+		},
+		{
+			name:   "An image smaller than a tile",
+			width:  5,
+			height: 3,
+			size:   32,
+			order:  TileOrderHilbert,
+			count:  1,
+		},
+		{
+			name:   "A long thin image",
+			width:  1000,
+			height: 7,
+			size:   8,
+			order:  TileOrderSpiral,
+			count:  125,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := tiles(tc.width, tc.height, tc.size, tc.order)
+			assert.Len(t, ts, tc.count)
+
+			// every pixel is in exactly one tile
+			covered := make([]int, tc.width*tc.height)
+			for _, tile := range ts {
+				assert.LessOrEqual(t, tile.w, tc.size)
+				assert.LessOrEqual(t, tile.h, tc.size)
+
+				for x := tile.x; x < tile.x+tile.w; x++ {
+					for y := tile.y; y < tile.y+tile.h; y++ {
+						covered[y*tc.width+x]++
+					}
+				}
+			}
+
+			for i, n := range covered {
+				assert.Equal(t, 1, n, "pixel %d, %d", i%tc.width, i/tc.width)
+			}
+		})
+	}
+}
+
+func TestSpiralCells(t *testing.T) {
+	assert.Equal(t, [][2]int{{1, 1}, {2, 1}, {2, 2}, {1, 2}, {0, 2}, {0, 1}, {0, 0}, {1, 0}, {2, 0}}, spiralCells(3, 3))
+
+	// the spiral starts in the middle of the image
+	assert.Equal(t, [2]int{4, 1}, spiralCells(10, 3)[0])
+}
+
+func TestHilbertCells(t *testing.T) {
+	assert.Equal(t, [][2]int{{0, 0}, {0, 1}, {1, 1}, {1, 0}}, hilbertCells(2, 2))
+
+	// each cell is next to the one before it
+	cells := hilbertCells(8, 8)
+	assert.Len(t, cells, 64)
+	for i := 1; i < len(cells); i++ {
+		dx, dy := cells[i][0]-cells[i-1][0], cells[i][1]-cells[i-1][1]
+		assert.Equal(t, 1, dx*dx+dy*dy, "cell %d", i)
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"math"
 
 	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/environment"
 	"github.com/Henelik/tricaster/pkg/geometry"
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
 	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/sampling"
 	"github.com/Henelik/tricaster/pkg/tuple"
+	"github.com/Henelik/tricaster/pkg/util"
 )
 
 var DefaultWorld = &World{
@@ -23,9 +26,11 @@ var DefaultWorld = &World{
 		}),
 		geometry.NewSphere(matrix.Scaling(0.5, 0.5, 0.5), nil),
 	},
-	Light: &light.PointLight{
-		Pos:   tuple.NewPoint(-10, -10, 10),
-		Color: color.White,
+	Lights: []light.Light{
+		&light.PointLight{
+			Pos:   tuple.NewPoint(-10, -10, 10),
+			Color: color.White,
+		},
 	},
 	Config: &WorldConfig{
 		Shadows:   false,
@@ -34,38 +39,107 @@ var DefaultWorld = &World{
 }
 
 type World struct {
-	Geometry   []Primitive
-	Light      *light.PointLight
-	Config     *WorldConfig
-	Background *color.Color
+	Geometry []Primitive
+	Lights   []light.Light
+	Config   *WorldConfig
+	// Background is seen by rays which miss every object, and is black if nil
+	Background environment.Environment
+	// BackgroundLighting lets the background light the scene in path traced renders
+	BackgroundLighting bool
+	// Ambient is the light which reaches every surface evenly.
+	// If it's nil, the colors of the point, spot and directional lights are added up instead.
+	Ambient *color.Color
+	// bvh accelerates intersection tests once BuildBVH has been called
+	bvh *geometry.BVH
+}
+
+// BuildBVH builds a bounding volume hierarchy over the world's geometry, including nested groups and CSGs.
+// The geometry must not be changed afterwards without rebuilding it.
+func (w *World) BuildBVH() {
+	items := make([]geometry.Bounded, len(w.Geometry))
+
+	for i, p := range w.Geometry {
+		if b, ok := p.(geometry.BVHBuilder); ok {
+			b.BuildBVH()
+		}
+
+		items[i] = p
+	}
+
+	w.bvh = geometry.NewBVH(items)
 }
 
 // Intersect returns all the intersections where a ray encounters an object in the world, sorted.
 func (w *World) Intersect(r *ray.Ray) []ray.Intersection {
+	if w.bvh != nil {
+		return ray.SortI(w.bvh.Intersects(r))
+	}
+
 	inters := make([]ray.Intersection, 0, len(w.Geometry)*2)
 
 	for _, p := range w.Geometry {
 		inters = append(inters, p.Intersects(r)...)
 	}
 
-	return ray.SimpleSort(inters)
+	return ray.SortI(inters)
+}
+
+// IntersectClosest returns the nearest intersection in front of the ray, closer than tMax
+func (w *World) IntersectClosest(r *ray.Ray, tMax float64) (ray.Intersection, bool) {
+	if w.bvh != nil {
+		return w.bvh.IntersectsClosest(r, tMax)
+	}
+
+	closest := *ray.NilIntersect
+	found := false
+
+	for _, p := range w.Geometry {
+		if inter, ok := geometry.ClosestIntersection(p, r, tMax); ok {
+			closest = inter
+			tMax = inter.T
+			found = true
+		}
+	}
+
+	return closest, found
 }
 
 // Shade finds the color of an object at a hit point
 func (w *World) Shade(h *ray.Hit, remainingBounce int) *color.Color {
-	if w.Config.Shadows {
-		h.InShadow = w.IsShadowed(h.OverP)
+	primitive := h.Inters[h.Index].P.(Primitive)
+
+	// emitted and ambient light are added once, and then every light adds its contribution with its own shadow test
+	surface := emission(primitive, h)
+
+	ambientMat, ambient := primitive.GetMaterial().(ambientLighter)
+	if ambient {
+		surface = surface.Add(ambientMat.AmbientAt(h, w.ambientLight()))
 	}
 
-	primitive := h.Inters[h.Index].P.(Primitive)
+	for _, l := range w.Lights {
+		if litBySelf(l, primitive) {
+			continue
+		}
 
-	surface := primitive.Shade(w.Light, h)
+		if w.Config.Shadows {
+			w.shadow(l, h)
+		}
+
+		if ambient {
+			surface = surface.Add(ambientMat.Direct(l, h))
+		} else {
+			surface = surface.Add(primitive.Shade(l, h))
+		}
+	}
+
+	mat, ok := primitive.GetMaterial().(*material.PhongMat)
+	if !ok {
+		return surface
+	}
 
 	reflected := w.ReflectedColor(h, remainingBounce-1)
 	refracted := w.RefractedColor(h, remainingBounce-1)
 
-	mat := primitive.GetMaterial().(*material.PhongMat)
-
 	if mat.Reflectivity > 0 && mat.Transparency > 0 {
 		reflectance := h.Schlick()
 
@@ -77,19 +151,139 @@ func (w *World) Shade(h *ray.Hit, remainingBounce int) *color.Color {
 	return surface.Add(reflected).Add(refracted)
 }
 
+// ambientLighter is implemented by materials whose ambient light can be added separately from their lights
+type ambientLighter interface {
+	directLighter
+	AmbientAt(h *ray.Hit, lightColor *color.Color) *color.Color
+}
+
+// ambientLight returns the light which reaches every surface evenly.
+// Unless the world sets it, each point, spot and directional light adds its color, so more lights never make it darker.
+// Area and glowing mesh lights are left out, and so a scene without other lights has no ambient light.
+func (w *World) ambientLight() *color.Color {
+	if w.Ambient != nil {
+		return w.Ambient
+	}
+
+	result := color.Black
+	for _, l := range w.Lights {
+		if _, ok := l.(light.AreaLight); ok {
+			continue
+		}
+
+		result = result.Add(l.GetColor())
+	}
+
+	return result
+}
+
+// Trace finds the color seen along a camera ray, using the configured integrator.
+// The path integrator takes its random choices from rnd.
+func (w *World) Trace(r *ray.Ray, rnd *sampling.Random) *color.Color {
+	if w.Config.Integrator == "path" {
+		samples := w.Config.Samples
+		if samples <= 0 {
+			samples = DefaultPathSamples
+		}
+
+		return w.PathColorAt(r, samples, rnd)
+	}
+
+	return w.ColorAt(r, w.Config.MaxBounce)
+}
+
 // ColorAt finds a ray's hit and then calls shade at that hit
 func (w *World) ColorAt(r *ray.Ray, remainingBounce int) *color.Color {
-	inters := w.Intersect(r)
-	if len(inters) == 0 {
+	h, ok := w.HitAt(r)
+	if !ok {
+		return w.BackgroundAt(r)
+	}
+
+	return w.Shade(h, remainingBounce)
+}
+
+// ColorThrough is like ColorAt, for a ray which travels through a medium such as colored glass.
+// The medium absorbs some of the light, depending on how far the ray travels before its hit.
+func (w *World) ColorThrough(r *ray.Ray, medium ray.IORHaver, remainingBounce int) *color.Color {
+	absorber, ok := mediumMaterial(medium).(material.Absorber)
+	if !ok {
+		return w.ColorAt(r, remainingBounce)
+	}
+
+	h, ok := w.HitAt(r)
+	if !ok {
+		// a ray which never leaves an endless medium, like the sea below a plane
+		return w.BackgroundAt(r).MultCol(absorber.Transmittance(math.Inf(1)))
+	}
+
+	distance := h.Inters[h.Index].T * r.Direction.Mag()
+
+	return w.Shade(h, remainingBounce).MultCol(absorber.Transmittance(distance))
+}
+
+// mediumMaterial returns the material filling a container, or nil outside of every object
+func mediumMaterial(medium interface{}) material.Material {
+	switch m := medium.(type) {
+	case *geometry.CSG:
+		// like its IOR, a CSG is filled with the material of its left child
+		return mediumMaterial(m.Left)
+	case interface{ GetMaterial() material.Material }:
+		return m.GetMaterial()
+	default:
+		return nil
+	}
+}
+
+// BackgroundAt returns the color of the background behind a ray
+func (w *World) BackgroundAt(r *ray.Ray) *color.Color {
+	if w.Background == nil {
 		return color.Black
 	}
 
-	closest := ray.GetClosestPositiveIndex(inters)
-	if closest != -1 {
-		return w.Shade(ray.NewHit(r, inters, closest), remainingBounce)
+	return w.Background.ColorAt(r.Direction.Norm())
+}
+
+// HitAt finds the hit where a ray first meets an object in front of it
+func (w *World) HitAt(r *ray.Ray) (*ray.Hit, bool) {
+	closest, ok := w.IntersectClosest(r, math.Inf(1))
+	if !ok {
+		return nil, false
 	}
 
-	return color.Black
+	// only refraction needs the other intersections, to know which objects the hit is inside
+	if !isTransparent(closest.P.(Primitive)) {
+		return bump(ray.NewHit(r, []ray.Intersection{closest}, 0)), true
+	}
+
+	inters := w.Intersect(r)
+
+	index := ray.GetClosestPositiveIndex(inters)
+	if index == -1 {
+		return nil, false
+	}
+
+	return bump(ray.NewHit(r, inters, index)), true
+}
+
+// bump replaces the normal of a hit with the bumped normal of its material, if it has one.
+// The points just above and below the surface keep following the true surface.
+func bump(h *ray.Hit) *ray.Hit {
+	bumper, ok := h.Inters[h.Index].P.(Primitive).GetMaterial().(material.Bumper)
+	if !ok || bumper.GetBump() == nil {
+		return h
+	}
+
+	normal := bumper.GetBump().NormalAt(h)
+
+	// a normal facing away from the eye would light the surface from behind
+	if normal.DotProd(h.EyeV) <= 0 {
+		return h
+	}
+
+	h.NormalV = normal
+	h.ReflectV = h.EyeV.Neg().Reflect(normal)
+
+	return h
 }
 
 // ReflectedColor handles reflection ray culling and finds the next color on the light path
@@ -103,7 +297,7 @@ func (w *World) ReflectedColor(h *ray.Hit, remainingBounce int) *color.Color {
 			return color.Black
 		}
 
-		return w.ColorAt(ray.NewRay(h.OverP, h.ReflectV), remainingBounce).MultF(m.Reflectivity)
+		return w.ColorThrough(ray.NewRay(h.OverP, h.ReflectV), h.From, remainingBounce).MultF(m.Reflectivity)
 	}
 
 	return color.Black
@@ -128,25 +322,166 @@ func (w *World) RefractedColor(h *ray.Hit, remainingBounce int) *color.Color {
 		cosT := math.Sqrt(math.Abs(1.0 - sin2T))
 		dir := h.NormalV.Mult(nRatio*cosI - cosT).Sub(h.EyeV.Mult(nRatio))
 
-		return w.ColorAt(ray.NewRay(h.UnderP, dir), remainingBounce).MultF(m.Transparency)
+		return w.ColorThrough(ray.NewRay(h.UnderP, dir), h.To, remainingBounce).MultF(m.Transparency)
 	}
 
 	return color.Black
 }
 
-func (w *World) IsShadowed(p *tuple.Tuple) bool {
-	v := w.Light.Pos.Sub(p)
-	distance := v.Mag()
-	direction := v.Norm()
+// IsShadowed checks if objects between a point and a light block all of its light
+func (w *World) IsShadowed(l light.Light, p *tuple.Tuple) bool {
+	direction, distance, _ := l.Illuminate(p)
 
-	r := ray.NewRay(p, direction)
+	return w.transmittance(ray.NewRay(p, direction), distance).Equal(color.Black)
+}
 
-	inters := w.Intersect(r)
+// LightTransmittance returns the fraction of a light which reaches a point, for each color channel,
+// from black in full shadow to white.
+// Transparent objects let some light through, tinted by their color, and area lights are sampled
+// with several jittered shadow rays, so they give soft shadows.
+func (w *World) LightTransmittance(l light.Light, p *tuple.Tuple) *color.Color {
+	result := color.Black
+
+	if area, ok := l.(light.AreaLight); ok {
+		if samples := area.Samples(p); len(samples) > 0 {
+			cols := make([]*color.Color, len(samples))
+
+			for i, sample := range samples {
+				v := sample.Sub(p)
+
+				// stop just short of the sample, which may lie on the surface of a glowing object
+				cols[i] = w.transmittance(ray.NewRay(p, v.Norm()), v.Mag()-util.Epsilon)
+			}
+
+			result = color.Avg(cols)
+		}
+	} else {
+		direction, distance, _ := l.Illuminate(p)
+
+		result = w.transmittance(ray.NewRay(p, direction), distance)
+	}
+
+	// weaker shadows let some of the blocked light through
+	return color.White.Lerp(result, w.Config.shadowStrength())
+}
+
+// transmittance returns the fraction of light which travels along a ray for a distance,
+// passing through any transparent objects in the way
+func (w *World) transmittance(r *ray.Ray, distance float64) *color.Color {
+	result := color.White
+
+	for {
+		inter, ok := w.IntersectClosest(r, distance)
+		if !ok {
+			return result
+		}
+
+		pos := r.Position(inter.T)
+
+		result = result.MultCol(shadowFilter(inter.P, pos))
+		if result.Equal(color.Black) {
+			return color.Black
+		}
+
+		// carry on from just past the surface
+		r = ray.NewRay(pos.Add(r.Direction.Mult(util.Epsilon)), r.Direction)
+		distance -= inter.T + util.Epsilon
+	}
+}
+
+// shadowFilter returns the fraction of light which passes through a primitive's surface
+func shadowFilter(p ray.Primitive, pos *tuple.Tuple) *color.Color {
+	if c, ok := p.(interface{ CastsShadow() bool }); ok && !c.CastsShadow() {
+		return color.White
+	}
+
+	if m, ok := p.(Primitive).GetMaterial().(*material.PhongMat); ok && m.Transparency > 0 {
+		return m.ColorAt(pos).MultF(m.Transparency)
+	}
 
-	h := ray.GetClosestPositive(inters)
+	return color.Black
+}
+
+// shadow sets how much of a light is blocked from reaching a hit, and the tint of the light which gets through
+func (w *World) shadow(l light.Light, h *ray.Hit) {
+	t := w.LightTransmittance(l, h.OverP)
+
+	brightest := maxComponent(t)
+
+	h.Shadow = 1 - brightest
+	h.Tint = nil
+
+	if brightest > 0 && !(t.R == t.G && t.G == t.B) {
+		h.Tint = t.MultF(1 / brightest)
+	}
+}
+
+// AddEmissiveLights adds a mesh light for every material which lights the scene from an object's surface.
+// Objects with infinite bounds can't be sampled, so they only glow without lighting anything.
+func (w *World) AddEmissiveLights() {
+	for _, p := range w.Geometry {
+		if !p.Bounds().IsFinite() {
+			continue
+		}
+
+		for _, mat := range emitters(p, nil) {
+			w.Lights = append(w.Lights, geometry.NewMeshLight(p, mat, geometry.DefaultMeshLightSteps))
+		}
+	}
+}
+
+// emitters appends the distinct materials which light the scene from an object, or anything inside it
+func emitters(item geometry.Intersecter, found []material.Emitter) []material.Emitter {
+	switch item := item.(type) {
+	case *geometry.BasicGroup:
+		for _, child := range item.Children {
+			found = emitters(child, found)
+		}
 
-	if *h != *ray.NilIntersect && h.T < distance {
-		return true
+		return found
+	case *geometry.CSG:
+		return emitters(item.Right, emitters(item.Left, found))
+	case Primitive:
+		e, ok := item.GetMaterial().(material.Emitter)
+		if !ok || !e.IsLight() {
+			return found
+		}
+
+		for _, f := range found {
+			if f == e {
+				return found
+			}
+		}
+
+		return append(found, e)
+	default:
+		return found
 	}
+}
+
+// emission returns the light given off by a primitive's material at a hit
+func emission(p Primitive, h *ray.Hit) *color.Color {
+	if e, ok := p.GetMaterial().(material.Emitter); ok {
+		return e.EmissionAtHit(h)
+	}
+
+	return color.Black
+}
+
+// litBySelf returns true if a light comes from the primitive's own glowing surface
+func litBySelf(l light.Light, p Primitive) bool {
+	if ml, ok := l.(*geometry.MeshLight); ok {
+		return ml.IsSource(p)
+	}
+
 	return false
 }
+
+// isTransparent returns true unless the primitive's material is known to be opaque
+func isTransparent(p Primitive) bool {
+	if m, ok := p.GetMaterial().(*material.PhongMat); ok {
+		return m.Transparency > 0
+	}
+
+	return true
+}
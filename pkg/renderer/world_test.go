@@ -5,11 +5,14 @@ import (
 	"testing"
 
 	"github.com/Henelik/tricaster/pkg/color"
+	"github.com/Henelik/tricaster/pkg/environment"
 	"github.com/Henelik/tricaster/pkg/geometry"
 	"github.com/Henelik/tricaster/pkg/light"
 	"github.com/Henelik/tricaster/pkg/material"
 	"github.com/Henelik/tricaster/pkg/matrix"
+	"github.com/Henelik/tricaster/pkg/pattern"
 	"github.com/Henelik/tricaster/pkg/ray"
+	"github.com/Henelik/tricaster/pkg/sampling"
 	"github.com/Henelik/tricaster/pkg/tuple"
 
 	"github.com/stretchr/testify/assert"
@@ -29,24 +32,104 @@ func TestIntersect(t *testing.T) {
 	assert.Equal(t, 6.0, got[3].T)
 }
 
+func TestIntersectBVH(t *testing.T) {
+	// Intersect a world with a ray once its hierarchy is built
+	w := *DefaultWorld
+	w.BuildBVH()
+
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	got := w.Intersect(r)
+
+	assert.Equal(t, DefaultWorld.Intersect(r), got)
+
+	closest, ok := w.IntersectClosest(r, math.Inf(1))
+	assert.True(t, ok)
+	assert.Equal(t, got[0], closest)
+
+	_, ok = w.IntersectClosest(r, 3.9)
+	assert.False(t, ok)
+}
+
 func TestShading(t *testing.T) {
 	// Shading an intersection
 	r := ray.NewRay(tuple.NewPoint(-5, 0, 0), tuple.Right)
 	s := DefaultWorld.Geometry[0]
-	i := ray.Intersection{4, s}
+	i := ray.Intersection{T: 4, P: s}
 	col := DefaultWorld.Shade(ray.NewHit(r, []ray.Intersection{i}, 0), 0)
 	assert.Equal(t, color.NewColor(0.38066119308103435, 0.47582649135129296, 0.28549589481077575), col)
 
 	// Shading an intersection from the inside
 	w := *DefaultWorld
-	w.Light = &light.PointLight{tuple.NewPoint(0, 0.25, 0), color.White}
+	w.Lights = []light.Light{&light.PointLight{Pos: tuple.NewPoint(0, 0.25, 0), Color: color.White}}
 	r2 := ray.NewRay(tuple.Origin, tuple.Right)
 	s2 := DefaultWorld.Geometry[1]
-	i2 := ray.Intersection{0.5, s2}
+	i2 := ray.Intersection{T: 0.5, P: s2}
 	col2 := w.Shade(ray.NewHit(r2, []ray.Intersection{i2}, 0), 0)
 	assert.Equal(t, color.Grey(0.9049844720832575), col2)
 }
 
+func TestShadingMultipleLights(t *testing.T) {
+	// Each light contributes to the shading of an intersection
+	r := ray.NewRay(tuple.NewPoint(-5, 0, 0), tuple.Right)
+	s := DefaultWorld.Geometry[0]
+	single := DefaultWorld.Shade(ray.NewHit(r, []ray.Intersection{{T: 4, P: s}}, 0), 0)
+
+	w := *DefaultWorld
+	w.Lights = []light.Light{DefaultWorld.Lights[0], DefaultWorld.Lights[0]}
+	double := w.Shade(ray.NewHit(r, []ray.Intersection{{T: 4, P: s}}, 0), 0)
+
+	// each light adds its share of the ambient light
+	ambient := color.NewColor(0.8, 1, 0.6).MultF(0.1)
+	assert.True(t, single.MultF(2).Equal(double))
+
+	// Each light has its own shadow test
+	w.Config = &WorldConfig{Shadows: true}
+	w.Lights = []light.Light{
+		DefaultWorld.Lights[0],
+		light.NewDirectionalLight(tuple.Left, color.White),
+	}
+	shadowed := w.Shade(ray.NewHit(r, []ray.Intersection{{T: 4, P: s}}, 0), 0)
+
+	// a shadow doesn't block ambient light
+	assert.True(t, single.Add(ambient).Equal(shadowed))
+
+	// a spot light pointing away still leaves the ambient light
+	w.Lights = []light.Light{
+		light.NewSpotLight(tuple.NewPoint(-10, 10, -10), tuple.NewVector(-1, 0, 0), 0.3, 0.1, color.White),
+	}
+	dark := w.Shade(ray.NewHit(r, []ray.Intersection{{T: 4, P: s}}, 0), 0)
+
+	assert.True(t, ambient.Equal(dark))
+}
+
+func TestWorld_ambientLight(t *testing.T) {
+	w := &World{
+		Lights: []light.Light{&light.PointLight{Pos: tuple.NewPoint(0, 0, 10), Color: color.White}},
+	}
+	lit := w.ambientLight()
+	assert.True(t, color.White.Equal(lit))
+
+	// a dim fill light doesn't make the ambient light darker
+	w.Lights = append(w.Lights, light.NewDirectionalLight(tuple.Down, color.Grey(0.2)))
+	filled := w.ambientLight()
+	assert.GreaterOrEqual(t, filled.R, lit.R)
+	assert.GreaterOrEqual(t, filled.G, lit.G)
+	assert.GreaterOrEqual(t, filled.B, lit.B)
+
+	// area lights and glowing objects are left out
+	w.Lights = append(w.Lights,
+		light.NewRectLight(tuple.NewPoint(0, 0, 5), tuple.NewVector(1, 0, 0), tuple.NewVector(0, 1, 0), 2, color.White),
+		geometry.NewMeshLight(geometry.NewSphere(nil, nil), material.NewEmissiveMat(color.Red, 10), 2))
+	assert.True(t, filled.Equal(w.ambientLight()))
+
+	// a scene without lights has no ambient light, unless the world sets one
+	w.Lights = nil
+	assert.True(t, color.Black.Equal(w.ambientLight()))
+
+	w.Ambient = color.Grey(0.5)
+	assert.True(t, color.Grey(0.5).Equal(w.ambientLight()))
+}
+
 func TestColorAtMiss(t *testing.T) {
 	// The color when a ray misses
 	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.Forward)
@@ -54,6 +137,28 @@ func TestColorAtMiss(t *testing.T) {
 	assert.Equal(t, color.Black, c)
 }
 
+func TestColorAtMissBackground(t *testing.T) {
+	mirror := material.DefaultPhong.Copy()
+	mirror.Diffuse = 0
+	mirror.Ambient = 0
+	mirror.Specular = 0
+	mirror.Reflectivity = 1
+
+	w := &World{
+		Geometry:   []Primitive{geometry.NewPlane(nil, mirror)},
+		Config:     &WorldConfig{MaxBounce: 2},
+		Background: environment.NewGradient(color.Black, color.White),
+	}
+
+	// a ray which misses sees the background in its direction
+	up := ray.NewRay(tuple.NewPoint(0, 0, 1), tuple.Up)
+	assert.Equal(t, color.White, w.ColorAt(up, 2))
+
+	// and reflections see it too
+	down := ray.NewRay(tuple.NewPoint(0, 0, 1), tuple.Down)
+	assert.True(t, color.White.Equal(w.ColorAt(down, 2)))
+}
+
 func TestColorAtHit(t *testing.T) {
 	// The color when a ray hits
 	r := ray.NewRay(tuple.NewPoint(-5, 0, 0), tuple.Right)
@@ -76,9 +181,11 @@ func TestColorAtHitBehind(t *testing.T) {
 					Color:   color.Blue,
 				}),
 		},
-		Light: &light.PointLight{
-			tuple.NewPoint(-10, -10, 10),
-			color.White,
+		Lights: []light.Light{
+			&light.PointLight{
+				Pos:   tuple.NewPoint(-10, -10, 10),
+				Color: color.White,
+			},
 		},
 		Config: &WorldConfig{
 			Shadows: false,
@@ -89,6 +196,26 @@ func TestColorAtHitBehind(t *testing.T) {
 	assert.Equal(t, color.Blue, col)
 }
 
+func TestColorAtCSG(t *testing.T) {
+	w := &World{
+		Config: &WorldConfig{Shadows: true, MaxBounce: 3},
+		Lights: []light.Light{
+			&light.PointLight{Pos: tuple.NewPoint(0, -10, 10), Color: color.White},
+		},
+		Geometry: []Primitive{
+			geometry.NewCSG(geometry.CSGDifference,
+				geometry.NewCube(nil, material.DefaultPhong),
+				geometry.NewSphere(matrix.Translation(0, -1, 0), material.DefaultPhong),
+				nil),
+		},
+	}
+
+	// the ray passes through the hole cut by the sphere, and hits the cube's surface inside it
+	r := ray.NewRay(tuple.NewPoint(0, -5, 0), tuple.NewVector(0, 1, 0))
+
+	assert.False(t, color.Black.Equal(w.ColorAt(r, 3)))
+}
+
 func TestShadow(t *testing.T) {
 	w := *DefaultWorld
 	w.Config = &WorldConfig{Shadows: true}
@@ -118,9 +245,13 @@ func TestShadow(t *testing.T) {
 			want: false,
 		},
 	}
+	wBVH := w
+	wBVH.BuildBVH()
+
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.want, w.IsShadowed(tc.p))
+			assert.Equal(t, tc.want, w.IsShadowed(w.Lights[0], tc.p))
+			assert.Equal(t, tc.want, wBVH.IsShadowed(w.Lights[0], tc.p))
 		})
 	}
 }
@@ -129,7 +260,7 @@ func TestShadow(t *testing.T) {
 func TestWorldRefractOpaque(t *testing.T) {
 	s := DefaultWorld.Geometry[0]
 	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
-	inters := []ray.Intersection{{4, s}, {6, s}}
+	inters := []ray.Intersection{{T: 4, P: s}, {T: 6, P: s}}
 	h := ray.NewHit(r, inters, 0)
 	col := DefaultWorld.RefractedColor(h, 3)
 	assert.Equal(t, color.Black, col)
@@ -152,7 +283,7 @@ func TestWorldRefractMax(t *testing.T) {
 	}
 
 	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
-	inters := []ray.Intersection{{4, s}, {6, s}}
+	inters := []ray.Intersection{{T: 4, P: s}, {T: 6, P: s}}
 	h := ray.NewHit(r, inters, 0)
 	col := DefaultWorld.RefractedColor(h, 0)
 	assert.Equal(t, color.Black, col)
@@ -175,7 +306,7 @@ func TestWorldRefractTotalInternalReflection(t *testing.T) {
 	}
 
 	r := ray.NewRay(tuple.NewPoint(0, 0, math.Sqrt2/2), tuple.NewVector(0, 1, 0))
-	inters := []ray.Intersection{{-math.Sqrt2 / 2, s}, {math.Sqrt2 / 2, s}}
+	inters := []ray.Intersection{{T: -math.Sqrt2 / 2, P: s}, {T: math.Sqrt2 / 2, P: s}}
 	h := ray.NewHit(r, inters, 1)
 	col := w.RefractedColor(h, 3)
 	assert.Equal(t, color.Black, col)
@@ -209,8 +340,287 @@ func TestWorldRefractRefract(t *testing.T) {
 		Color:        color.White,
 	}
 	r := ray.NewRay(tuple.NewPoint(0, 0, 0.1), tuple.NewVector(0, 1, 0))
-	inters := []ray.Intersection{{-0.9899, a}, {-0.4899, b}, {0.4899, b}, {0.9899, a}}
+	inters := []ray.Intersection{{T: -0.9899, P: a}, {T: -0.4899, P: b}, {T: 0.4899, P: b}, {T: 0.9899, P: a}}
 	h := ray.NewHit(r, inters, 2)
 	col := w.RefractedColor(h, 5)
 	assert.Equal(t, color.NewColor(0, 0.998884682797801, 0.04721642163417859), col)
 }
+
+func TestColorThroughAbsorption(t *testing.T) {
+	glass := &material.PhongMat{
+		Transparency:       1,
+		IOR:                1,
+		Color:              color.White,
+		Absorption:         color.NewColor(0.5, 0.5, 1),
+		AbsorptionDistance: 1,
+	}
+
+	testCases := []struct {
+		name      string
+		thickness float64
+		want      *color.Color
+	}{
+		{
+			name:      "Thin glass absorbs a little light",
+			thickness: 1,
+			want:      color.NewColor(0.5, 0.5, 1),
+		},
+		{
+			name:      "Thick glass absorbs more light",
+			thickness: 2,
+			want:      color.NewColor(0.25, 0.25, 1),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &World{
+				Config: &WorldConfig{MaxBounce: 5},
+				Geometry: []Primitive{
+					geometry.NewCube(matrix.Scaling(10, 10, tc.thickness/2), glass),
+					geometry.NewPlane(matrix.Translation(0, 0, -5), &material.ShadelessMat{Color: color.White}),
+				},
+			}
+
+			got := w.ColorAt(ray.NewRay(tuple.NewPoint(0, 0, 5), tuple.Down), 5)
+
+			assert.True(t, tc.want.Equal(got))
+		})
+	}
+}
+
+func TestHitAtBump(t *testing.T) {
+	testCases := []struct {
+		name  string
+		color *color.Color
+		want  *tuple.Tuple
+	}{
+		{
+			name:  "The normal map leans the normal toward increasing u",
+			color: color.NewColor(1, 0.5, 1),
+			want:  tuple.NewVector(1, 0, 1).Norm(),
+		},
+		{
+			name:  "Green leans the normal toward increasing v",
+			color: color.NewColor(0.5, 1, 1),
+			want:  tuple.NewVector(0, 1, 1).Norm(),
+		},
+		{
+			name:  "A normal facing away from the eye is ignored",
+			color: color.NewColor(0.5, 0.5, 0),
+			want:  tuple.Up,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mat := material.DefaultPhong.Copy()
+			mat.Bump = material.NewNormalMap(pattern.NewSolidPattern(tc.color), 1)
+
+			w := &World{
+				Config:   &WorldConfig{},
+				Geometry: []Primitive{geometry.NewPlane(nil, mat)},
+			}
+
+			h, ok := w.HitAt(ray.NewRay(tuple.NewPoint(0.3, 0.3, 5), tuple.Down))
+
+			assert.True(t, ok)
+			assert.True(t, tc.want.Equal(h.NormalV))
+			assert.True(t, h.EyeV.Neg().Reflect(tc.want).Equal(h.ReflectV))
+			// the offset points still follow the flat plane
+			assert.True(t, h.OverP.Sub(h.Pos).Norm().Equal(tuple.Up))
+		})
+	}
+}
+
+func TestLightTransmittanceAreaLight(t *testing.T) {
+	w := &World{
+		Config: &WorldConfig{Shadows: true},
+		Geometry: []Primitive{
+			geometry.NewSphere(matrix.Identity, material.DefaultPhong),
+		},
+	}
+	l := light.NewRectLight(tuple.NewPoint(0, 0, 5), tuple.NewVector(4, 0, 0), tuple.NewVector(0, 4, 0), 16, color.White)
+
+	testCases := []struct {
+		name  string
+		p     *tuple.Tuple
+		want  float64
+		delta float64
+	}{
+		{
+			name: "A point between the sphere and the light is fully lit",
+			p:    tuple.NewPoint(0, 0, 2),
+			want: 1,
+		},
+		{
+			name: "A point just below the sphere is fully shadowed",
+			p:    tuple.NewPoint(0, 0, -1.5),
+			want: 0,
+		},
+		{
+			// the sphere hides the part of the light within a radius of sqrt(100/24) of its center,
+			// which is 81% of the light
+			name:  "A point far below the sphere is in the penumbra",
+			p:     tuple.NewPoint(0, 0, -5),
+			want:  0.187,
+			delta: 0.02,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := w.LightTransmittance(l, tc.p)
+			assert.InDelta(t, tc.want, got.R, tc.delta)
+
+			// the shadow rays are the same every time
+			assert.Equal(t, got, w.LightTransmittance(l, tc.p))
+		})
+	}
+}
+
+func TestLightTransmittance(t *testing.T) {
+	glass := &material.PhongMat{
+		Transparency: 0.5,
+		IOR:          1.5,
+		Color:        color.NewColor(1, 0.5, 0),
+	}
+
+	hidden := geometry.NewSphere(nil, material.DefaultPhong)
+	hidden.SetCastsShadow(false)
+
+	l := &light.PointLight{Pos: tuple.NewPoint(0, 0, 10), Color: color.White}
+	p := tuple.NewPoint(0, 0, -5)
+	weak, none := 0.75, 0.0
+
+	testCases := []struct {
+		name     string
+		sphere   Primitive
+		strength *float64
+		want     *color.Color
+	}{
+		{
+			name:   "An opaque object blocks all light",
+			sphere: geometry.NewSphere(nil, material.DefaultPhong),
+			want:   color.Black,
+		},
+		{
+			name:   "A transparent object lets some light through, tinted by each surface it crosses",
+			sphere: geometry.NewSphere(nil, glass),
+			want:   color.NewColor(0.25, 0.0625, 0),
+		},
+		{
+			name:   "An object which casts no shadow lets all light through",
+			sphere: hidden,
+			want:   color.White,
+		},
+		{
+			name:     "A weaker shadow lets some blocked light through",
+			sphere:   geometry.NewSphere(nil, material.DefaultPhong),
+			strength: &weak,
+			want:     color.Grey(0.25),
+		},
+		{
+			name:     "A shadow strength of 0 lets all blocked light through",
+			sphere:   geometry.NewSphere(nil, material.DefaultPhong),
+			strength: &none,
+			want:     color.White,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &World{
+				Config:   &WorldConfig{Shadows: true, ShadowStrength: tc.strength},
+				Geometry: []Primitive{tc.sphere},
+			}
+
+			assert.True(t, tc.want.Equal(w.LightTransmittance(l, p)))
+		})
+	}
+}
+
+func TestShadeTintedShadow(t *testing.T) {
+	floorMat := material.DefaultPhong.Copy()
+	floorMat.Ambient = 0
+	floorMat.Specular = 0
+	floorMat.Diffuse = 1
+
+	glass := &material.PhongMat{
+		Transparency: 0.5,
+		IOR:          1,
+		Color:        color.NewColor(1, 0.5, 0),
+	}
+
+	floor := geometry.NewPlane(nil, floorMat)
+
+	w := &World{
+		Config: &WorldConfig{Shadows: true},
+		Geometry: []Primitive{
+			floor,
+			geometry.NewSphere(matrix.Translation(0, 0, 3), glass),
+		},
+		Lights: []light.Light{light.NewDirectionalLight(tuple.Down, color.White)},
+	}
+
+	// the ray starts under the sphere, so only the shadow reaches the floor
+	r := ray.NewRay(tuple.NewPoint(0, 0, 1), tuple.Down)
+	h := ray.NewHit(r, floor.Intersects(r), 0)
+
+	assert.True(t, color.NewColor(0.25, 0.0625, 0).Equal(w.Shade(h, 1)))
+}
+
+func TestEmissiveLights(t *testing.T) {
+	floorMat := material.DefaultPhong.Copy()
+	floorMat.Specular = 0
+
+	glow := material.NewEmissiveMat(color.NewColor(1, 0.5, 0), 1)
+
+	w := &World{
+		Config: &WorldConfig{Shadows: true},
+		Geometry: []Primitive{
+			geometry.NewPlane(nil, floorMat),
+			geometry.NewSphere(matrix.Translation(0, 0, 3), glow),
+			geometry.NewSphere(matrix.Translation(10, 0, 3), &material.ShadelessMat{Color: color.White}),
+		},
+	}
+	w.AddEmissiveLights()
+
+	// only the emissive sphere lights the scene
+	assert.Len(t, w.Lights, 1)
+
+	t.Run("A glowing sphere is its emission color", func(t *testing.T) {
+		got := w.ColorAt(ray.NewRay(tuple.NewPoint(0, 0, 10), tuple.Down), 1)
+		assert.True(t, color.NewColor(1, 0.5, 0).Equal(got))
+	})
+
+	t.Run("A shadeless sphere is its flat color", func(t *testing.T) {
+		got := w.ColorAt(ray.NewRay(tuple.NewPoint(10, 0, 10), tuple.Down), 1)
+		assert.True(t, color.White.Equal(got))
+	})
+
+	t.Run("A glowing sphere lights the floor below it", func(t *testing.T) {
+		got := w.ColorAt(ray.NewRay(tuple.NewPoint(0, 0, 1), tuple.Down), 1)
+		// the full diffuse light of the sphere straight above, with no ambient light since glowing objects don't add any
+		assert.InDelta(t, 0.9, got.R, 1e-9)
+		assert.InDelta(t, 0.45, got.G, 1e-9)
+		assert.Equal(t, 0.0, got.B)
+	})
+}
+
+func TestEmissionUV(t *testing.T) {
+	// the checker follows the triangle's surface coordinates, which don't match a planar mapping of the hit
+	glow := &material.EmissiveMat{
+		Pattern:  pattern.NewUVPattern(nil, nil, pattern.NewUVChecker(2, 2, color.Black, color.White)),
+		Strength: 1,
+	}
+
+	w := &World{
+		Config: &WorldConfig{},
+		Geometry: []Primitive{
+			geometry.NewTriangle(tuple.Origin, tuple.NewPoint(4, 0, 0), tuple.NewPoint(0, 4, 0), nil, glow),
+		},
+	}
+
+	r := ray.NewRay(tuple.NewPoint(2.5, 0.5, 5), tuple.Down)
+
+	assert.Equal(t, color.Black, glow.EmissionAt(tuple.NewPoint(2.5, 0.5, 0)))
+	assert.Equal(t, color.White, w.ColorAt(r, 1))
+	assert.Equal(t, color.White, w.TracePath(r, sampling.NewRandom(0, 0)))
+}
@@ -0,0 +1,39 @@
+package sampling
+
+import "math"
+
+// Estimate keeps the running mean and variance of a series of samples,
+// to tell how closely their mean is known
+type Estimate struct {
+	N    int
+	Mean float64
+	// m2 is the sum of the squared differences from the mean
+	m2 float64
+}
+
+// Add adds a sample, using Welford's method so that the variance stays accurate
+func (e *Estimate) Add(x float64) {
+	e.N++
+	d := x - e.Mean
+	e.Mean += d / float64(e.N)
+	e.m2 += d * (x - e.Mean)
+}
+
+// Variance returns the sample variance, which is 0 until there are two samples
+func (e *Estimate) Variance() float64 {
+	if e.N < 2 {
+		return 0
+	}
+
+	return e.m2 / float64(e.N-1)
+}
+
+// StandardError returns how far the mean is likely to be from the true mean.
+// It's infinite until there are two samples, since nothing is known about their spread.
+func (e *Estimate) StandardError() float64 {
+	if e.N < 2 {
+		return math.Inf(1)
+	}
+
+	return math.Sqrt(e.Variance() / float64(e.N))
+}
@@ -0,0 +1,34 @@
+package sampling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate(t *testing.T) {
+	var e Estimate
+
+	assert.True(t, math.IsInf(e.StandardError(), 1))
+
+	e.Add(3)
+	assert.Equal(t, 3.0, e.Mean)
+	assert.Zero(t, e.Variance())
+	assert.True(t, math.IsInf(e.StandardError(), 1))
+
+	for _, x := range []float64{5, 7, 9} {
+		e.Add(x)
+	}
+
+	assert.Equal(t, 4, e.N)
+	assert.InDelta(t, 6, e.Mean, 1e-12)
+	assert.InDelta(t, 20.0/3, e.Variance(), 1e-12)
+	assert.InDelta(t, math.Sqrt(20.0/3/4), e.StandardError(), 1e-12)
+
+	// samples which agree are known exactly
+	var same Estimate
+	same.Add(0.5)
+	same.Add(0.5)
+	assert.Zero(t, same.StandardError())
+}
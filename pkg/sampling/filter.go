@@ -0,0 +1,179 @@
+package sampling
+
+import (
+	"math"
+	"sort"
+)
+
+// Filter weights the samples of a pixel by their offset from its center, in pixels.
+// Filters wider than a pixel blend in some of the neighboring pixels, which softens aliasing.
+// The weight of every filter is the product of the same curve along x and along y.
+type Filter interface {
+	// Radius returns how far from the center of the pixel samples are taken
+	Radius() float64
+	// Weight returns the weight of a sample at an offset, which is 0 outside of the radius
+	Weight(x, y float64) float64
+}
+
+// BoxFilter weights every sample equally
+type BoxFilter struct {
+	R float64
+}
+
+func NewBoxFilter(r float64) *BoxFilter {
+	return &BoxFilter{R: r}
+}
+
+func (f *BoxFilter) Radius() float64 {
+	return f.R
+}
+
+func (f *BoxFilter) Weight(x, y float64) float64 {
+	if math.Abs(x) > f.R || math.Abs(y) > f.R {
+		return 0
+	}
+
+	return 1
+}
+
+// TentFilter weights samples less the further they are from the center, falling linearly to 0 at the radius
+type TentFilter struct {
+	R float64
+}
+
+func NewTentFilter(r float64) *TentFilter {
+	return &TentFilter{R: r}
+}
+
+func (f *TentFilter) Radius() float64 {
+	return f.R
+}
+
+func (f *TentFilter) Weight(x, y float64) float64 {
+	return math.Max(0, f.R-math.Abs(x)) * math.Max(0, f.R-math.Abs(y))
+}
+
+// GaussianFilter falls off like a bell curve, shifted down to reach 0 at the radius.
+// A larger Alpha gives a narrower curve and a sharper image.
+type GaussianFilter struct {
+	R     float64
+	Alpha float64
+}
+
+func NewGaussianFilter(r, alpha float64) *GaussianFilter {
+	return &GaussianFilter{R: r, Alpha: alpha}
+}
+
+func (f *GaussianFilter) Radius() float64 {
+	return f.R
+}
+
+func (f *GaussianFilter) Weight(x, y float64) float64 {
+	return f.gaussian(x) * f.gaussian(y)
+}
+
+func (f *GaussianFilter) gaussian(d float64) float64 {
+	return math.Max(0, math.Exp(-f.Alpha*d*d)-math.Exp(-f.Alpha*f.R*f.R))
+}
+
+// MitchellFilter is the cubic filter of Mitchell and Netravali, which has small negative lobes
+// that keep edges sharp. B and C trade blurring against ringing, and are usually both 1/3.
+type MitchellFilter struct {
+	R float64
+	B float64
+	C float64
+}
+
+func NewMitchellFilter(r, b, c float64) *MitchellFilter {
+	return &MitchellFilter{R: r, B: b, C: c}
+}
+
+func (f *MitchellFilter) Radius() float64 {
+	return f.R
+}
+
+func (f *MitchellFilter) Weight(x, y float64) float64 {
+	return f.mitchell(x/f.R) * f.mitchell(y/f.R)
+}
+
+// mitchell evaluates the filter at an offset scaled so that the radius is 1
+func (f *MitchellFilter) mitchell(d float64) float64 {
+	// the cubic is defined from -2 to 2
+	d = math.Abs(2 * d)
+	b, c := f.B, f.C
+
+	switch {
+	case d > 2:
+		return 0
+	case d > 1:
+		return ((-b-6*c)*d*d*d + (6*b+30*c)*d*d + (-12*b-48*c)*d + (8*b + 24*c)) / 6
+	default:
+		return ((12-9*b-6*c)*d*d*d + (-18+12*b+6*c)*d*d + (6 - 2*b)) / 6
+	}
+}
+
+// filterSamplerBins is the number of steps of the table which approximates the curve of a filter
+const filterSamplerBins = 64
+
+// FilterSampler places samples more densely where a filter's weight is larger, so that samples near
+// the edge of a wide filter aren't wasted on tiny weights. Every sample then has a weight of 1,
+// or -1 in the negative lobes of a filter, which is much less noisy than weighting evenly spread samples.
+type FilterSampler struct {
+	filter Filter
+	// curve is the filter's weight in the middle of each step of the table
+	curve []float64
+	// cdf holds the running total of the absolute weight at the start of each step, from 0 to 1
+	cdf []float64
+}
+
+func NewFilterSampler(f Filter) *FilterSampler {
+	s := &FilterSampler{
+		filter: f,
+		curve:  make([]float64, filterSamplerBins),
+		cdf:    make([]float64, filterSamplerBins+1),
+	}
+
+	step := 2 * f.Radius() / filterSamplerBins
+
+	// the filter is separable, so its curve along x is enough
+	for i := range s.curve {
+		s.curve[i] = f.Weight(-f.Radius()+(float64(i)+0.5)*step, 0)
+		s.cdf[i+1] = s.cdf[i] + math.Abs(s.curve[i])
+	}
+
+	total := s.cdf[filterSamplerBins]
+	for i := range s.cdf {
+		s.cdf[i] /= total
+	}
+
+	return s
+}
+
+// Sample moves a point of the unit square to an offset from the center of the pixel, in pixels,
+// and returns the weight of a sample there. The center of the square stays in the center of the pixel.
+func (s *FilterSampler) Sample(u, v float64) (float64, float64, float64) {
+	dx, signX := s.sample1D(u)
+	dy, signY := s.sample1D(v)
+
+	return dx, dy, signX * signY
+}
+
+// sample1D returns the offset along one axis for a number from 0 to 1, and the sign of the filter there
+func (s *FilterSampler) sample1D(u float64) (float64, float64) {
+	// find the step which the running total passes u in, skipping any empty steps
+	i := sort.SearchFloat64s(s.cdf, u) - 1
+	if i < 0 {
+		i = 0
+	}
+	for i < filterSamplerBins-1 && s.curve[i] == 0 {
+		i++
+	}
+
+	t := 0.0
+	if width := s.cdf[i+1] - s.cdf[i]; width > 0 {
+		t = math.Min(1, math.Max(0, (u-s.cdf[i])/width))
+	}
+
+	r := s.filter.Radius()
+	return -r + (float64(i)+t)*2*r/filterSamplerBins, math.Copysign(1, s.curve[i])
+}
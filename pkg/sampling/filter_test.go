@@ -0,0 +1,128 @@
+package sampling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter Filter
+	}{
+		{
+			name:   "Box",
+			filter: NewBoxFilter(0.5),
+		},
+		{
+			name:   "Tent",
+			filter: NewTentFilter(1),
+		},
+		{
+			name:   "Gaussian",
+			filter: NewGaussianFilter(1.5, 2),
+		},
+		{
+			name:   "Mitchell",
+			filter: NewMitchellFilter(2, 1.0/3, 1.0/3),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.filter.Radius()
+			center := tc.filter.Weight(0, 0)
+
+			assert.Greater(t, center, 0.0)
+
+			// the weight is symmetric, never more than at the center, and 0 beyond the radius
+			for _, d := range []float64{0.1, 0.3, r * 0.6, r * 0.9} {
+				w := tc.filter.Weight(d, 0)
+				assert.LessOrEqual(t, w, center)
+				assert.Equal(t, w, tc.filter.Weight(-d, 0))
+				assert.Equal(t, w, tc.filter.Weight(0, d))
+			}
+
+			assert.Zero(t, tc.filter.Weight(r+0.01, 0))
+			assert.Zero(t, tc.filter.Weight(0, -r-0.01))
+		})
+	}
+}
+
+func TestMitchellFilter(t *testing.T) {
+	f := NewMitchellFilter(2, 1.0/3, 1.0/3)
+
+	assert.InDelta(t, 8.0/9, f.mitchell(0), 1e-12)
+	assert.InDelta(t, 1.0/18, f.mitchell(0.5), 1e-12)
+	assert.InDelta(t, 0, f.mitchell(1), 1e-12)
+
+	// the negative lobe sharpens edges
+	assert.Less(t, f.Weight(1.5, 0), 0.0)
+}
+
+func TestTentFilter(t *testing.T) {
+	f := NewTentFilter(1)
+
+	assert.Equal(t, 1.0, f.Weight(0, 0))
+	assert.Equal(t, 0.5, f.Weight(0.5, 0))
+	assert.Equal(t, 0.25, f.Weight(0.5, -0.5))
+}
+
+func TestGaussianFilter(t *testing.T) {
+	f := NewGaussianFilter(1.5, 2)
+
+	assert.InDelta(t, math.Pow(1-math.Exp(-4.5), 2), f.Weight(0, 0), 1e-12)
+	assert.Zero(t, f.Weight(1.5, 0))
+}
+
+func TestFilterSampler(t *testing.T) {
+	filters := []Filter{
+		NewBoxFilter(0.5),
+		NewTentFilter(1),
+		NewGaussianFilter(1.5, 2),
+		NewMitchellFilter(2, 1.0/3, 1.0/3),
+	}
+	for _, f := range filters {
+		s := NewFilterSampler(f)
+
+		// the center of the square is the center of the pixel, and the corners are the edges of the filter
+		dx, dy, _ := s.Sample(0.5, 0.5)
+		assert.InDelta(t, 0, dx, 1e-9)
+		assert.InDelta(t, 0, dy, 1e-9)
+
+		dx, dy, _ = s.Sample(0, 1)
+		assert.InDelta(t, -f.Radius(), dx, 1e-9)
+		assert.InDelta(t, f.Radius(), dy, 1e-9)
+
+		// the offsets keep the order of the points, so stratified points stay stratified
+		last := -f.Radius()
+		for u := 0.05; u < 1; u += 0.1 {
+			d, _, _ := s.Sample(u, 0.5)
+			assert.Greater(t, d, last)
+			last = d
+		}
+	}
+
+	// a box filter leaves the samples evenly spread
+	box := NewFilterSampler(NewBoxFilter(0.5))
+	dx, dy, w := box.Sample(0.25, 0.75)
+	assert.InDelta(t, -0.25, dx, 1e-9)
+	assert.InDelta(t, 0.25, dy, 1e-9)
+	assert.Equal(t, 1.0, w)
+
+	// a tent filter crowds the samples toward the center, so they can all have the same weight
+	tent := NewFilterSampler(NewTentFilter(1))
+	dx, _, w = tent.Sample(0.25, 0.5)
+	assert.InDelta(t, math.Sqrt(0.5)-1, dx, 0.01)
+	assert.Equal(t, 1.0, w)
+
+	// only the negative lobes of the Mitchell filter have negative weights
+	mitchell := NewFilterSampler(NewMitchellFilter(2, 1.0/3, 1.0/3))
+	_, _, w = mitchell.Sample(0.5, 0.5)
+	assert.Equal(t, 1.0, w)
+	_, _, w = mitchell.Sample(0.01, 0.5)
+	assert.Equal(t, -1.0, w)
+	_, _, w = mitchell.Sample(0.01, 0.99)
+	assert.Equal(t, 1.0, w)
+}
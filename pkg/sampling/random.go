@@ -0,0 +1,20 @@
+package sampling
+
+// Random is a repeatable stream of random numbers, for the choices made along a single path.
+// Each stream of a seed is unrelated to the others, so every sample of a pixel can have its own.
+type Random struct {
+	seed uint32
+	next uint32
+}
+
+// NewRandom returns the given stream of random numbers of a seed
+func NewRandom(seed uint32, stream int) *Random {
+	return &Random{seed: hash(seed ^ hash(uint32(stream)))}
+}
+
+// Float64 returns the next random number from 0 to 1
+func (r *Random) Float64() float64 {
+	r.next++
+
+	return toUnit(hash(r.seed ^ hash(r.next)))
+}
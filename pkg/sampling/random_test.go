@@ -0,0 +1,36 @@
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandom(t *testing.T) {
+	take := func(r *Random, n int) []float64 {
+		result := make([]float64, n)
+		for i := range result {
+			result[i] = r.Float64()
+		}
+
+		return result
+	}
+
+	first := take(NewRandom(7, 0), 64)
+
+	// the same seed and stream always give the same numbers
+	assert.Equal(t, first, take(NewRandom(7, 0), 64))
+
+	// other streams and seeds give other numbers
+	assert.NotEqual(t, first, take(NewRandom(7, 1), 64))
+	assert.NotEqual(t, first, take(NewRandom(8, 0), 64))
+
+	sum := 0.0
+	for _, x := range take(NewRandom(3, 2), 4096) {
+		assert.GreaterOrEqual(t, x, 0.0)
+		assert.Less(t, x, 1.0)
+		sum += x
+	}
+
+	assert.InDelta(t, 0.5, sum/4096, 0.02)
+}
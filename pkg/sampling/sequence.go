@@ -0,0 +1,130 @@
+package sampling
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Sequence places the samples of a pixel in the unit square
+type Sequence interface {
+	// Point returns the i-th sample of a pixel which takes n samples at a time.
+	// The seed decorrelates the samples of different pixels.
+	Point(i, n int, seed uint32) (float64, float64)
+}
+
+// Grid places the samples at the centers of the cells of a regular grid.
+// Each later batch of n samples shifts the whole grid by a random offset from the seed,
+// so adaptive sampling doesn't take the same samples again.
+type Grid struct{}
+
+func (Grid) Point(i, n int, seed uint32) (float64, float64) {
+	if i < n || n < 1 {
+		return gridPoint(i, n, 0.5, 0.5)
+	}
+
+	dx, dy := RandomPoint(i/n, hash(seed))
+	return gridPoint(i, n, dx, dy)
+}
+
+// Stratified places each sample at a random point in its own cell of a grid,
+// so the samples are random but still spread over the whole square.
+// The random points come from the seed, so each pixel's are repeatable.
+type Stratified struct{}
+
+func (Stratified) Point(i, n int, seed uint32) (float64, float64) {
+	dx, dy := RandomPoint(i, seed)
+	return gridPoint(i, n, dx, dy)
+}
+
+// gridPoint returns a point in the cell of the i-th of n samples, offset from its corner by a fraction of the cell.
+// Samples past n start again in the first cell.
+func gridPoint(i, n int, dx, dy float64) (float64, float64) {
+	if n < 1 {
+		n = 1
+	}
+	i %= n
+
+	// the cells are as close to square as n allows
+	nx := int(math.Sqrt(float64(n)))
+	ny := (n + nx - 1) / nx
+	row := i / nx
+
+	// if the last row has fewer cells, they're wider so the whole square is still covered
+	cols := nx
+	if row == ny-1 {
+		cols = n - row*nx
+	}
+
+	return (float64(i%nx) + dx) / float64(cols), (float64(row) + dy) / float64(ny)
+}
+
+// Halton is the low-discrepancy sequence with bases 2 and 3, shifted by a random offset for each pixel.
+// Each new sample fills the largest gap left by the ones before it, however many are taken.
+type Halton struct{}
+
+func (Halton) Point(i, n int, seed uint32) (float64, float64) {
+	h := hash(seed)
+
+	return frac(radicalInverse(i, 2) + toUnit(h)), frac(radicalInverse(i, 3) + toUnit(hash(h)))
+}
+
+// radicalInverse mirrors the digits of i in a base around the decimal point
+func radicalInverse(i, base int) float64 {
+	result := 0.0
+	scale := 1.0 / float64(base)
+
+	for ; i > 0; i /= base {
+		result += float64(i%base) * scale
+		scale /= float64(base)
+	}
+
+	return result
+}
+
+// Sobol is the first two dimensions of the Sobol' sequence, scrambled with random bits for each pixel.
+// Every power of two samples has exactly one sample in each cell of many different grids.
+type Sobol struct{}
+
+func (Sobol) Point(i, n int, seed uint32) (float64, float64) {
+	h := hash(seed)
+	index := uint32(i)
+
+	// the second dimension uses the direction numbers of the polynomial x + 1
+	var y uint32
+	for v := uint32(1 << 31); index != 0; index >>= 1 {
+		if index&1 != 0 {
+			y ^= v
+		}
+		v ^= v >> 1
+	}
+
+	return toUnit(bits.Reverse32(uint32(i)) ^ h), toUnit(y ^ hash(h))
+}
+
+// RandomPoint returns the i-th of a sequence of random points in the unit square.
+// The same seed always gives the same sequence, so renders don't change from run to run.
+func RandomPoint(i int, seed uint32) (float64, float64) {
+	h := hash(seed ^ hash(uint32(i)))
+
+	return toUnit(h), toUnit(hash(h))
+}
+
+// hash scrambles the bits of a number, so that nearby seeds give unrelated results
+func hash(x uint32) uint32 {
+	x ^= x >> 16
+	x *= 0x7feb352d
+	x ^= x >> 15
+	x *= 0x846ca68b
+	x ^= x >> 16
+
+	return x
+}
+
+// toUnit converts 32 random bits to a number from 0 to 1
+func toUnit(x uint32) float64 {
+	return float64(x) / (1 << 32)
+}
+
+func frac(x float64) float64 {
+	return x - math.Floor(x)
+}
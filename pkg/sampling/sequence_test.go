@@ -0,0 +1,161 @@
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// strata counts the samples in each cell of an n by n grid
+func strata(points [][2]float64, n int) []int {
+	counts := make([]int, n*n)
+	for _, p := range points {
+		counts[int(p[0]*float64(n))+int(p[1]*float64(n))*n]++
+	}
+
+	return counts
+}
+
+func TestSequences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sequence Sequence
+		// stratified sequences have one sample in each cell of a 4x4 grid
+		stratified bool
+	}{
+		{
+			name:       "Grid",
+			sequence:   Grid{},
+			stratified: true,
+		},
+		{
+			name:       "Stratified",
+			sequence:   Stratified{},
+			stratified: true,
+		},
+		{
+			name:     "Halton",
+			sequence: Halton{},
+		},
+		{
+			name:       "Sobol",
+			sequence:   Sobol{},
+			stratified: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for seed := uint32(0); seed < 20; seed++ {
+				points := make([][2]float64, 16)
+				for i := range points {
+					x, y := tc.sequence.Point(i, 16, seed)
+					assert.True(t, x >= 0 && x < 1 && y >= 0 && y < 1, "point %d is %v, %v", i, x, y)
+					points[i] = [2]float64{x, y}
+				}
+
+				counts := strata(points, 4)
+				if tc.stratified {
+					assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, counts)
+					continue
+				}
+
+				// the samples are still spread evenly along each axis
+				left, bottom := 0, 0
+				for _, p := range points {
+					if p[0] < 0.5 {
+						left++
+					}
+					if p[1] < 0.5 {
+						bottom++
+					}
+				}
+				assert.Equal(t, 8, left)
+				assert.InDelta(t, 8, bottom, 2)
+			}
+		})
+	}
+}
+
+func TestGrid(t *testing.T) {
+	// the samples are centered in their cells
+	x, y := Grid{}.Point(0, 1, 0)
+	assert.Equal(t, 0.5, x)
+	assert.Equal(t, 0.5, y)
+
+	x, y = Grid{}.Point(3, 4, 0)
+	assert.Equal(t, 0.75, x)
+	assert.Equal(t, 0.75, y)
+
+	// counts which aren't square use cells which aren't square
+	x, y = Grid{}.Point(1, 2, 0)
+	assert.Equal(t, 0.5, x)
+	assert.Equal(t, 0.75, y)
+
+	// a partly full last row has wider cells, so no part of the square is left empty
+	x, y = Grid{}.Point(4, 5, 0)
+	assert.Equal(t, 0.5, x)
+	assert.InDelta(t, 5.0/6, y, 1e-12)
+}
+
+func TestBatches(t *testing.T) {
+	batch := func(s Sequence, b, n int, seed uint32) [][2]float64 {
+		points := make([][2]float64, n)
+		for i := range points {
+			x, y := s.Point(b*n+i, n, seed)
+			points[i] = [2]float64{x, y}
+		}
+
+		return points
+	}
+
+	for _, s := range []Sequence{Grid{}, Stratified{}} {
+		first := batch(s, 0, 16, 1)
+		second := batch(s, 1, 16, 1)
+
+		// an adaptive batch takes new samples, which still have one in each cell
+		assert.NotEqual(t, first, second)
+		assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, strata(second, 4))
+		assert.NotEqual(t, second, batch(s, 2, 16, 1))
+	}
+}
+
+func TestScrambling(t *testing.T) {
+	for _, s := range []Sequence{Stratified{}, Halton{}, Sobol{}} {
+		x1, y1 := s.Point(3, 16, 1)
+		x2, y2 := s.Point(3, 16, 2)
+
+		// different pixels get different samples, but each pixel's are repeatable
+		assert.NotEqual(t, [2]float64{x1, y1}, [2]float64{x2, y2})
+
+		x3, y3 := s.Point(3, 16, 1)
+		assert.Equal(t, [2]float64{x1, y1}, [2]float64{x3, y3})
+	}
+}
+
+func TestRandomPoint(t *testing.T) {
+	var sumX, sumY float64
+	for i := 0; i < 1000; i++ {
+		x, y := RandomPoint(i, 1)
+		assert.True(t, x >= 0 && x < 1 && y >= 0 && y < 1)
+		sumX += x
+		sumY += y
+	}
+
+	assert.InDelta(t, 0.5, sumX/1000, 0.05)
+	assert.InDelta(t, 0.5, sumY/1000, 0.05)
+
+	// the same point comes back every time, and other seeds give other points
+	x1, y1 := RandomPoint(3, 1)
+	x2, y2 := RandomPoint(3, 1)
+	assert.Equal(t, [2]float64{x1, y1}, [2]float64{x2, y2})
+
+	x3, y3 := RandomPoint(3, 2)
+	assert.NotEqual(t, [2]float64{x1, y1}, [2]float64{x3, y3})
+}
+
+func TestRadicalInverse(t *testing.T) {
+	assert.Equal(t, 0.5, radicalInverse(1, 2))
+	assert.Equal(t, 0.75, radicalInverse(3, 2))
+	assert.Equal(t, 0.125, radicalInverse(4, 2))
+	assert.InDelta(t, 1.0/3+1.0/9, radicalInverse(4, 3), 1e-12)
+}
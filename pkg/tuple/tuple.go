@@ -100,3 +100,33 @@ func (t *Tuple) Fmt() string {
 func (t *Tuple) Reflect(n *Tuple) *Tuple {
 	return t.Sub(n.Mult(2 * t.DotProd(n)))
 }
+
+// Basis returns two unit vectors which are perpendicular to each other and to t,
+// which must be a unit vector.
+func (t *Tuple) Basis() (*Tuple, *Tuple) {
+	// cross with whichever axis is least parallel to t
+	helper := Right
+	if math.Abs(t.X) > 0.9 {
+		helper = Backward
+	}
+
+	u := t.CrossProd(helper).Norm()
+
+	return u, t.CrossProd(u)
+}
+
+// CosineSample returns a random unit vector in the hemisphere around t, which must be a unit vector.
+// Directions are more likely to point along t, in proportion to the cosine of their angle.
+// The random numbers from 0 to 1 come from random.
+func (t *Tuple) CosineSample(random func() float64) *Tuple {
+	u, v := t.Basis()
+
+	r := math.Sqrt(random())
+	theta := 2 * math.Pi * random()
+
+	x := r * math.Cos(theta)
+	y := r * math.Sin(theta)
+	z := math.Sqrt(util.Max(0, 1-x*x-y*y))
+
+	return u.Mult(x).Add(v.Mult(y)).Add(t.Mult(z)).Norm()
+}
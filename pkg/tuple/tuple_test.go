@@ -2,6 +2,7 @@ package tuple
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -228,3 +229,35 @@ func TestIsVector(t *testing.T) {
 func TestFmt(t *testing.T) {
 	assert.Equal(t, "X: 1.000000, Y: 2.000000, Z:3.000000, W:1.000000", (&Tuple{1, 2, 3, 1}).Fmt())
 }
+
+func TestBasis(t *testing.T) {
+	for _, n := range []*Tuple{Up, Right, Forward, NewVector(1, 2, 3).Norm(), NewVector(-0.95, 0.1, 0.2).Norm()} {
+		u, v := n.Basis()
+
+		assert.InDelta(t, 1, u.Mag(), 1e-9)
+		assert.InDelta(t, 1, v.Mag(), 1e-9)
+		assert.InDelta(t, 0, u.DotProd(n), 1e-9)
+		assert.InDelta(t, 0, v.DotProd(n), 1e-9)
+		assert.InDelta(t, 0, u.DotProd(v), 1e-9)
+	}
+}
+
+func TestCosineSample(t *testing.T) {
+	n := NewVector(1, 2, 3).Norm()
+	random := rand.New(rand.NewSource(1)).Float64
+
+	const count = 10000
+	sum := 0.0
+
+	for i := 0; i < count; i++ {
+		d := n.CosineSample(random)
+
+		assert.InDelta(t, 1, d.Mag(), 1e-9)
+		assert.GreaterOrEqual(t, d.DotProd(n), 0.0)
+
+		sum += d.DotProd(n)
+	}
+
+	// the mean cosine of a cosine-weighted hemisphere is 2/3
+	assert.InDelta(t, 2.0/3.0, sum/count, 0.02)
+}